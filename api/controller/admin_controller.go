@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminController struct {
+	AdminUsecase domain.AdminUsecase
+	AuditUsecase domain.AuditUsecase
+	AuditLogger  domain.AuditLogger
+	Env          *bootstrap.Env
+}
+
+func (ac *AdminController) FetchUsers(c *gin.Context) {
+	var query domain.UserQuery
+
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	page, err := ac.AdminUsecase.FetchUsers(c, &query)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (ac *AdminController) GetUser(c *gin.Context) {
+	user, err := ac.AdminUsecase.GetUser(c, c.Param("id"))
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (ac *AdminController) FetchActivity(c *gin.Context) {
+	var query domain.AuditQuery
+
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	query.UserID = c.Param("id")
+
+	page, err := ac.AuditUsecase.Fetch(c, &query)
+	if err != nil {
+		respondAuditError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (ac *AdminController) DisableUser(c *gin.Context) {
+	userID := c.Param("id")
+	err := ac.AdminUsecase.DisableUser(c, userID, ac.Env.AccessTokenExpiryHour)
+	audit(c, ac.AuditLogger, domain.AuditActionAccountDisable, userID, err, nil)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Account disabled"})
+}
+
+func (ac *AdminController) EnableUser(c *gin.Context) {
+	userID := c.Param("id")
+	err := ac.AdminUsecase.EnableUser(c, userID)
+	audit(c, ac.AuditLogger, domain.AuditActionAccountEnable, userID, err, nil)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Account enabled"})
+}
+
+func (ac *AdminController) ForceLogout(c *gin.Context) {
+	userID := c.Param("id")
+	err := ac.AdminUsecase.ForceLogout(c, userID, ac.Env.AccessTokenExpiryHour)
+	audit(c, ac.AuditLogger, domain.AuditActionForceLogout, userID, err, nil)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Signed out of all sessions"})
+}
+
+func (ac *AdminController) ResetTwoFactor(c *gin.Context) {
+	userID := c.Param("id")
+	err := ac.AdminUsecase.ResetTwoFactor(c, userID)
+	audit(c, ac.AuditLogger, domain.AuditActionTwoFactorReset, userID, err, nil)
+	if err != nil {
+		respondAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Two-factor authentication reset"})
+}
+
+func respondAdminError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain.ErrInvalidUserCursor):
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+	}
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditController struct {
+	AuditUsecase domain.AuditUsecase
+}
+
+func (ac *AuditController) Fetch(c *gin.Context) {
+	var query domain.AuditQuery
+
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	page, err := ac.AuditUsecase.FetchByUserID(c, c.GetString("x-user-id"), &query)
+	if err != nil {
+		respondAuditError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (ac *AuditController) FetchAll(c *gin.Context) {
+	var query domain.AuditQuery
+
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	page, err := ac.AuditUsecase.Fetch(c, &query)
+	if err != nil {
+		respondAuditError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func respondAuditError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidAuditCursor):
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+	}
+}
+
+// audit records the outcome of an action on the account of userID, taken by
+// the authenticated user of the request, if any.
+func audit(c *gin.Context, logger domain.AuditLogger, action string, userID string, err error, details map[string]string) {
+	event := domain.AuditEvent{
+		Action:    action,
+		Outcome:   domain.AuditOutcomeSuccess,
+		UserID:    userID,
+		ActorID:   c.GetString("x-user-id"),
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		Details:   details,
+	}
+	if event.ActorID == "" {
+		event.ActorID = userID
+	}
+	if err != nil {
+		event.Outcome = domain.AuditOutcomeFailure
+		event.Reason = auditReason(err)
+	}
+	logger.Log(event)
+}
+
+// auditedErrors are the failures of audited actions that are recorded with
+// their message.
+var auditedErrors = []error{
+	domain.ErrInvalidCredentials,
+	domain.ErrLoginLocked,
+	domain.ErrAccountDisabled,
+	domain.ErrInvalidChallengeToken,
+	domain.ErrInvalidTwoFactorCode,
+	domain.ErrInvalidOIDCState,
+	domain.ErrOIDCEmailNotVerified,
+	domain.ErrOIDCAccountExists,
+	domain.ErrOIDCProviderRejected,
+	domain.ErrInvalidOIDCIdentity,
+	domain.ErrInvalidRefreshToken,
+	domain.ErrRefreshTokenNotFound,
+	domain.ErrRefreshTokenRevoked,
+	domain.ErrRefreshTokenReused,
+	domain.ErrInvalidResetToken,
+	domain.ErrPasswordRejected,
+	domain.ErrIncorrectPassword,
+	domain.ErrReauthenticationRequired,
+	domain.ErrEmailTaken,
+	domain.ErrOutstandingBalance,
+	domain.ErrTwoFactorNotEnrolled,
+	domain.ErrTwoFactorAlreadyEnabled,
+	domain.ErrTwoFactorNotEnabled,
+	domain.ErrSessionNotFound,
+	domain.ErrPersonalAccessTokenNotFound,
+	domain.ErrUnknownScope,
+	domain.ErrForbidden,
+	domain.ErrUserNotFound,
+	domain.ErrGroupMemberNotFound,
+	domain.ErrGroupMemberExists,
+	domain.ErrUnknownGroupRole,
+	domain.ErrGroupOwnerImmutable,
+}
+
+func auditReason(err error) string {
+	for _, target := range auditedErrors {
+		if errors.Is(err, target) {
+			return err.Error()
+		}
+	}
+	return domain.AuditReasonInternal
+}
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/gin-gonic/gin"
+)
+
+func tokenCookieMode(c *gin.Context) bool {
+	return c.Query("mode") == domain.TokenModeCookie
+}
+
+// respondWithTokenCookies sets the tokens as cookies that scripts cannot
+// read, along with a CSRF token that they can.
+func respondWithTokenCookies(c *gin.Context, env *bootstrap.Env, accessToken string, refreshToken string) {
+	csrfToken, _, err := tokenutil.NewOpaqueToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	refreshMaxAge := env.RefreshTokenExpiryHour * 3600
+	setAuthCookie(c, env, domain.AccessTokenCookie, accessToken, env.AccessTokenExpiryHour*3600, true)
+	setAuthCookie(c, env, domain.RefreshTokenCookie, refreshToken, refreshMaxAge, true)
+	setAuthCookie(c, env, domain.CSRFTokenCookie, csrfToken, refreshMaxAge, false)
+
+	c.JSON(http.StatusOK, domain.CookieLoginResponse{CSRFToken: csrfToken})
+}
+
+func clearTokenCookies(c *gin.Context, env *bootstrap.Env) {
+	setAuthCookie(c, env, domain.AccessTokenCookie, "", -1, true)
+	setAuthCookie(c, env, domain.RefreshTokenCookie, "", -1, true)
+	setAuthCookie(c, env, domain.CSRFTokenCookie, "", -1, false)
+}
+
+func setAuthCookie(c *gin.Context, env *bootstrap.Env, name string, value string, maxAge int, httpOnly bool) {
+	c.SetSameSite(http.SameSiteStrictMode)
+	c.SetCookie(name, value, maxAge, "/", env.AuthCookieDomain, env.AppEnv != "development", httpOnly)
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type DataExportController struct {
+	DataExportUsecase domain.DataExportUsecase
+}
+
+func (dc *DataExportController) Create(c *gin.Context) {
+	export, err := dc.DataExportUsecase.RequestExport(c, c.GetString("x-user-id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, export)
+}
+
+func (dc *DataExportController) Fetch(c *gin.Context) {
+	export, err := dc.DataExportUsecase.GetExport(c, c.GetString("x-user-id"), c.Param("id"))
+	if err != nil {
+		respondDataExportError(c, err)
+		return
+	}
+
+	if export.Status == domain.DataExportStatusReady {
+		export.DownloadURL = c.Request.URL.Path + "/download"
+	}
+	c.JSON(http.StatusOK, export)
+}
+
+func (dc *DataExportController) Download(c *gin.Context) {
+	archive, err := dc.DataExportUsecase.OpenExport(c, c.GetString("x-user-id"), c.Param("id"))
+	if err != nil {
+		respondDataExportError(c, err)
+		return
+	}
+	defer archive.Close()
+
+	c.Header("Content-Disposition", `attachment; filename="data-export.zip"`)
+	c.Header("Content-Type", "application/zip")
+	c.Status(http.StatusOK)
+	_, _ = io.Copy(c.Writer, archive)
+}
+
+func respondDataExportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrDataExportNotFound):
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain.ErrDataExportNotReady):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+	}
+}
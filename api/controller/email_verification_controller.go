@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type EmailVerificationController struct {
+	EmailVerificationUsecase domain.EmailVerificationUsecase
+	Env                      *bootstrap.Env
+}
+
+func (ec *EmailVerificationController) Verify(c *gin.Context) {
+	var request domain.VerifyEmailRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = ec.EmailVerificationUsecase.Verify(c, request.Token)
+	if errors.Is(err, domain.ErrInvalidVerificationToken) {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if errors.Is(err, domain.ErrEmailTaken) {
+		c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Email verified"})
+}
+
+func (ec *EmailVerificationController) Resend(c *gin.Context) {
+	userID := c.GetString("x-user-id")
+
+	err := ec.EmailVerificationUsecase.Resend(c, userID, ec.Env.EmailVerificationURL, ec.Env.EmailVerificationTokenExpiryMinute, ec.Env.EmailVerificationResendLimit)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, domain.ErrEmailAlreadyVerified):
+			status = http.StatusConflict
+		case errors.Is(err, domain.ErrTooManyRequests):
+			status = http.StatusTooManyRequests
+		}
+		c.JSON(status, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Verification email sent"})
+}
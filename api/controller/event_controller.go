@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+const eventStreamHeartbeat = 15 * time.Second
+
+type EventController struct {
+	EventBroker domain.EventBroker
+}
+
+func (ec *EventController) Stream(c *gin.Context) {
+	groupID := c.Param("groupId")
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		// EventSource polyfills that cannot set headers pass it as a query parameter.
+		lastEventID = c.Query("lastEventId")
+	}
+
+	subscription, unsubscribe := ec.EventBroker.Subscribe(groupID, lastEventID)
+	defer unsubscribe()
+
+	c.Header("Content-Type", sse.ContentType)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if !subscription.Complete {
+		c.Render(-1, sse.Event{Event: domain.EventTypeResync, Data: gin.H{"groupID": groupID}})
+	}
+	for _, event := range subscription.Backlog {
+		renderEvent(c, event)
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return false
+			}
+			renderEvent(c, event)
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		}
+	})
+}
+
+func renderEvent(c *gin.Context, event domain.Event) {
+	c.Render(-1, sse.Event{
+		Id:    event.ID,
+		Event: event.Type,
+		Data:  event,
+	})
+}
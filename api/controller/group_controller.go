@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type GroupController struct {
+	GroupUsecase domain.GroupUsecase
+	AuditLogger  domain.AuditLogger
+}
+
+func (gc *GroupController) Create(c *gin.Context) {
+	var request domain.GroupRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	userID, err := primitive.ObjectIDFromHex(c.GetString("x-user-id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	group := domain.Group{
+		ID:        primitive.NewObjectID(),
+		Name:      request.Name,
+		CreatedBy: userID,
+	}
+
+	err = gc.GroupUsecase.Create(c, &group)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
+
+func (gc *GroupController) FetchMembers(c *gin.Context) {
+	members, err := gc.GroupUsecase.FetchMembers(c, c.Param("groupId"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+func (gc *GroupController) AddMember(c *gin.Context) {
+	var request domain.GroupMemberRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	actor := c.MustGet("x-group-member").(domain.GroupMember)
+	member, err := gc.GroupUsecase.AddMember(c, &actor, request.UserID, request.Role)
+	audit(c, gc.AuditLogger, domain.AuditActionGroupRoleChange, request.UserID, err, map[string]string{"groupID": actor.GroupID.Hex(), "role": request.Role})
+	if err != nil {
+		respondGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+func (gc *GroupController) UpdateMemberRole(c *gin.Context) {
+	var request domain.GroupMemberRoleRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	actor := c.MustGet("x-group-member").(domain.GroupMember)
+	err = gc.GroupUsecase.UpdateMemberRole(c, &actor, c.Param("userId"), request.Role)
+	audit(c, gc.AuditLogger, domain.AuditActionGroupRoleChange, c.Param("userId"), err, map[string]string{"groupID": actor.GroupID.Hex(), "role": request.Role})
+	if err != nil {
+		respondGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Role updated"})
+}
+
+func (gc *GroupController) RemoveMember(c *gin.Context) {
+	actor := c.MustGet("x-group-member").(domain.GroupMember)
+	err := gc.GroupUsecase.RemoveMember(c, &actor, c.Param("userId"))
+	audit(c, gc.AuditLogger, domain.AuditActionGroupRoleChange, c.Param("userId"), err, map[string]string{"groupID": actor.GroupID.Hex()})
+	if err != nil {
+		respondGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Member removed"})
+}
+
+func (gc *GroupController) SetLateFee(c *gin.Context) {
+	var request domain.LateFeeRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	actor := c.MustGet("x-group-member").(domain.GroupMember)
+	group, err := gc.GroupUsecase.SetLateFee(c, &actor, &domain.LateFeePolicy{
+		Type:        request.Type,
+		Amount:      request.Amount,
+		DailyRateBP: request.DailyRateBP,
+		GraceDays:   request.GraceDays,
+	})
+	if err != nil {
+		respondGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+func (gc *GroupController) RemoveLateFee(c *gin.Context) {
+	actor := c.MustGet("x-group-member").(domain.GroupMember)
+	group, err := gc.GroupUsecase.SetLateFee(c, &actor, nil)
+	if err != nil {
+		respondGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+func (gc *GroupController) Close(c *gin.Context) {
+	actor := c.MustGet("x-group-member").(domain.GroupMember)
+	group, err := gc.GroupUsecase.Close(c, &actor)
+	if err != nil {
+		respondGroupError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+func respondGroupError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrForbidden), errors.Is(err, domain.ErrGroupOwnerImmutable):
+		status = http.StatusForbidden
+	case errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrGroupMemberNotFound), errors.Is(err, domain.ErrGroupNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrGroupMemberExists), errors.Is(err, domain.ErrGroupClosed), errors.Is(err, domain.ErrGroupWalletChanged):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrUnknownGroupRole), errors.Is(err, domain.ErrInvalidLateFee):
+		status = http.StatusBadRequest
+	}
+	c.JSON(status, domain.ErrorResponse{Message: err.Error()})
+}
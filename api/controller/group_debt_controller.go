@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type GroupDebtController struct {
+	GroupDebtUsecase domain.GroupDebtUsecase
+}
+
+func (dc *GroupDebtController) Create(c *gin.Context) {
+	var request domain.GroupDebtRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	actor := c.MustGet("x-group-member").(domain.GroupMember)
+	debt, err := dc.GroupDebtUsecase.Create(c, &actor, &request)
+	if err != nil {
+		respondGroupDebtError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, debt)
+}
+
+func (dc *GroupDebtController) Fetch(c *gin.Context) {
+	debts, err := dc.GroupDebtUsecase.FetchByGroupID(c, c.Param("groupId"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, debts)
+}
+
+func (dc *GroupDebtController) FetchLedger(c *gin.Context) {
+	entries, err := dc.GroupDebtUsecase.FetchLedger(c, c.Param("groupId"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+func (dc *GroupDebtController) RecordPayment(c *gin.Context) {
+	var request domain.GroupPaymentRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	actor := c.MustGet("x-group-member").(domain.GroupMember)
+	debt, err := dc.GroupDebtUsecase.RecordPayment(c, &actor, c.Param("debtId"), request.Amount)
+	if err != nil {
+		respondGroupDebtError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, debt)
+}
+
+func (dc *GroupDebtController) WaiveCharge(c *gin.Context) {
+	actor := c.MustGet("x-group-member").(domain.GroupMember)
+	debt, err := dc.GroupDebtUsecase.WaiveCharge(c, &actor, c.Param("debtId"), c.Param("entryId"))
+	if err != nil {
+		respondGroupDebtError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, debt)
+}
+
+func respondGroupDebtError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrForbidden), errors.Is(err, domain.ErrGroupDebtNotCreditor):
+		status = http.StatusForbidden
+	case errors.Is(err, domain.ErrGroupDebtNotFound), errors.Is(err, domain.ErrGroupChargeNotFound), errors.Is(err, domain.ErrGroupNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrGroupDebtSelf),
+		errors.Is(err, domain.ErrGroupDebtParticipant),
+		errors.Is(err, domain.ErrGroupPaymentExceedsBalance):
+		status = http.StatusBadRequest
+	case errors.Is(err, domain.ErrGroupClosed),
+		errors.Is(err, domain.ErrGroupDebtChanged),
+		errors.Is(err, domain.ErrGroupChargeWaived),
+		errors.Is(err, domain.ErrGroupChargePaid):
+		status = http.StatusConflict
+	}
+	c.JSON(status, domain.ErrorResponse{Message: err.Error()})
+}
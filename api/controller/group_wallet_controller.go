@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type GroupWalletController struct {
+	GroupWalletUsecase domain.GroupWalletUsecase
+}
+
+func (wc *GroupWalletController) Open(c *gin.Context) {
+	var request domain.GroupWalletRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	actor := c.MustGet("x-group-member").(domain.GroupMember)
+	wallet, err := wc.GroupWalletUsecase.Open(c, &actor, request.Currency)
+	if err != nil {
+		respondGroupWalletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, wallet)
+}
+
+func (wc *GroupWalletController) Fetch(c *gin.Context) {
+	summary, err := wc.GroupWalletUsecase.GetSummary(c, c.Param("groupId"))
+	if err != nil {
+		respondGroupWalletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+func (wc *GroupWalletController) Contribute(c *gin.Context) {
+	var request domain.WalletContributionRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	actor := c.MustGet("x-group-member").(domain.GroupMember)
+	entry, err := wc.GroupWalletUsecase.Contribute(c, &actor, request.Amount)
+	if err != nil {
+		respondGroupWalletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+func (wc *GroupWalletController) AddExpense(c *gin.Context) {
+	var request domain.WalletExpenseRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	actor := c.MustGet("x-group-member").(domain.GroupMember)
+	entry, err := wc.GroupWalletUsecase.AddExpense(c, &actor, &request)
+	if err != nil {
+		respondGroupWalletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+func respondGroupWalletError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrGroupWalletNotFound), errors.Is(err, domain.ErrGroupNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrWalletParticipant),
+		errors.Is(err, domain.ErrGroupWalletInsufficient):
+		status = http.StatusBadRequest
+	case errors.Is(err, domain.ErrGroupWalletExists),
+		errors.Is(err, domain.ErrGroupWalletClosed),
+		errors.Is(err, domain.ErrGroupClosed),
+		errors.Is(err, domain.ErrGroupWalletChanged):
+		status = http.StatusConflict
+	}
+	c.JSON(status, domain.ErrorResponse{Message: err.Error()})
+}
@@ -0,0 +1,17 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSController struct {
+	TokenManager *tokenutil.TokenManager
+}
+
+func (jc *JWKSController) Fetch(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, jc.TokenManager.JWKS())
+}
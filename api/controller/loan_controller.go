@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/loanutil"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LoanController struct {
+	LoanUsecase domain.LoanUsecase
+}
+
+func (lc *LoanController) Create(c *gin.Context) {
+	var request domain.LoanRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	userID, err := primitive.ObjectIDFromHex(c.GetString("x-user-id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	counterpartyID, err := primitive.ObjectIDFromHex(request.CounterpartyID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid counterpartyID"})
+		return
+	}
+
+	installments, err := loanutil.Schedule(request.ScheduleType, request.Principal, request.AnnualInterestRateBP, request.Installments, request.FirstDueDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	loan := domain.Loan{
+		ID:                   primitive.NewObjectID(),
+		LenderID:             userID,
+		BorrowerID:           counterpartyID,
+		Principal:            request.Principal,
+		Currency:             request.Currency,
+		AnnualInterestRateBP: request.AnnualInterestRateBP,
+		ScheduleType:         request.ScheduleType,
+		Installments:         installments,
+		CreatedBy:            userID,
+	}
+	if request.Role == "borrower" {
+		loan.LenderID, loan.BorrowerID = counterpartyID, userID
+	}
+
+	err = lc.LoanUsecase.Create(c, &loan)
+	if err != nil {
+		respondLoanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loan)
+}
+
+func (lc *LoanController) Fetch(c *gin.Context) {
+	userID := c.GetString("x-user-id")
+
+	loans, err := lc.LoanUsecase.FetchByUserID(c, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, loans)
+}
+
+func (lc *LoanController) FetchByID(c *gin.Context) {
+	userID := c.GetString("x-user-id")
+
+	loan, err := lc.LoanUsecase.GetByID(c, c.Param("id"), userID)
+	if err != nil {
+		respondLoanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loan)
+}
+
+func (lc *LoanController) Confirm(c *gin.Context) {
+	userID := c.GetString("x-user-id")
+
+	loan, err := lc.LoanUsecase.Confirm(c, c.Param("id"), userID)
+	if err != nil {
+		respondLoanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loan)
+}
+
+func (lc *LoanController) Decline(c *gin.Context) {
+	userID := c.GetString("x-user-id")
+
+	loan, err := lc.LoanUsecase.Decline(c, c.Param("id"), userID)
+	if err != nil {
+		respondLoanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loan)
+}
+
+func (lc *LoanController) AddRepayment(c *gin.Context) {
+	var request domain.RepaymentRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	userID := c.GetString("x-user-id")
+
+	repayment := domain.Repayment{
+		Amount: request.Amount,
+		PaidAt: request.PaidAt,
+		Note:   request.Note,
+	}
+
+	loan, err := lc.LoanUsecase.AddRepayment(c, c.Param("id"), userID, repayment)
+	if err != nil {
+		respondLoanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loan)
+}
+
+func (lc *LoanController) Balance(c *gin.Context) {
+	userID := c.GetString("x-user-id")
+
+	balance, err := lc.LoanUsecase.GetBalance(c, c.Param("id"), userID)
+	if err != nil {
+		respondLoanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, balance)
+}
+
+func respondLoanError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrLoanNotFound),
+		errors.Is(err, domain.ErrUserNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrLoanSelf),
+		errors.Is(err, domain.ErrRepaymentExceedsBalance):
+		status = http.StatusBadRequest
+	case errors.Is(err, domain.ErrLoanNotLender):
+		status = http.StatusForbidden
+	case errors.Is(err, domain.ErrLoanNotPending),
+		errors.Is(err, domain.ErrLoanNotActive),
+		errors.Is(err, domain.ErrLoanAlreadyConfirmed),
+		errors.Is(err, domain.ErrLoanChanged):
+		status = http.StatusConflict
+	}
+	c.JSON(status, domain.ErrorResponse{Message: err.Error()})
+}
@@ -1,18 +1,34 @@
 package controller
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
 	"net/http"
-
-	"golang.org/x/crypto/bcrypt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	oidcStateCookie = "oidc_state"
+	// oidcModeCookie carries the token mode across the provider redirect.
+	oidcModeCookie  = "oidc_mode"
+	oidcStateExpiry = 10 * time.Minute
+)
+
 type LoginController struct {
-	LoginUsecase domain.LoginUsecase
-	Env          *bootstrap.Env
+	LoginUsecase     domain.LoginUsecase
+	TwoFactorUsecase domain.TwoFactorUsecase
+	OIDCUsecase      domain.OIDCUsecase
+	AuditLogger      domain.AuditLogger
+	Env              *bootstrap.Env
 }
 
 func (lc *LoginController) Login(c *gin.Context) {
@@ -24,29 +40,174 @@ func (lc *LoginController) Login(c *gin.Context) {
 		return
 	}
 
-	user, err := lc.LoginUsecase.GetUserByEmail(c, request.Email)
+	user, err := lc.LoginUsecase.Authenticate(c, request.Email, request.Password, c.ClientIP())
+	if err != nil {
+		// Attempts on an unknown account only keep a keyed hash of the email,
+		// which still links attempts on the same address but cannot be
+		// reversed by hashing candidate addresses without the secret.
+		details := map[string]string{"method": "password"}
+		var invalid *domain.InvalidCredentialsError
+		userID := ""
+		if errors.As(err, &invalid) {
+			userID = invalid.UserID
+		}
+		if userID == "" {
+			mac := hmac.New(sha256.New, []byte(lc.Env.AuditHashSecret))
+			mac.Write([]byte(strings.ToLower(request.Email)))
+			details["emailHash"] = hex.EncodeToString(mac.Sum(nil))
+		}
+		audit(c, lc.AuditLogger, domain.AuditActionLogin, userID, err, details)
+
+		var locked *domain.LoginLockedError
+		switch {
+		case errors.As(err, &locked):
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, domain.ErrorResponse{Message: err.Error()})
+		case errors.Is(err, domain.ErrInvalidCredentials):
+			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
+		case errors.Is(err, domain.ErrAccountDisabled):
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	audit(c, lc.AuditLogger, domain.AuditActionLogin, user.ID.Hex(), nil, map[string]string{"method": "password"})
+
+	lc.respondWithLogin(c, &user, tokenCookieMode(c))
+}
+
+// OIDCLogin redirects to the OpenID Connect provider. The state is also set
+// as a cookie to bind the callback to this browser.
+func (lc *LoginController) OIDCLogin(c *gin.Context) {
+	authURL, state, err := lc.OIDCUsecase.Begin(c, oidcStateExpiry)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, state, int(oidcStateExpiry.Seconds()), "/login/oidc", "", lc.Env.AppEnv != "development", true)
+	if tokenCookieMode(c) {
+		c.SetCookie(oidcModeCookie, domain.TokenModeCookie, int(oidcStateExpiry.Seconds()), "/login/oidc", "", lc.Env.AppEnv != "development", true)
+	}
+	c.Redirect(http.StatusFound, authURL)
+}
+
+func (lc *LoginController) OIDCCallback(c *gin.Context) {
+	if providerError := c.Query("error"); providerError != "" {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: domain.ErrOIDCProviderRejected.Error() + ": " + providerError})
+		return
+	}
+
+	state := c.Query("state")
+	cookie, err := c.Cookie(oidcStateCookie)
+	if err != nil || state == "" || cookie != state {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: domain.ErrInvalidOIDCState.Error()})
+		return
+	}
+	c.SetCookie(oidcStateCookie, "", -1, "/login/oidc", "", lc.Env.AppEnv != "development", true)
+	mode, _ := c.Cookie(oidcModeCookie)
+	if mode != "" {
+		c.SetCookie(oidcModeCookie, "", -1, "/login/oidc", "", lc.Env.AppEnv != "development", true)
+	}
+
+	user, err := lc.OIDCUsecase.Complete(c, state, c.Query("code"))
 	if err != nil {
-		c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: "User not found with the given email"})
+		audit(c, lc.AuditLogger, domain.AuditActionLogin, "", err, map[string]string{"method": "oidc"})
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, domain.ErrInvalidOIDCState):
+			status = http.StatusBadRequest
+		case errors.Is(err, domain.ErrOIDCProviderRejected),
+			errors.Is(err, domain.ErrInvalidOIDCIdentity),
+			errors.Is(err, domain.ErrOIDCEmailNotVerified):
+			status = http.StatusUnauthorized
+		case errors.Is(err, domain.ErrOIDCAccountExists):
+			status = http.StatusConflict
+		case errors.Is(err, domain.ErrAccountDisabled):
+			status = http.StatusForbidden
+		}
+		c.JSON(status, domain.ErrorResponse{Message: err.Error()})
 		return
 	}
+	audit(c, lc.AuditLogger, domain.AuditActionLogin, user.ID.Hex(), nil, map[string]string{"method": "oidc"})
+
+	lc.respondWithLogin(c, &user, mode == domain.TokenModeCookie)
+}
+
+// respondWithLogin answers with a challenge if the user has enabled
+// two-factor authentication and with the token pair otherwise. Clients in
+// cookie mode answer the challenge with ?mode=cookie too.
+func (lc *LoginController) respondWithLogin(c *gin.Context, user *domain.User, cookieMode bool) {
+	if user.TwoFactor != nil && user.TwoFactor.EnabledAt != nil {
+		challengeToken, err := lc.TwoFactorUsecase.CreateChallengeToken(user, lc.Env.RefreshTokenSecret, lc.Env.TwoFactorChallengeExpiryMinute)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+			return
+		}
 
-	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password)) != nil {
-		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "Invalid credentials"})
+		c.JSON(http.StatusOK, domain.LoginChallengeResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    challengeToken,
+		})
 		return
 	}
 
-	accessToken, err := lc.LoginUsecase.CreateAccessToken(&user, lc.Env.AccessTokenSecret, lc.Env.AccessTokenExpiryHour)
+	lc.respondWithTokens(c, user, cookieMode)
+}
+
+// LoginTwoFactor completes a login that was answered with a challenge.
+func (lc *LoginController) LoginTwoFactor(c *gin.Context) {
+	var request domain.LoginTwoFactorRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	user, err := lc.TwoFactorUsecase.VerifyChallenge(c, request.ChallengeToken, request.Code, lc.Env.RefreshTokenSecret)
+	if err != nil {
+		audit(c, lc.AuditLogger, domain.AuditActionLoginTwoFactor, "", err, nil)
+		var locked *domain.LoginLockedError
+		switch {
+		case errors.As(err, &locked):
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, domain.ErrorResponse{Message: err.Error()})
+		case errors.Is(err, domain.ErrInvalidChallengeToken),
+			errors.Is(err, domain.ErrInvalidTwoFactorCode):
+			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
+		case errors.Is(err, domain.ErrAccountDisabled):
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		}
+		return
+	}
+	audit(c, lc.AuditLogger, domain.AuditActionLoginTwoFactor, user.ID.Hex(), nil, nil)
+
+	lc.respondWithTokens(c, &user, tokenCookieMode(c))
+}
+
+func (lc *LoginController) respondWithTokens(c *gin.Context, user *domain.User, cookieMode bool) {
+	refreshToken, sessionID, err := lc.LoginUsecase.CreateRefreshToken(c, user, sessionClient(c), lc.Env.RefreshTokenSecret, lc.Env.RefreshTokenExpiryHour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
 		return
 	}
 
-	refreshToken, err := lc.LoginUsecase.CreateRefreshToken(&user, lc.Env.RefreshTokenSecret, lc.Env.RefreshTokenExpiryHour)
+	accessToken, err := lc.LoginUsecase.CreateAccessToken(user, sessionID, lc.Env.AccessTokenSecret, lc.Env.AccessTokenExpiryHour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
 		return
 	}
 
+	if cookieMode {
+		respondWithTokenCookies(c, lc.Env, accessToken, refreshToken)
+		return
+	}
+
 	loginResponse := domain.LoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
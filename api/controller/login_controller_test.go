@@ -0,0 +1,75 @@
+package controller_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLoginAudit(t *testing.T) {
+	login := func(err error) domain.AuditEvent {
+		mockLoginUsecase := new(mocks.LoginUsecase)
+		mockLoginUsecase.On("Authenticate", mock.Anything, "Test@gmail.com", "password", mock.Anything).Return(domain.User{}, err).Once()
+
+		var event domain.AuditEvent
+		mockAuditLogger := new(mocks.AuditLogger)
+		mockAuditLogger.On("Log", mock.Anything).Run(func(args mock.Arguments) {
+			event = args.Get(0).(domain.AuditEvent)
+		}).Return().Once()
+
+		lc := &controller.LoginController{
+			LoginUsecase: mockLoginUsecase,
+			AuditLogger:  mockAuditLogger,
+			Env:          &bootstrap.Env{AuditHashSecret: "secret"},
+		}
+
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.POST("/login", lc.Login)
+
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader("email=Test@gmail.com&password=password"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		mockAuditLogger.AssertExpectations(t)
+		return event
+	}
+
+	t.Run("unknown account", func(t *testing.T) {
+		event := login(&domain.InvalidCredentialsError{})
+
+		assert.Equal(t, domain.ErrInvalidCredentials.Error(), event.Reason)
+		assert.NotContains(t, event.Details, "email")
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte("test@gmail.com"))
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), event.Details["emailHash"])
+	})
+
+	t.Run("known account", func(t *testing.T) {
+		event := login(&domain.InvalidCredentialsError{UserID: "user"})
+
+		assert.Equal(t, "user", event.UserID)
+		assert.NotContains(t, event.Details, "email")
+		assert.NotContains(t, event.Details, "emailHash")
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		event := login(errors.New("connection() error occurred during connection handshake: dial tcp 10.0.0.5:27017"))
+
+		assert.Equal(t, domain.AuditOutcomeFailure, event.Outcome)
+		assert.Equal(t, domain.AuditReasonInternal, event.Reason)
+	})
+}
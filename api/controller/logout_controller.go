@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type LogoutController struct {
+	LogoutUsecase domain.LogoutUsecase
+	AuditLogger   domain.AuditLogger
+	Env           *bootstrap.Env
+}
+
+func (lc *LogoutController) Logout(c *gin.Context) {
+	var request domain.LogoutRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	userID := c.GetString("x-user-id")
+	tokenID := c.GetString("x-token-id")
+	expiresAt := c.GetTime("x-token-expires-at")
+
+	// Without an Authorization header the request was authenticated by the
+	// token cookies.
+	cookieMode := c.GetHeader("Authorization") == ""
+	if cookieMode && request.RefreshToken == "" {
+		request.RefreshToken, _ = c.Cookie(domain.RefreshTokenCookie)
+	}
+
+	var refreshClaims *domain.JwtCustomRefreshClaims
+	if request.RefreshToken != "" {
+		refreshClaims, err = lc.LogoutUsecase.ExtractRefreshClaimsFromToken(request.RefreshToken, lc.Env.RefreshTokenSecret)
+		if err != nil || refreshClaims.ID != userID {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Invalid refresh token"})
+			return
+		}
+	}
+
+	err = lc.LogoutUsecase.Logout(c, userID, tokenID, c.GetString("x-session-id"), expiresAt, refreshClaims, lc.Env.AccessTokenExpiryHour)
+	audit(c, lc.AuditLogger, domain.AuditActionLogout, userID, err, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if cookieMode {
+		clearTokenCookies(c, lc.Env)
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Logged out"})
+}
+
+func (lc *LogoutController) LogoutAll(c *gin.Context) {
+	userID := c.GetString("x-user-id")
+
+	err := lc.LogoutUsecase.LogoutAll(c, userID, lc.Env.AccessTokenExpiryHour)
+	audit(c, lc.AuditLogger, domain.AuditActionLogoutAll, userID, err, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if c.GetHeader("Authorization") == "" {
+		clearTokenCookies(c, lc.Env)
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Logged out of all sessions"})
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type PasswordResetController struct {
+	PasswordResetUsecase domain.PasswordResetUsecase
+	AuditLogger          domain.AuditLogger
+	Env                  *bootstrap.Env
+}
+
+func (pc *PasswordResetController) Forgot(c *gin.Context) {
+	var request domain.ForgotPasswordRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = pc.PasswordResetUsecase.RequestReset(c, request.Email, c.ClientIP(), pc.Env.PasswordResetURL, pc.Env.PasswordResetTokenExpiryMinute, pc.Env.PasswordResetEmailLimit, pc.Env.PasswordResetIPLimit)
+	if errors.Is(err, domain.ErrTooManyRequests) {
+		c.JSON(http.StatusTooManyRequests, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "If the email is registered, a reset link has been sent"})
+}
+
+func (pc *PasswordResetController) Reset(c *gin.Context) {
+	var request domain.ResetPasswordRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	userID, err := pc.PasswordResetUsecase.ResetPassword(c, request.Token, request.Password, pc.Env.AccessTokenExpiryHour)
+	audit(c, pc.AuditLogger, domain.AuditActionPasswordReset, userID, err, nil)
+	var rejected *domain.PasswordPolicyError
+	if errors.As(err, &rejected) {
+		c.JSON(http.StatusBadRequest, domain.ValidationErrorResponse{Message: err.Error(), Errors: rejected.Violations})
+		return
+	}
+	if errors.Is(err, domain.ErrInvalidResetToken) {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Password has been reset"})
+}
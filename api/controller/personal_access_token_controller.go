@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type PersonalAccessTokenController struct {
+	PersonalAccessTokenUsecase domain.PersonalAccessTokenUsecase
+	AuditLogger                domain.AuditLogger
+}
+
+func (pc *PersonalAccessTokenController) Create(c *gin.Context) {
+	var request domain.PersonalAccessTokenRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	userID := c.GetString("x-user-id")
+	response, err := pc.PersonalAccessTokenUsecase.Create(c, userID, &request)
+	audit(c, pc.AuditLogger, domain.AuditActionAccessTokenCreate, userID, err, map[string]string{"name": request.Name})
+	if errors.Is(err, domain.ErrUnknownScope) {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+func (pc *PersonalAccessTokenController) Fetch(c *gin.Context) {
+	tokens, err := pc.PersonalAccessTokenUsecase.FetchByUserID(c, c.GetString("x-user-id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (pc *PersonalAccessTokenController) Revoke(c *gin.Context) {
+	userID := c.GetString("x-user-id")
+	err := pc.PersonalAccessTokenUsecase.Revoke(c, userID, c.Param("id"))
+	audit(c, pc.AuditLogger, domain.AuditActionAccessTokenRevoke, userID, err, map[string]string{"tokenID": c.Param("id")})
+	if errors.Is(err, domain.ErrPersonalAccessTokenNotFound) {
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Token revoked"})
+}
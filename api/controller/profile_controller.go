@@ -1,14 +1,24 @@
 package controller
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/gin-gonic/gin"
 )
 
+// maxAvatarUploadBytes limits the request body of an avatar upload.
+const maxAvatarUploadBytes = 10 << 20
+
 type ProfileController struct {
-	ProfileUsecase domain.ProfileUsecase
+	ProfileUsecase           domain.ProfileUsecase
+	EmailVerificationUsecase domain.EmailVerificationUsecase
+	AuditLogger              domain.AuditLogger
+	Env                      *bootstrap.Env
 }
 
 func (pc *ProfileController) Fetch(c *gin.Context) {
@@ -22,3 +32,161 @@ func (pc *ProfileController) Fetch(c *gin.Context) {
 
 	c.JSON(http.StatusOK, profile)
 }
+
+func (pc *ProfileController) Update(c *gin.Context) {
+	var request domain.UpdateProfileRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	profile, err := pc.ProfileUsecase.UpdateProfile(c, c.GetString("x-user-id"), &request)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (pc *ProfileController) UpdateAvatar(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxAvatarUploadBytes)
+
+	header, err := c.FormFile("avatar")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	defer file.Close()
+
+	profile, err := pc.ProfileUsecase.UpdateAvatar(c, c.GetString("x-user-id"), file)
+	if errors.Is(err, domain.ErrInvalidImage) {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (pc *ProfileController) DeleteAvatar(c *gin.Context) {
+	profile, err := pc.ProfileUsecase.DeleteAvatar(c, c.GetString("x-user-id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (pc *ProfileController) ChangePassword(c *gin.Context) {
+	var request domain.ChangePasswordRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	userID := c.GetString("x-user-id")
+	err = pc.ProfileUsecase.ChangePassword(c, userID, c.GetString("x-session-id"), request.CurrentPassword, request.NewPassword, pc.Env.AccessTokenExpiryHour)
+	audit(c, pc.AuditLogger, domain.AuditActionPasswordChange, userID, err, nil)
+	if err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Password changed"})
+}
+
+func (pc *ProfileController) ChangeEmail(c *gin.Context) {
+	var request domain.ChangeEmailRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	userID := c.GetString("x-user-id")
+	user, err := pc.ProfileUsecase.ChangeEmail(c, userID, c.GetString("x-session-id"), request.Password, request.Email)
+	audit(c, pc.AuditLogger, domain.AuditActionEmailChange, userID, err, nil)
+	if err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	// The change is stored at this point, so a failed send is not reported;
+	// the user can ask for the link again.
+	_ = pc.EmailVerificationUsecase.SendVerification(c, &user, pc.Env.EmailVerificationURL, pc.Env.EmailVerificationTokenExpiryMinute)
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Check the inbox of the new email to confirm the change"})
+}
+
+func (pc *ProfileController) Delete(c *gin.Context) {
+	var request domain.DeleteAccountRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	userID := c.GetString("x-user-id")
+	err = pc.ProfileUsecase.DeleteAccount(c, userID, c.GetString("x-session-id"), request.Password, pc.Env.AccessTokenExpiryHour)
+	audit(c, pc.AuditLogger, domain.AuditActionAccountDelete, userID, err, nil)
+	if err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Account deleted"})
+}
+
+func (pc *ProfileController) Erase(c *gin.Context) {
+	var request domain.DeleteAccountRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	userID := c.GetString("x-user-id")
+	err = pc.ProfileUsecase.EraseAccount(c, userID, c.GetString("x-session-id"), request.Password, pc.Env.AccessTokenExpiryHour)
+	audit(c, pc.AuditLogger, domain.AuditActionAccountErase, userID, err, nil)
+	if err != nil {
+		respondProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Account erased"})
+}
+
+func respondProfileError(c *gin.Context, err error) {
+	var rejected *domain.PasswordPolicyError
+	var locked *domain.LoginLockedError
+	switch {
+	case errors.As(err, &rejected):
+		c.JSON(http.StatusBadRequest, domain.ValidationErrorResponse{Message: err.Error(), Errors: rejected.Violations})
+	case errors.As(err, &locked):
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, domain.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain.ErrIncorrectPassword), errors.Is(err, domain.ErrReauthenticationRequired):
+		c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain.ErrEmailTaken), errors.Is(err, domain.ErrOutstandingBalance):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+	}
+}
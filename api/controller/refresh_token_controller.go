@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
@@ -10,6 +11,7 @@ import (
 
 type RefreshTokenController struct {
 	RefreshTokenUsecase domain.RefreshTokenUsecase
+	AuditLogger         domain.AuditLogger
 	Env                 *bootstrap.Env
 }
 
@@ -22,30 +24,64 @@ func (rtc *RefreshTokenController) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	id, err := rtc.RefreshTokenUsecase.ExtractIDFromToken(request.RefreshToken, rtc.Env.RefreshTokenSecret)
+	// Clients in cookie mode send the refresh token as a cookie and get the
+	// new pair as cookies.
+	cookieMode := request.RefreshToken == ""
+	if cookieMode {
+		request.RefreshToken, err = c.Cookie(domain.RefreshTokenCookie)
+		if err != nil || request.RefreshToken == "" {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "Refresh token is required"})
+			return
+		}
+	}
+
+	claims, err := rtc.RefreshTokenUsecase.ExtractClaimsFromToken(request.RefreshToken, rtc.Env.RefreshTokenSecret)
 	if err != nil {
+		audit(c, rtc.AuditLogger, domain.AuditActionTokenRefresh, "", domain.ErrInvalidRefreshToken, nil)
 		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "User not found"})
 		return
 	}
 
-	user, err := rtc.RefreshTokenUsecase.GetUserByID(c, id)
+	err = rtc.RefreshTokenUsecase.Rotate(c, claims, rtc.Env.AccessTokenExpiryHour)
+	audit(c, rtc.AuditLogger, domain.AuditActionTokenRefresh, claims.ID, err, map[string]string{"sessionID": claims.FamilyID})
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrRefreshTokenNotFound) ||
+			errors.Is(err, domain.ErrRefreshTokenRevoked) ||
+			errors.Is(err, domain.ErrRefreshTokenReused) {
+			status = http.StatusUnauthorized
+		}
+		c.JSON(status, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	user, err := rtc.RefreshTokenUsecase.GetUserByID(c, claims.ID)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "User not found"})
 		return
 	}
+	if user.DisabledAt != nil {
+		c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: domain.ErrAccountDisabled.Error()})
+		return
+	}
 
-	accessToken, err := rtc.RefreshTokenUsecase.CreateAccessToken(&user, rtc.Env.AccessTokenSecret, rtc.Env.AccessTokenExpiryHour)
+	refreshToken, err := rtc.RefreshTokenUsecase.CreateRefreshToken(c, &user, claims.FamilyID, sessionClient(c), rtc.Env.RefreshTokenSecret, rtc.Env.RefreshTokenExpiryHour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
 		return
 	}
 
-	refreshToken, err := rtc.RefreshTokenUsecase.CreateRefreshToken(&user, rtc.Env.RefreshTokenSecret, rtc.Env.RefreshTokenExpiryHour)
+	accessToken, err := rtc.RefreshTokenUsecase.CreateAccessToken(&user, claims.FamilyID, rtc.Env.AccessTokenSecret, rtc.Env.AccessTokenExpiryHour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
 		return
 	}
 
+	if cookieMode {
+		respondWithTokenCookies(c, rtc.Env, accessToken, refreshToken)
+		return
+	}
+
 	refreshTokenResponse := domain.RefreshTokenResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -0,0 +1,74 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRefreshTokenCookieMode(t *testing.T) {
+	user := domain.User{ID: primitive.NewObjectID()}
+	claims := &domain.JwtCustomRefreshClaims{ID: user.ID.Hex(), FamilyID: "sid"}
+	env := &bootstrap.Env{
+		AppEnv:                 "production",
+		AccessTokenExpiryHour:  2,
+		RefreshTokenExpiryHour: 168,
+		AccessTokenSecret:      "access",
+		RefreshTokenSecret:     "refresh",
+	}
+
+	mockRefreshTokenUsecase := new(mocks.RefreshTokenUsecase)
+	mockRefreshTokenUsecase.On("ExtractClaimsFromToken", "old-refresh", env.RefreshTokenSecret).Return(claims, nil).Once()
+	mockRefreshTokenUsecase.On("Rotate", mock.Anything, claims, mock.Anything).Return(nil).Once()
+	mockRefreshTokenUsecase.On("GetUserByID", mock.Anything, user.ID.Hex()).Return(user, nil).Once()
+	mockRefreshTokenUsecase.On("CreateRefreshToken", mock.Anything, &user, "sid", mock.Anything, env.RefreshTokenSecret, env.RefreshTokenExpiryHour).Return("new-refresh", nil).Once()
+	mockRefreshTokenUsecase.On("CreateAccessToken", &user, "sid", env.AccessTokenSecret, env.AccessTokenExpiryHour).Return("new-access", nil).Once()
+
+	mockAuditLogger := new(mocks.AuditLogger)
+	mockAuditLogger.On("Log", mock.Anything).Return()
+
+	rtc := &controller.RefreshTokenController{
+		RefreshTokenUsecase: mockRefreshTokenUsecase,
+		AuditLogger:         mockAuditLogger,
+		Env:                 env,
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/refresh", rtc.RefreshToken)
+
+	req := httptest.NewRequest(http.MethodPost, "/refresh", nil)
+	req.AddCookie(&http.Cookie{Name: domain.RefreshTokenCookie, Value: "old-refresh"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "new-access")
+
+	var response domain.CookieLoginResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range rec.Result().Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	assert.Equal(t, "new-access", cookies[domain.AccessTokenCookie].Value)
+	assert.True(t, cookies[domain.AccessTokenCookie].HttpOnly)
+	assert.True(t, cookies[domain.AccessTokenCookie].Secure)
+	assert.Equal(t, http.SameSiteStrictMode, cookies[domain.AccessTokenCookie].SameSite)
+	assert.Equal(t, "new-refresh", cookies[domain.RefreshTokenCookie].Value)
+	assert.Equal(t, response.CSRFToken, cookies[domain.CSRFTokenCookie].Value)
+	assert.False(t, cookies[domain.CSRFTokenCookie].HttpOnly)
+
+	mockRefreshTokenUsecase.AssertExpectations(t)
+}
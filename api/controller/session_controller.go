@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type SessionController struct {
+	SessionUsecase domain.SessionUsecase
+	AuditLogger    domain.AuditLogger
+	Env            *bootstrap.Env
+}
+
+func (sc *SessionController) Fetch(c *gin.Context) {
+	sessions, err := sc.SessionUsecase.FetchByUserID(c, c.GetString("x-user-id"), c.GetString("x-session-id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+func (sc *SessionController) Revoke(c *gin.Context) {
+	userID := c.GetString("x-user-id")
+	err := sc.SessionUsecase.Revoke(c, userID, c.Param("id"), sc.Env.AccessTokenExpiryHour)
+	audit(c, sc.AuditLogger, domain.AuditActionSessionRevoke, userID, err, map[string]string{"sessionID": c.Param("id")})
+	if errors.Is(err, domain.ErrSessionNotFound) {
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Session revoked"})
+}
+
+func sessionClient(c *gin.Context) domain.SessionClient {
+	return domain.SessionClient{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
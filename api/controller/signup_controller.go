@@ -1,18 +1,20 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type SignupController struct {
-	SignupUsecase domain.SignupUsecase
-	Env           *bootstrap.Env
+	SignupUsecase            domain.SignupUsecase
+	EmailVerificationUsecase domain.EmailVerificationUsecase
+	AuditLogger              domain.AuditLogger
+	Env                      *bootstrap.Env
 }
 
 func (sc *SignupController) Signup(c *gin.Context) {
@@ -30,17 +32,6 @@ func (sc *SignupController) Signup(c *gin.Context) {
 		return
 	}
 
-	encryptedPassword, err := bcrypt.GenerateFromPassword(
-		[]byte(request.Password),
-		bcrypt.DefaultCost,
-	)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
-		return
-	}
-
-	request.Password = string(encryptedPassword)
-
 	user := domain.User{
 		ID:       primitive.NewObjectID(),
 		Name:     request.Name,
@@ -49,23 +40,42 @@ func (sc *SignupController) Signup(c *gin.Context) {
 	}
 
 	err = sc.SignupUsecase.Create(c, &user)
+	audit(c, sc.AuditLogger, domain.AuditActionSignup, user.ID.Hex(), err, nil)
+	var rejected *domain.PasswordPolicyError
+	if errors.As(err, &rejected) {
+		c.JSON(http.StatusBadRequest, domain.ValidationErrorResponse{Message: err.Error(), Errors: rejected.Violations})
+		return
+	}
+	if errors.Is(err, domain.ErrEmailTaken) {
+		c.JSON(http.StatusConflict, domain.ErrorResponse{Message: "User already exists with the given email"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
 		return
 	}
 
-	accessToken, err := sc.SignupUsecase.CreateAccessToken(&user, sc.Env.AccessTokenSecret, sc.Env.AccessTokenExpiryHour)
+	// The account exists at this point, so a failed send is not reported; the
+	// user can ask for the link again.
+	_ = sc.EmailVerificationUsecase.SendVerification(c, &user, sc.Env.EmailVerificationURL, sc.Env.EmailVerificationTokenExpiryMinute)
+
+	refreshToken, sessionID, err := sc.SignupUsecase.CreateRefreshToken(c, &user, sessionClient(c), sc.Env.RefreshTokenSecret, sc.Env.RefreshTokenExpiryHour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
 		return
 	}
 
-	refreshToken, err := sc.SignupUsecase.CreateRefreshToken(&user, sc.Env.RefreshTokenSecret, sc.Env.RefreshTokenExpiryHour)
+	accessToken, err := sc.SignupUsecase.CreateAccessToken(&user, sessionID, sc.Env.AccessTokenSecret, sc.Env.AccessTokenExpiryHour)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
 		return
 	}
 
+	if tokenCookieMode(c) {
+		respondWithTokenCookies(c, sc.Env, accessToken, refreshToken)
+		return
+	}
+
 	signupResponse := domain.SignupResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorController struct {
+	TwoFactorUsecase domain.TwoFactorUsecase
+	AuditLogger      domain.AuditLogger
+	Env              *bootstrap.Env
+}
+
+func (tc *TwoFactorController) Enroll(c *gin.Context) {
+	userID := c.GetString("x-user-id")
+
+	response, err := tc.TwoFactorUsecase.Enroll(c, userID, tc.Env.TOTPIssuer)
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (tc *TwoFactorController) Enable(c *gin.Context) {
+	var request domain.TwoFactorCodeRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	userID := c.GetString("x-user-id")
+	err = tc.TwoFactorUsecase.Enable(c, userID, request.Code)
+	audit(c, tc.AuditLogger, domain.AuditActionTwoFactorEnable, userID, err, nil)
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Two-factor authentication enabled"})
+}
+
+func (tc *TwoFactorController) Disable(c *gin.Context) {
+	var request domain.TwoFactorCodeRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	userID := c.GetString("x-user-id")
+	err = tc.TwoFactorUsecase.Disable(c, userID, request.Code)
+	audit(c, tc.AuditLogger, domain.AuditActionTwoFactorDisable, userID, err, nil)
+	if err != nil {
+		respondTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Two-factor authentication disabled"})
+}
+
+func respondTwoFactorError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	var locked *domain.LoginLockedError
+	switch {
+	case errors.As(err, &locked):
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+		status = http.StatusTooManyRequests
+	case errors.Is(err, domain.ErrInvalidTwoFactorCode):
+		status = http.StatusUnauthorized
+	case errors.Is(err, domain.ErrTwoFactorNotEnrolled),
+		errors.Is(err, domain.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, domain.ErrTwoFactorNotEnabled):
+		status = http.StatusConflict
+	}
+	c.JSON(status, domain.ErrorResponse{Message: err.Error()})
+}
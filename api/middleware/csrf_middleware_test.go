@@ -0,0 +1,57 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSRFMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.CSRFMiddleware())
+	router.GET("/tasks", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/tasks", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	serve := func(method string, header string, csrfCookie string) int {
+		req := httptest.NewRequest(method, "/tasks", nil)
+		req.AddCookie(&http.Cookie{Name: domain.AccessTokenCookie, Value: "token"})
+		if csrfCookie != "" {
+			req.AddCookie(&http.Cookie{Name: domain.CSRFTokenCookie, Value: csrfCookie})
+		}
+		if header != "" {
+			req.Header.Set(domain.CSRFTokenHeader, header)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("safe method", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(http.MethodGet, "", ""))
+	})
+
+	t.Run("matching token", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(http.MethodPost, "csrf", "csrf"))
+	})
+
+	t.Run("missing or wrong token", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "", "csrf"))
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "other", "csrf"))
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "csrf", ""))
+	})
+
+	t.Run("authorization header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tasks", nil)
+		req.AddCookie(&http.Cookie{Name: domain.AccessTokenCookie, Value: "token"})
+		req.Header.Set("Authorization", "Bearer token")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// EmailVerificationMiddleware restricts accounts with an unverified email
+// according to policy. It must run after JwtAuthMiddleware. The verification
+// state comes from the access token, so it changes after the next refresh.
+func EmailVerificationMiddleware(policy string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool("x-email-verified") {
+			c.Next()
+			return
+		}
+
+		switch policy {
+		case domain.UnverifiedEmailPolicyBlock:
+		case domain.UnverifiedEmailPolicyReadOnly:
+			if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead || c.Request.Method == http.MethodOptions {
+				c.Next()
+				return
+			}
+		default:
+			c.Next()
+			return
+		}
+
+		c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: "Email is not verified"})
+		c.Abort()
+	}
+}
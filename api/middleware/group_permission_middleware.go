@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireGroupPermission resolves the caller's role in the :groupId group and
+// sets x-group-member for the handlers if the role grants the permission.
+func RequireGroupPermission(groupUsecase domain.GroupUsecase, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		member, err := groupUsecase.Authorize(c, c.Param("groupId"), c.GetString("x-user-id"), permission)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, domain.ErrForbidden) {
+				status = http.StatusForbidden
+			}
+			c.JSON(status, domain.ErrorResponse{Message: err.Error()})
+			c.Abort()
+			return
+		}
+		c.Set("x-group-member", member)
+		c.Next()
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireScope lets personal access tokens through only if they carry the
+// scope. Session tokens have every scope.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, ok := c.Get("x-token-scopes")
+		if !ok {
+			c.Next()
+			return
+		}
+
+		scopes, _ := value.([]string)
+		for _, s := range scopes {
+			if s == scope {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: "Token lacks the " + scope + " scope"})
+		c.Abort()
+	}
+}
+
+// RequireSession rejects personal access tokens, for account management that
+// needs the user to be signed in.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("x-token-scopes"); ok {
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: "Not available to personal access tokens"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
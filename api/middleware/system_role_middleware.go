@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RequireSystemRole lets through session tokens carrying the system role.
+// Personal access tokens never carry one. The role is checked against the
+// user as well, so that a demoted or disabled operator loses access before
+// their token expires.
+func RequireSystemRole(userRepository domain.UserRepository, role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("x-system-role") != role {
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: "Requires the " + role + " role"})
+			c.Abort()
+			return
+		}
+
+		user, err := userRepository.GetByID(c, c.GetString("x-user-id"))
+		if err == mongo.ErrNoDocuments {
+			user = domain.User{}
+			err = nil
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+			c.Abort()
+			return
+		}
+		if user.SystemRole != role || user.DisabledAt != nil || user.DeletedAt != nil {
+			c.JSON(http.StatusForbidden, domain.ErrorResponse{Message: "Requires the " + role + " role"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,73 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestRequireSystemRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serve := func(userRepository domain.UserRepository, tokenRole string) int {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("x-user-id", "user")
+			if tokenRole != "" {
+				c.Set("x-system-role", tokenRole)
+			}
+		})
+		router.Use(middleware.RequireSystemRole(userRepository, domain.SystemRoleOperator))
+		router.GET("/admin/users", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/users", nil))
+		return rec.Code
+	}
+
+	t.Run("operator", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByID", mock.Anything, "user").Return(domain.User{SystemRole: domain.SystemRoleOperator}, nil).Once()
+
+		assert.Equal(t, http.StatusOK, serve(mockUserRepository, domain.SystemRoleOperator))
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("token without the role", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+
+		assert.Equal(t, http.StatusForbidden, serve(mockUserRepository, ""))
+		mockUserRepository.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("demoted since the token was issued", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByID", mock.Anything, "user").Return(domain.User{}, nil).Once()
+
+		assert.Equal(t, http.StatusForbidden, serve(mockUserRepository, domain.SystemRoleOperator))
+	})
+
+	t.Run("disabled since the token was issued", func(t *testing.T) {
+		disabledAt := time.Now()
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByID", mock.Anything, "user").Return(domain.User{SystemRole: domain.SystemRoleOperator, DisabledAt: &disabledAt}, nil).Once()
+
+		assert.Equal(t, http.StatusForbidden, serve(mockUserRepository, domain.SystemRoleOperator))
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByID", mock.Anything, "user").Return(domain.User{}, mongo.ErrNoDocuments).Once()
+
+		assert.Equal(t, http.StatusForbidden, serve(mockUserRepository, domain.SystemRoleOperator))
+	})
+}
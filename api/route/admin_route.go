@@ -0,0 +1,41 @@
+package route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewAdminRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, revocationStore domain.TokenRevocationStore, auditLogger domain.AuditLogger, group *gin.RouterGroup) {
+	ur := repository.NewUserRepository(db, domain.CollectionUser)
+	gmr := repository.NewGroupMemberRepository(db, domain.CollectionGroupMember)
+	rtr := repository.NewRefreshTokenRepository(db, domain.CollectionRefreshToken)
+	sr := repository.NewSessionRepository(db, domain.CollectionSession)
+	pr := repository.NewPersonalAccessTokenRepository(db, domain.CollectionPersonalAccessToken)
+	ar := repository.NewAuditRepository(db, domain.CollectionAuditEvent)
+	auditUsecase := usecase.NewAuditUsecase(ar, timeout)
+
+	adc := &controller.AdminController{
+		AdminUsecase: usecase.NewAdminUsecase(ur, gmr, rtr, sr, pr, revocationStore, timeout),
+		AuditUsecase: auditUsecase,
+		AuditLogger:  auditLogger,
+		Env:          env,
+	}
+	ac := &controller.AuditController{
+		AuditUsecase: auditUsecase,
+	}
+	group.GET("/admin/users", adc.FetchUsers)
+	group.GET("/admin/users/:id", adc.GetUser)
+	group.GET("/admin/users/:id/activity", adc.FetchActivity)
+	group.POST("/admin/users/:id/disable", adc.DisableUser)
+	group.POST("/admin/users/:id/enable", adc.EnableUser)
+	group.POST("/admin/users/:id/logout", adc.ForceLogout)
+	group.DELETE("/admin/users/:id/2fa", adc.ResetTwoFactor)
+	group.GET("/admin/audit", ac.FetchAll)
+}
@@ -0,0 +1,21 @@
+package route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewAuditRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	ar := repository.NewAuditRepository(db, domain.CollectionAuditEvent)
+	ac := &controller.AuditController{
+		AuditUsecase: usecase.NewAuditUsecase(ar, timeout),
+	}
+	group.GET("/account/audit", ac.Fetch)
+}
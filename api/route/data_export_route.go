@@ -0,0 +1,42 @@
+package route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewDataExportRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, fileStorage domain.FileStorage, mailer domain.Mailer, group *gin.RouterGroup) {
+	dc := &controller.DataExportController{
+		DataExportUsecase: NewDataExportUsecase(timeout, db, fileStorage, mailer),
+	}
+	group.POST("/account/exports", dc.Create)
+	group.GET("/account/exports/:id", dc.Fetch)
+	group.GET("/account/exports/:id/download", dc.Download)
+}
+
+// NewDataExportUsecase is shared with the worker that builds the archives.
+func NewDataExportUsecase(timeout time.Duration, db mongo.Database, fileStorage domain.FileStorage, mailer domain.Mailer) domain.DataExportUsecase {
+	return usecase.NewDataExportUsecase(
+		repository.NewDataExportRepository(db, domain.CollectionDataExport),
+		repository.NewUserRepository(db, domain.CollectionUser),
+		repository.NewGroupRepository(db, domain.CollectionGroup),
+		repository.NewGroupMemberRepository(db, domain.CollectionGroupMember),
+		repository.NewLoanRepository(db, domain.CollectionLoan),
+		repository.NewWalletEntryRepository(db, domain.CollectionWalletEntry),
+		repository.NewGroupDebtRepository(db, domain.CollectionGroupDebt),
+		repository.NewTaskRepository(db, domain.CollectionTask),
+		repository.NewSessionRepository(db, domain.CollectionSession),
+		repository.NewPersonalAccessTokenRepository(db, domain.CollectionPersonalAccessToken),
+		repository.NewAuditRepository(db, domain.CollectionAuditEvent),
+		fileStorage,
+		mailer,
+		timeout,
+	)
+}
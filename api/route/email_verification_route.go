@@ -0,0 +1,24 @@
+package route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewEmailVerificationRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, mailer domain.Mailer, publicGroup *gin.RouterGroup, protectedGroup *gin.RouterGroup) {
+	ur := repository.NewUserRepository(db, domain.CollectionUser)
+	utr := repository.NewUserTokenRepository(db, domain.CollectionUserToken)
+	ec := &controller.EmailVerificationController{
+		EmailVerificationUsecase: usecase.NewEmailVerificationUsecase(ur, utr, mailer, timeout),
+		Env:                      env,
+	}
+	publicGroup.POST("/email/verify", ec.Verify)
+	protectedGroup.POST("/email/verify/resend", ec.Resend)
+}
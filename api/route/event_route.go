@@ -0,0 +1,19 @@
+package route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/gin-gonic/gin"
+)
+
+func NewEventRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, broker domain.EventBroker, groupUsecase domain.GroupUsecase, group *gin.RouterGroup) {
+	ec := &controller.EventController{
+		EventBroker: broker,
+	}
+	group.GET("/groups/:groupId/events", middleware.RequireScope(domain.ScopeGroupsRead), middleware.RequireGroupPermission(groupUsecase, domain.GroupPermissionView), ec.Stream)
+}
@@ -0,0 +1,77 @@
+package route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewGroupRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, groupUsecase domain.GroupUsecase, transactor domain.Transactor, auditLogger domain.AuditLogger, group *gin.RouterGroup) {
+	gc := &controller.GroupController{
+		GroupUsecase: groupUsecase,
+		AuditLogger:  auditLogger,
+	}
+	read := middleware.RequireScope(domain.ScopeGroupsRead)
+	write := middleware.RequireScope(domain.ScopeGroupsWrite)
+	view := middleware.RequireGroupPermission(groupUsecase, domain.GroupPermissionView)
+	manage := middleware.RequireGroupPermission(groupUsecase, domain.GroupPermissionManageMembers)
+	// Only the owner closes the group, as it settles the wallet for good.
+	closeGroup := middleware.RequireGroupPermission(groupUsecase, domain.GroupPermissionDelete)
+	group.POST("/groups", write, gc.Create)
+	group.GET("/groups/:groupId/members", read, view, gc.FetchMembers)
+	group.POST("/groups/:groupId/members", write, manage, gc.AddMember)
+	group.PUT("/groups/:groupId/members/:userId", write, manage, gc.UpdateMemberRole)
+	// Viewing is enough to leave the group, the usecase checks the rest.
+	group.DELETE("/groups/:groupId/members/:userId", write, view, gc.RemoveMember)
+	group.PUT("/groups/:groupId/late-fee", write, manage, gc.SetLateFee)
+	group.DELETE("/groups/:groupId/late-fee", write, manage, gc.RemoveLateFee)
+	group.POST("/groups/:groupId/close", write, closeGroup, gc.Close)
+
+	wc := &controller.GroupWalletController{
+		GroupWalletUsecase: usecase.NewGroupWalletUsecase(
+			repository.NewGroupWalletRepository(db, domain.CollectionGroupWallet),
+			repository.NewWalletEntryRepository(db, domain.CollectionWalletEntry),
+			repository.NewGroupRepository(db, domain.CollectionGroup),
+			repository.NewGroupMemberRepository(db, domain.CollectionGroupMember),
+			repository.NewOutboxRepository(db, domain.CollectionOutbox),
+			transactor,
+			timeout,
+		),
+	}
+	expensesRead := middleware.RequireScope(domain.ScopeExpensesRead)
+	expensesWrite := middleware.RequireScope(domain.ScopeExpensesWrite)
+	writeExpenses := middleware.RequireGroupPermission(groupUsecase, domain.GroupPermissionWriteExpenses)
+	group.POST("/groups/:groupId/wallet", write, manage, wc.Open)
+	group.GET("/groups/:groupId/wallet", expensesRead, view, wc.Fetch)
+	group.POST("/groups/:groupId/wallet/contributions", expensesWrite, writeExpenses, wc.Contribute)
+	group.POST("/groups/:groupId/wallet/expenses", expensesWrite, writeExpenses, wc.AddExpense)
+
+	dc := &controller.GroupDebtController{
+		GroupDebtUsecase: NewGroupDebtUsecase(timeout, db, transactor),
+	}
+	group.POST("/groups/:groupId/debts", expensesWrite, writeExpenses, dc.Create)
+	group.GET("/groups/:groupId/debts", expensesRead, view, dc.Fetch)
+	group.POST("/groups/:groupId/debts/:debtId/payments", expensesWrite, writeExpenses, dc.RecordPayment)
+	group.POST("/groups/:groupId/debts/:debtId/charges/:entryId/waive", expensesWrite, manage, dc.WaiveCharge)
+	group.GET("/groups/:groupId/ledger", expensesRead, view, dc.FetchLedger)
+}
+
+// NewGroupDebtUsecase is shared with the worker that charges the late fees.
+func NewGroupDebtUsecase(timeout time.Duration, db mongo.Database, transactor domain.Transactor) domain.GroupDebtUsecase {
+	return usecase.NewGroupDebtUsecase(
+		repository.NewGroupDebtRepository(db, domain.CollectionGroupDebt),
+		repository.NewGroupLedgerRepository(db, domain.CollectionGroupLedger),
+		repository.NewGroupRepository(db, domain.CollectionGroup),
+		repository.NewGroupMemberRepository(db, domain.CollectionGroupMember),
+		repository.NewOutboxRepository(db, domain.CollectionOutbox),
+		transactor,
+		timeout,
+	)
+}
@@ -0,0 +1,18 @@
+package route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/gin-gonic/gin"
+)
+
+func NewJWKSRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, tokenManager *tokenutil.TokenManager, group *gin.RouterGroup) {
+	jc := &controller.JWKSController{
+		TokenManager: tokenManager,
+	}
+	group.GET("/.well-known/jwks.json", jc.Fetch)
+}
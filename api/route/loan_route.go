@@ -0,0 +1,31 @@
+package route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewLoanRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+	lr := repository.NewLoanRepository(db, domain.CollectionLoan)
+	ur := repository.NewUserRepository(db, domain.CollectionUser)
+	lc := &controller.LoanController{
+		LoanUsecase: usecase.NewLoanUsecase(lr, ur, timeout),
+	}
+	read := middleware.RequireScope(domain.ScopeLoansRead)
+	write := middleware.RequireScope(domain.ScopeLoansWrite)
+	group.GET("/loans", read, lc.Fetch)
+	group.POST("/loans", write, lc.Create)
+	group.GET("/loans/:id", read, lc.FetchByID)
+	group.GET("/loans/:id/balance", read, lc.Balance)
+	group.POST("/loans/:id/confirm", write, lc.Confirm)
+	group.POST("/loans/:id/decline", write, lc.Decline)
+	group.POST("/loans/:id/repayments", write, lc.AddRepayment)
+}
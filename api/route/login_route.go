@@ -6,17 +6,44 @@ import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/oidcutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
 	"github.com/gin-gonic/gin"
 )
 
-func NewLoginRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+func NewLoginRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, tokenManager *tokenutil.TokenManager, passwordHasher domain.PasswordHasher, revocationStore domain.TokenRevocationStore, loginAttemptStore domain.LoginAttemptStore, auditLogger domain.AuditLogger, group *gin.RouterGroup) {
 	ur := repository.NewUserRepository(db, domain.CollectionUser)
+	rtr := repository.NewRefreshTokenRepository(db, domain.CollectionRefreshToken)
+	sr := repository.NewSessionRepository(db, domain.CollectionSession)
+	policy := loginThrottlePolicy(env)
 	lc := &controller.LoginController{
-		LoginUsecase: usecase.NewLoginUsecase(ur, timeout),
-		Env:          env,
+		LoginUsecase:     usecase.NewLoginUsecase(ur, rtr, sr, passwordHasher, loginAttemptStore, policy, tokenManager, timeout),
+		TwoFactorUsecase: usecase.NewTwoFactorUsecase(ur, loginAttemptStore, revocationStore, policy, tokenManager, timeout),
+		AuditLogger:      auditLogger,
+		Env:              env,
 	}
 	group.POST("/login", lc.Login)
+	group.POST("/login/2fa", lc.LoginTwoFactor)
+
+	// Sign-in with an OpenID Connect provider is optional.
+	if env.OIDCIssuer != "" {
+		provider := oidcutil.NewProvider(env.OIDCProvider, env.OIDCIssuer, env.OIDCClientID, env.OIDCClientSecret, env.OIDCRedirectURL)
+		osr := repository.NewOIDCStateRepository(db, domain.CollectionOIDCState)
+		lc.OIDCUsecase = usecase.NewOIDCUsecase(provider, osr, ur, timeout)
+		group.GET("/login/oidc", lc.OIDCLogin)
+		group.GET("/login/oidc/callback", lc.OIDCCallback)
+	}
+}
+
+func loginThrottlePolicy(env *bootstrap.Env) domain.LoginThrottlePolicy {
+	return domain.LoginThrottlePolicy{
+		FreeAttempts:       3,
+		BaseDelay:          time.Second,
+		MaxAccountFailures: env.LoginMaxFailedAttempts,
+		MaxIPFailures:      env.LoginMaxFailedAttemptsPerIP,
+		LockoutDuration:    time.Duration(env.LoginLockoutMinute) * time.Minute,
+	}
 }
@@ -0,0 +1,26 @@
+package route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewLogoutRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, tokenManager *tokenutil.TokenManager, revocationStore domain.TokenRevocationStore, auditLogger domain.AuditLogger, group *gin.RouterGroup) {
+	rtr := repository.NewRefreshTokenRepository(db, domain.CollectionRefreshToken)
+	sr := repository.NewSessionRepository(db, domain.CollectionSession)
+	lc := &controller.LogoutController{
+		LogoutUsecase: usecase.NewLogoutUsecase(rtr, sr, revocationStore, tokenManager, timeout),
+		AuditLogger:   auditLogger,
+		Env:           env,
+	}
+	group.POST("/logout", lc.Logout)
+	group.POST("/logout/all", lc.LogoutAll)
+}
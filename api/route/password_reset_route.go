@@ -0,0 +1,27 @@
+package route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewPasswordResetRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, revocationStore domain.TokenRevocationStore, passwordHasher domain.PasswordHasher, passwordPolicy domain.PasswordPolicy, mailer domain.Mailer, auditLogger domain.AuditLogger, group *gin.RouterGroup) {
+	ur := repository.NewUserRepository(db, domain.CollectionUser)
+	utr := repository.NewUserTokenRepository(db, domain.CollectionUserToken)
+	rtr := repository.NewRefreshTokenRepository(db, domain.CollectionRefreshToken)
+	sr := repository.NewSessionRepository(db, domain.CollectionSession)
+	pc := &controller.PasswordResetController{
+		PasswordResetUsecase: usecase.NewPasswordResetUsecase(ur, utr, rtr, sr, revocationStore, passwordHasher, passwordPolicy, mailer, timeout),
+		AuditLogger:          auditLogger,
+		Env:                  env,
+	}
+	group.POST("/password/forgot", pc.Forgot)
+	group.POST("/password/reset", pc.Reset)
+}
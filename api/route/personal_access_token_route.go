@@ -0,0 +1,21 @@
+package route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/gin-gonic/gin"
+)
+
+func NewPersonalAccessTokenRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, personalAccessTokenUsecase domain.PersonalAccessTokenUsecase, auditLogger domain.AuditLogger, group *gin.RouterGroup) {
+	pc := &controller.PersonalAccessTokenController{
+		PersonalAccessTokenUsecase: personalAccessTokenUsecase,
+		AuditLogger:                auditLogger,
+	}
+	group.GET("/tokens", pc.Fetch)
+	group.POST("/tokens", pc.Create)
+	group.DELETE("/tokens/:id", pc.Revoke)
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
@@ -12,10 +13,32 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func NewProfileRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+func NewProfileRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, revocationStore domain.TokenRevocationStore, loginAttemptStore domain.LoginAttemptStore, passwordHasher domain.PasswordHasher, passwordPolicy domain.PasswordPolicy, mailer domain.Mailer, fileStorage domain.FileStorage, transactor domain.Transactor, auditLogger domain.AuditLogger, group *gin.RouterGroup, sessionGroup *gin.RouterGroup) {
 	ur := repository.NewUserRepository(db, domain.CollectionUser)
+	rtr := repository.NewRefreshTokenRepository(db, domain.CollectionRefreshToken)
+	sr := repository.NewSessionRepository(db, domain.CollectionSession)
+	pr := repository.NewPersonalAccessTokenRepository(db, domain.CollectionPersonalAccessToken)
+	lr := repository.NewLoanRepository(db, domain.CollectionLoan)
+	wr := repository.NewWalletEntryRepository(db, domain.CollectionWalletEntry)
+	gdr := repository.NewGroupDebtRepository(db, domain.CollectionGroupDebt)
+	tr := repository.NewTaskRepository(db, domain.CollectionTask)
+	dr := repository.NewDataExportRepository(db, domain.CollectionDataExport)
+	utr := repository.NewUserTokenRepository(db, domain.CollectionUserToken)
+	ar := repository.NewAuditRepository(db, domain.CollectionAuditEvent)
 	pc := &controller.ProfileController{
-		ProfileUsecase: usecase.NewProfileUsecase(ur, timeout),
+		ProfileUsecase:           usecase.NewProfileUsecase(ur, rtr, sr, pr, lr, wr, gdr, tr, dr, ar, revocationStore, loginAttemptStore, loginThrottlePolicy(env), time.Duration(env.ReauthMaxAgeMinute)*time.Minute, passwordHasher, passwordPolicy, mailer, fileStorage, transactor, timeout),
+		EmailVerificationUsecase: usecase.NewEmailVerificationUsecase(ur, utr, mailer, timeout),
+		AuditLogger:              auditLogger,
+		Env:                      env,
 	}
-	group.GET("/profile", pc.Fetch)
+	group.GET("/profile", middleware.RequireScope(domain.ScopeProfileRead), pc.Fetch)
+	group.PATCH("/profile", middleware.RequireScope(domain.ScopeProfileWrite), pc.Update)
+	group.PUT("/profile/avatar", middleware.RequireScope(domain.ScopeProfileWrite), pc.UpdateAvatar)
+	group.DELETE("/profile/avatar", middleware.RequireScope(domain.ScopeProfileWrite), pc.DeleteAvatar)
+	// Account changes are open to unverified accounts so that a mistyped
+	// email can be corrected.
+	sessionGroup.PUT("/profile/password", pc.ChangePassword)
+	sessionGroup.PUT("/profile/email", pc.ChangeEmail)
+	sessionGroup.DELETE("/profile", pc.Delete)
+	sessionGroup.POST("/profile/erasure", pc.Erase)
 }
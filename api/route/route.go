@@ -1,25 +1,96 @@
 package route
 
 import (
+	"path/filepath"
 	"time"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/memstore"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
 	"github.com/gin-gonic/gin"
 )
 
-func Setup(env *bootstrap.Env, timeout time.Duration, db mongo.Database, gin *gin.Engine) {
+func Setup(env *bootstrap.Env, timeout time.Duration, db mongo.Database, broker domain.EventBroker, tokenManager *tokenutil.TokenManager, mailer domain.Mailer, passwordHasher domain.PasswordHasher, passwordPolicy domain.PasswordPolicy, fileStorage domain.FileStorage, transactor domain.Transactor, auditLogger domain.AuditLogger, gin *gin.Engine) {
+	revocationStore := newTokenRevocationStore(env, db)
+	loginAttemptStore := newLoginAttemptStore(env, db)
+
 	publicRouter := gin.Group("")
 	// All Public APIs
-	NewSignupRouter(env, timeout, db, publicRouter)
-	NewLoginRouter(env, timeout, db, publicRouter)
-	NewRefreshTokenRouter(env, timeout, db, publicRouter)
+	// Only avatars are public; data exports are downloaded through their
+	// owner's session.
+	publicRouter.Static(env.StorageURLPath+"/avatars", filepath.Join(env.StorageDir, "avatars"))
+	NewSignupRouter(env, timeout, db, tokenManager, passwordHasher, passwordPolicy, mailer, auditLogger, publicRouter)
+	NewLoginRouter(env, timeout, db, tokenManager, passwordHasher, revocationStore, loginAttemptStore, auditLogger, publicRouter)
+	NewRefreshTokenRouter(env, timeout, db, revocationStore, tokenManager, auditLogger, publicRouter)
+	NewJWKSRouter(env, timeout, db, tokenManager, publicRouter)
+	NewPasswordResetRouter(env, timeout, db, revocationStore, passwordHasher, passwordPolicy, mailer, auditLogger, publicRouter)
+
+	pr := repository.NewPersonalAccessTokenRepository(db, domain.CollectionPersonalAccessToken)
+	personalAccessTokenUsecase := usecase.NewPersonalAccessTokenUsecase(pr, timeout)
+
+	gr := repository.NewGroupRepository(db, domain.CollectionGroup)
+	gmr := repository.NewGroupMemberRepository(db, domain.CollectionGroupMember)
+	gwr := repository.NewGroupWalletRepository(db, domain.CollectionGroupWallet)
+	wer := repository.NewWalletEntryRepository(db, domain.CollectionWalletEntry)
+	ur := repository.NewUserRepository(db, domain.CollectionUser)
+	or := repository.NewOutboxRepository(db, domain.CollectionOutbox)
+	groupUsecase := usecase.NewGroupUsecase(gr, gmr, gwr, wer, ur, or, transactor, timeout)
 
 	protectedRouter := gin.Group("")
-	// Middleware to verify AccessToken
-	protectedRouter.Use(middleware.JwtAuthMiddleware(env.AccessTokenSecret))
+	// Middleware to verify AccessToken or personal access token
+	protectedRouter.Use(middleware.JwtAuthMiddleware(env.AccessTokenSecret, tokenManager, revocationStore, personalAccessTokenUsecase))
+	// Middleware to check the CSRF token of requests authenticated by cookie
+	protectedRouter.Use(middleware.CSRFMiddleware())
 	// All Private APIs
-	NewProfileRouter(env, timeout, db, protectedRouter)
-	NewTaskRouter(env, timeout, db, protectedRouter)
+
+	sessionRouter := protectedRouter.Group("")
+	// Middleware to reject personal access tokens
+	sessionRouter.Use(middleware.RequireSession())
+	NewLogoutRouter(env, timeout, db, tokenManager, revocationStore, auditLogger, sessionRouter)
+	NewSessionRouter(env, timeout, db, revocationStore, auditLogger, sessionRouter)
+	NewEmailVerificationRouter(env, timeout, db, mailer, publicRouter, sessionRouter)
+	NewDataExportRouter(env, timeout, db, fileStorage, mailer, sessionRouter)
+	NewAuditRouter(env, timeout, db, sessionRouter)
+
+	adminRouter := sessionRouter.Group("")
+	// Middleware to restrict the admin API to operators
+	adminRouter.Use(middleware.RequireSystemRole(ur, domain.SystemRoleOperator))
+	NewAdminRouter(env, timeout, db, revocationStore, auditLogger, adminRouter)
+
+	verifiedRouter := protectedRouter.Group("")
+	// Middleware to restrict accounts with an unverified email
+	verifiedRouter.Use(middleware.EmailVerificationMiddleware(env.UnverifiedEmailPolicy))
+	NewProfileRouter(env, timeout, db, revocationStore, loginAttemptStore, passwordHasher, passwordPolicy, mailer, fileStorage, transactor, auditLogger, verifiedRouter, sessionRouter)
+	NewTaskRouter(env, timeout, db, verifiedRouter)
+	NewLoanRouter(env, timeout, db, verifiedRouter)
+	NewGroupRouter(env, timeout, db, groupUsecase, transactor, auditLogger, verifiedRouter)
+	NewEventRouter(env, timeout, db, broker, groupUsecase, verifiedRouter)
+
+	accountRouter := verifiedRouter.Group("")
+	accountRouter.Use(middleware.RequireSession())
+	NewTwoFactorRouter(env, timeout, db, tokenManager, revocationStore, loginAttemptStore, auditLogger, accountRouter)
+	NewPersonalAccessTokenRouter(env, timeout, db, personalAccessTokenUsecase, auditLogger, accountRouter)
+}
+
+// newTokenRevocationStore keeps revocations in Mongo unless the memory store
+// is configured, which only works for a single instance.
+func newTokenRevocationStore(env *bootstrap.Env, db mongo.Database) domain.TokenRevocationStore {
+	if env.TokenRevocationStore == "memory" {
+		return memstore.NewTokenRevocationStore()
+	}
+	return repository.NewTokenRevocationRepository(db, domain.CollectionTokenRevocation)
+}
+
+// newLoginAttemptStore counts failed logins in Mongo unless the memory store
+// is configured, in which case each instance counts on its own.
+func newLoginAttemptStore(env *bootstrap.Env, db mongo.Database) domain.LoginAttemptStore {
+	if env.LoginAttemptStore == "memory" {
+		return memstore.NewLoginAttemptStore()
+	}
+	return repository.NewLoginAttemptRepository(db, domain.CollectionLoginAttempt)
 }
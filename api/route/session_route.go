@@ -0,0 +1,25 @@
+package route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewSessionRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, revocationStore domain.TokenRevocationStore, auditLogger domain.AuditLogger, group *gin.RouterGroup) {
+	sr := repository.NewSessionRepository(db, domain.CollectionSession)
+	rtr := repository.NewRefreshTokenRepository(db, domain.CollectionRefreshToken)
+	sc := &controller.SessionController{
+		SessionUsecase: usecase.NewSessionUsecase(sr, rtr, revocationStore, timeout),
+		AuditLogger:    auditLogger,
+		Env:            env,
+	}
+	group.GET("/sessions", sc.Fetch)
+	group.DELETE("/sessions/:id", sc.Revoke)
+}
@@ -6,17 +6,23 @@ import (
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
 	"github.com/gin-gonic/gin"
 )
 
-func NewSignupRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, group *gin.RouterGroup) {
+func NewSignupRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, tokenManager *tokenutil.TokenManager, passwordHasher domain.PasswordHasher, passwordPolicy domain.PasswordPolicy, mailer domain.Mailer, auditLogger domain.AuditLogger, group *gin.RouterGroup) {
 	ur := repository.NewUserRepository(db, domain.CollectionUser)
+	rtr := repository.NewRefreshTokenRepository(db, domain.CollectionRefreshToken)
+	sr := repository.NewSessionRepository(db, domain.CollectionSession)
+	utr := repository.NewUserTokenRepository(db, domain.CollectionUserToken)
 	sc := controller.SignupController{
-		SignupUsecase: usecase.NewSignupUsecase(ur, timeout),
-		Env:           env,
+		SignupUsecase:            usecase.NewSignupUsecase(ur, rtr, sr, passwordHasher, passwordPolicy, tokenManager, timeout),
+		EmailVerificationUsecase: usecase.NewEmailVerificationUsecase(ur, utr, mailer, timeout),
+		AuditLogger:              auditLogger,
+		Env:                      env,
 	}
 	group.POST("/signup", sc.Signup)
 }
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/middleware"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
@@ -17,6 +18,6 @@ func NewTaskRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database,
 	tc := &controller.TaskController{
 		TaskUsecase: usecase.NewTaskUsecase(tr, timeout),
 	}
-	group.GET("/task", tc.Fetch)
-	group.POST("/task", tc.Create)
+	group.GET("/task", middleware.RequireScope(domain.ScopeTasksRead), tc.Fetch)
+	group.POST("/task", middleware.RequireScope(domain.ScopeTasksWrite), tc.Create)
 }
@@ -0,0 +1,26 @@
+package route
+
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/api/controller"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func NewTwoFactorRouter(env *bootstrap.Env, timeout time.Duration, db mongo.Database, tokenManager *tokenutil.TokenManager, revocationStore domain.TokenRevocationStore, loginAttemptStore domain.LoginAttemptStore, auditLogger domain.AuditLogger, group *gin.RouterGroup) {
+	ur := repository.NewUserRepository(db, domain.CollectionUser)
+	tc := &controller.TwoFactorController{
+		TwoFactorUsecase: usecase.NewTwoFactorUsecase(ur, loginAttemptStore, revocationStore, loginThrottlePolicy(env), tokenManager, timeout),
+		AuditLogger:      auditLogger,
+		Env:              env,
+	}
+	group.POST("/2fa/enroll", tc.Enroll)
+	group.POST("/2fa/enable", tc.Enable)
+	group.POST("/2fa/disable", tc.Disable)
+}
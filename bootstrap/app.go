@@ -1,16 +1,38 @@
 package bootstrap
 
-import "github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+import (
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/eventbroker"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
+)
 
 type Application struct {
-	Env   *Env
-	Mongo mongo.Client
+	Env            *Env
+	Mongo          mongo.Client
+	EventBroker    domain.EventBroker
+	TokenManager   *tokenutil.TokenManager
+	Mailer         domain.Mailer
+	PasswordHasher domain.PasswordHasher
+	PasswordPolicy domain.PasswordPolicy
+	FileStorage    domain.FileStorage
+	Transactor     domain.Transactor
 }
 
 func App() Application {
 	app := &Application{}
 	app.Env = NewEnv()
 	app.Mongo = NewMongoDatabase(app.Env)
+	app.EventBroker = eventbroker.NewEventBroker(app.Env.EventLogSize, time.Duration(app.Env.EventLogIdleMinute)*time.Minute)
+	app.TokenManager = NewTokenManager(app.Env)
+	app.Mailer = NewMailer(app.Env)
+	app.PasswordHasher = NewPasswordHasher(app.Env)
+	app.PasswordPolicy = NewPasswordPolicy(app.Env)
+	app.FileStorage = NewFileStorage(app.Env)
+	app.Transactor = repository.NewTransactor(app.Mongo)
 	return *app
 }
 
@@ -7,18 +7,66 @@ import (
 )
 
 type Env struct {
-	AppEnv                 string `mapstructure:"APP_ENV"`
-	ServerAddress          string `mapstructure:"SERVER_ADDRESS"`
-	ContextTimeout         int    `mapstructure:"CONTEXT_TIMEOUT"`
-	DBHost                 string `mapstructure:"DB_HOST"`
-	DBPort                 string `mapstructure:"DB_PORT"`
-	DBUser                 string `mapstructure:"DB_USER"`
-	DBPass                 string `mapstructure:"DB_PASS"`
-	DBName                 string `mapstructure:"DB_NAME"`
-	AccessTokenExpiryHour  int    `mapstructure:"ACCESS_TOKEN_EXPIRY_HOUR"`
-	RefreshTokenExpiryHour int    `mapstructure:"REFRESH_TOKEN_EXPIRY_HOUR"`
-	AccessTokenSecret      string `mapstructure:"ACCESS_TOKEN_SECRET"`
-	RefreshTokenSecret     string `mapstructure:"REFRESH_TOKEN_SECRET"`
+	AppEnv                             string `mapstructure:"APP_ENV"`
+	ServerAddress                      string `mapstructure:"SERVER_ADDRESS"`
+	ContextTimeout                     int    `mapstructure:"CONTEXT_TIMEOUT"`
+	DBHost                             string `mapstructure:"DB_HOST"`
+	DBPort                             string `mapstructure:"DB_PORT"`
+	DBUser                             string `mapstructure:"DB_USER"`
+	DBPass                             string `mapstructure:"DB_PASS"`
+	DBName                             string `mapstructure:"DB_NAME"`
+	AccessTokenExpiryHour              int    `mapstructure:"ACCESS_TOKEN_EXPIRY_HOUR"`
+	RefreshTokenExpiryHour             int    `mapstructure:"REFRESH_TOKEN_EXPIRY_HOUR"`
+	AccessTokenSecret                  string `mapstructure:"ACCESS_TOKEN_SECRET"`
+	RefreshTokenSecret                 string `mapstructure:"REFRESH_TOKEN_SECRET"`
+	TokenIssuer                        string `mapstructure:"TOKEN_ISSUER"`
+	TokenAudience                      string `mapstructure:"TOKEN_AUDIENCE"`
+	TokenLeewaySeconds                 int    `mapstructure:"TOKEN_LEEWAY_SECONDS"`
+	JWTSigningKeyFile                  string `mapstructure:"JWT_SIGNING_KEY_FILE"`
+	JWTVerificationKeyFiles            string `mapstructure:"JWT_VERIFICATION_KEY_FILES"`
+	EventLogSize                       int    `mapstructure:"EVENT_LOG_SIZE"`
+	EventLogIdleMinute                 int    `mapstructure:"EVENT_LOG_IDLE_MINUTE"`
+	OutboxPollIntervalMs               int    `mapstructure:"OUTBOX_POLL_INTERVAL_MS"`
+	TokenRevocationStore               string `mapstructure:"TOKEN_REVOCATION_STORE"`
+	SMTPHost                           string `mapstructure:"SMTP_HOST"`
+	SMTPPort                           string `mapstructure:"SMTP_PORT"`
+	SMTPUser                           string `mapstructure:"SMTP_USER"`
+	SMTPPass                           string `mapstructure:"SMTP_PASS"`
+	MailFrom                           string `mapstructure:"MAIL_FROM"`
+	PasswordResetURL                   string `mapstructure:"PASSWORD_RESET_URL"`
+	PasswordResetTokenExpiryMinute     int    `mapstructure:"PASSWORD_RESET_TOKEN_EXPIRY_MINUTE"`
+	PasswordResetEmailLimit            int    `mapstructure:"PASSWORD_RESET_EMAIL_LIMIT"`
+	PasswordResetIPLimit               int    `mapstructure:"PASSWORD_RESET_IP_LIMIT"`
+	EmailVerificationURL               string `mapstructure:"EMAIL_VERIFICATION_URL"`
+	EmailVerificationTokenExpiryMinute int    `mapstructure:"EMAIL_VERIFICATION_TOKEN_EXPIRY_MINUTE"`
+	EmailVerificationResendLimit       int    `mapstructure:"EMAIL_VERIFICATION_RESEND_LIMIT"`
+	UnverifiedEmailPolicy              string `mapstructure:"UNVERIFIED_EMAIL_POLICY"`
+	TOTPIssuer                         string `mapstructure:"TOTP_ISSUER"`
+	TwoFactorChallengeExpiryMinute     int    `mapstructure:"TWO_FACTOR_CHALLENGE_EXPIRY_MINUTE"`
+	LoginAttemptStore                  string `mapstructure:"LOGIN_ATTEMPT_STORE"`
+	LoginMaxFailedAttempts             int    `mapstructure:"LOGIN_MAX_FAILED_ATTEMPTS"`
+	LoginMaxFailedAttemptsPerIP        int    `mapstructure:"LOGIN_MAX_FAILED_ATTEMPTS_PER_IP"`
+	LoginLockoutMinute                 int    `mapstructure:"LOGIN_LOCKOUT_MINUTE"`
+	ReauthMaxAgeMinute                 int    `mapstructure:"REAUTH_MAX_AGE_MINUTE"`
+	OIDCProvider                       string `mapstructure:"OIDC_PROVIDER"`
+	OIDCIssuer                         string `mapstructure:"OIDC_ISSUER"`
+	OIDCClientID                       string `mapstructure:"OIDC_CLIENT_ID"`
+	OIDCClientSecret                   string `mapstructure:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL                    string `mapstructure:"OIDC_REDIRECT_URL"`
+	Argon2MemoryKiB                    uint32 `mapstructure:"ARGON2_MEMORY_KIB"`
+	Argon2Iterations                   uint32 `mapstructure:"ARGON2_ITERATIONS"`
+	Argon2Parallelism                  uint8  `mapstructure:"ARGON2_PARALLELISM"`
+	PasswordMinLength                  int    `mapstructure:"PASSWORD_MIN_LENGTH"`
+	PasswordMaxBytes                   int    `mapstructure:"PASSWORD_MAX_BYTES"`
+	PasswordBreachListPath             string `mapstructure:"PASSWORD_BREACH_LIST_PATH"`
+	StorageDir                         string `mapstructure:"STORAGE_DIR"`
+	StorageURLPath                     string `mapstructure:"STORAGE_URL_PATH"`
+	DataExportExpiryHour               int    `mapstructure:"DATA_EXPORT_EXPIRY_HOUR"`
+	DataExportPollIntervalMs           int    `mapstructure:"DATA_EXPORT_POLL_INTERVAL_MS"`
+	LateFeePollIntervalMinute          int    `mapstructure:"LATE_FEE_POLL_INTERVAL_MINUTE"`
+	AuditLogBufferSize                 int    `mapstructure:"AUDIT_LOG_BUFFER_SIZE"`
+	AuditHashSecret                    string `mapstructure:"AUDIT_HASH_SECRET"`
+	AuthCookieDomain                   string `mapstructure:"AUTH_COOKIE_DOMAIN"`
 }
 
 func NewEnv() *Env {
@@ -0,0 +1,120 @@
+package bootstrap
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes creates the indexes the repositories rely on. TTL indexes let
+// Mongo drop token records once they expire.
+func EnsureIndexes(db mongo.Database) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ttl := mongodriver.IndexModel{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+
+	for _, collection := range []string{domain.CollectionTokenRevocation, domain.CollectionRefreshToken, domain.CollectionUserToken, domain.CollectionLoginAttempt, domain.CollectionOIDCState, domain.CollectionSession} {
+		_, err := db.Collection(collection).CreateIndex(ctx, ttl)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	tokenHash := mongodriver.IndexModel{
+		Keys:    bson.D{{Key: "tokenHash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err := db.Collection(domain.CollectionPersonalAccessToken).CreateIndex(ctx, tokenHash)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	groupMember := mongodriver.IndexModel{
+		Keys:    bson.D{{Key: "groupID", Value: 1}, {Key: "userID", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err = db.Collection(domain.CollectionGroupMember).CreateIndex(ctx, groupMember)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	groupWallet := mongodriver.IndexModel{
+		Keys:    bson.D{{Key: "groupID", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err = db.Collection(domain.CollectionGroupWallet).CreateIndex(ctx, groupWallet)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	walletEntryWallet := mongodriver.IndexModel{Keys: bson.D{{Key: "walletID", Value: 1}, {Key: "_id", Value: 1}}}
+	walletEntryUser := mongodriver.IndexModel{Keys: bson.D{{Key: "userID", Value: 1}}}
+	walletEntryShare := mongodriver.IndexModel{Keys: bson.D{{Key: "shares.userID", Value: 1}}}
+	for _, index := range []mongodriver.IndexModel{walletEntryWallet, walletEntryUser, walletEntryShare} {
+		_, err = db.Collection(domain.CollectionWalletEntry).CreateIndex(ctx, index)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Deleted accounts have an empty email.
+	email := mongodriver.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"email": bson.M{"$gt": ""}}),
+	}
+	_, err = db.Collection(domain.CollectionUser).CreateIndex(ctx, email)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	identity := mongodriver.IndexModel{
+		Keys:    bson.D{{Key: "identities.provider", Value: 1}, {Key: "identities.subject", Value: 1}},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"identities": bson.M{"$exists": true}}),
+	}
+	_, err = db.Collection(domain.CollectionUser).CreateIndex(ctx, identity)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	groupDebt := mongodriver.IndexModel{Keys: bson.D{{Key: "groupID", Value: 1}, {Key: "_id", Value: 1}}}
+	overdueGroupDebt := mongodriver.IndexModel{
+		Keys:    bson.D{{Key: "groupID", Value: 1}, {Key: "dueDate", Value: 1}},
+		Options: options.Index().SetPartialFilterExpression(bson.M{"balance": bson.M{"$gt": 0}}),
+	}
+	groupDebtDebtor := mongodriver.IndexModel{Keys: bson.D{{Key: "debtorID", Value: 1}}}
+	groupDebtCreditor := mongodriver.IndexModel{Keys: bson.D{{Key: "creditorID", Value: 1}}}
+	for _, index := range []mongodriver.IndexModel{groupDebt, overdueGroupDebt, groupDebtDebtor, groupDebtCreditor} {
+		_, err = db.Collection(domain.CollectionGroupDebt).CreateIndex(ctx, index)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	groupLedgerGroup := mongodriver.IndexModel{Keys: bson.D{{Key: "groupID", Value: 1}, {Key: "_id", Value: 1}}}
+	groupLedgerDebt := mongodriver.IndexModel{Keys: bson.D{{Key: "debtID", Value: 1}, {Key: "_id", Value: 1}}}
+	for _, index := range []mongodriver.IndexModel{groupLedgerGroup, groupLedgerDebt} {
+		_, err = db.Collection(domain.CollectionGroupLedger).CreateIndex(ctx, index)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	auditUser := mongodriver.IndexModel{Keys: bson.D{{Key: "userID", Value: 1}, {Key: "_id", Value: -1}}}
+	auditActor := mongodriver.IndexModel{Keys: bson.D{{Key: "actorID", Value: 1}, {Key: "_id", Value: -1}}}
+	for _, index := range []mongodriver.IndexModel{auditUser, auditActor} {
+		_, err = db.Collection(domain.CollectionAuditEvent).CreateIndex(ctx, index)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"log"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/mailutil"
+)
+
+func NewMailer(env *Env) domain.Mailer {
+	if env.SMTPHost == "" {
+		if env.AppEnv != "development" {
+			log.Fatal("SMTP_HOST must be set outside development")
+		}
+		return mailutil.NewLogMailer()
+	}
+	return mailutil.NewSMTPMailer(env.SMTPHost, env.SMTPPort, env.SMTPUser, env.SMTPPass, env.MailFrom)
+}
@@ -0,0 +1,43 @@
+package bootstrap
+
+import (
+	"log"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/passwordutil"
+)
+
+func NewPasswordHasher(env *Env) domain.PasswordHasher {
+	if env.Argon2MemoryKiB == 0 || env.Argon2Iterations == 0 || env.Argon2Parallelism == 0 {
+		log.Fatal("ARGON2_MEMORY_KIB, ARGON2_ITERATIONS and ARGON2_PARALLELISM must be set")
+	}
+	return passwordutil.NewArgon2idHasher(passwordutil.Argon2idParams{
+		Memory:      env.Argon2MemoryKiB,
+		Iterations:  env.Argon2Iterations,
+		Parallelism: env.Argon2Parallelism,
+	})
+}
+
+func NewPasswordPolicy(env *Env) domain.PasswordPolicy {
+	maxBytes := env.PasswordMaxBytes
+	if maxBytes == 0 {
+		maxBytes = passwordutil.BcryptMaxBytes
+	}
+	if maxBytes > passwordutil.BcryptMaxBytes {
+		log.Fatal("PASSWORD_MAX_BYTES must not be more than ", passwordutil.BcryptMaxBytes)
+	}
+
+	var breaches domain.BreachedPasswordChecker
+	if env.PasswordBreachListPath != "" {
+		list, err := passwordutil.LoadBreachList(env.PasswordBreachListPath)
+		if err != nil {
+			log.Fatal("Breached password list can't be loaded: ", err)
+		}
+		breaches = list
+	}
+
+	return passwordutil.NewPolicy(passwordutil.PolicyParams{
+		MinLength: env.PasswordMinLength,
+		MaxBytes:  maxBytes,
+	}, breaches)
+}
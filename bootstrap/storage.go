@@ -0,0 +1,15 @@
+package bootstrap
+
+import (
+	"log"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/storageutil"
+)
+
+func NewFileStorage(env *Env) domain.FileStorage {
+	if env.StorageDir == "" || env.StorageURLPath == "" {
+		log.Fatal("STORAGE_DIR and STORAGE_URL_PATH must be set")
+	}
+	return storageutil.NewLocalStorage(env.StorageDir, env.StorageURLPath)
+}
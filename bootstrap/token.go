@@ -0,0 +1,34 @@
+package bootstrap
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+)
+
+// NewTokenManager signs access tokens with the configured PEM keys, or with
+// ACCESS_TOKEN_SECRET when JWT_SIGNING_KEY_FILE is not set.
+// JWT_VERIFICATION_KEY_FILES is a comma separated list of keys that are still
+// accepted, such as the previous signing key during a rotation.
+func NewTokenManager(env *Env) *tokenutil.TokenManager {
+	var keys *tokenutil.KeySet
+	if env.JWTSigningKeyFile != "" {
+		var verificationKeyFiles []string
+		for _, file := range strings.Split(env.JWTVerificationKeyFiles, ",") {
+			if file = strings.TrimSpace(file); file != "" {
+				verificationKeyFiles = append(verificationKeyFiles, file)
+			}
+		}
+
+		var err error
+		keys, err = tokenutil.LoadKeySet(env.JWTSigningKeyFile, verificationKeyFiles)
+		if err != nil {
+			log.Fatal("Signing keys can't be loaded: ", err)
+		}
+	}
+
+	leeway := time.Duration(env.TokenLeewaySeconds) * time.Second
+	return tokenutil.NewTokenManager(env.TokenIssuer, env.TokenAudience, leeway, keys)
+}
@@ -1,10 +1,16 @@
 package main
 
 import (
+	"context"
 	"time"
 
 	route "github.com/amitshekhariitbhu/go-backend-clean-architecture/api/route"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/bootstrap"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/auditlog"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/eventbus"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/jobutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/repository"
 	"github.com/gin-gonic/gin"
 )
 
@@ -17,11 +23,43 @@ func main() {
 	db := app.Mongo.Database(env.DBName)
 	defer app.CloseDBConnection()
 
+	bootstrap.EnsureIndexes(db)
+
 	timeout := time.Duration(env.ContextTimeout) * time.Second
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := eventbus.NewBus()
+	// The real-time relay is the only subscriber. Notifications, webhooks and
+	// cache invalidation are out of scope for now and would subscribe here.
+	bus.Subscribe("realtime", eventbus.Deduplicate(eventbus.RelayToBroker(app.EventBroker), 1000),
+		domain.DomainEventExpenseAdded,
+		domain.DomainEventPaymentRecorded,
+		domain.DomainEventMemberJoined,
+		domain.DomainEventMemberLeft,
+	)
+
+	or := repository.NewOutboxRepository(db, domain.CollectionOutbox)
+	pollInterval := time.Duration(env.OutboxPollIntervalMs) * time.Millisecond
+	go eventbus.NewDispatcher(or, bus, pollInterval, timeout).Run(ctx)
+
+	auditLogger := auditlog.NewLogger(repository.NewAuditRepository(db, domain.CollectionAuditEvent), env.AuditLogBufferSize, timeout)
+	go auditLogger.Run(ctx)
+
+	dataExportUsecase := route.NewDataExportUsecase(timeout, db, app.FileStorage, app.Mailer)
+	go jobutil.Run(ctx, "Data export", time.Duration(env.DataExportPollIntervalMs)*time.Millisecond, func(ctx context.Context) error {
+		return dataExportUsecase.ProcessPending(ctx, env.DataExportExpiryHour)
+	})
+
+	groupDebtUsecase := route.NewGroupDebtUsecase(timeout, db, app.Transactor)
+	go jobutil.Run(ctx, "Late fees", time.Duration(env.LateFeePollIntervalMinute)*time.Minute, func(ctx context.Context) error {
+		return groupDebtUsecase.ApplyLateFees(ctx, time.Now())
+	})
+
 	gin := gin.Default()
 
-	route.Setup(env, timeout, db, gin)
+	route.Setup(env, timeout, db, app.EventBroker, app.TokenManager, app.Mailer, app.PasswordHasher, app.PasswordPolicy, app.FileStorage, app.Transactor, auditLogger, gin)
 
 	gin.Run(env.ServerAddress)
 }
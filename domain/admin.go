@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrInvalidUserCursor = errors.New("Invalid page cursor")
+
+const (
+	defaultUserPageSize int64 = 50
+	maxUserPageSize     int64 = 200
+)
+
+type UserQuery struct {
+	// Search matches part of the name or the email, ignoring case.
+	Search string `form:"q"`
+	// Before is the ID of the last user of the previous page.
+	Before string `form:"before"`
+	Limit  int64  `form:"limit" binding:"omitempty,min=1"`
+}
+
+// PageSize returns the requested limit within the allowed range.
+func (q *UserQuery) PageSize() int64 {
+	if q.Limit <= 0 {
+		return defaultUserPageSize
+	}
+	if q.Limit > maxUserPageSize {
+		return maxUserPageSize
+	}
+	return q.Limit
+}
+
+// AdminUser is the view of an account operators get, without credentials.
+type AdminUser struct {
+	ID               primitive.ObjectID `json:"id"`
+	Name             string             `json:"name"`
+	Email            string             `json:"email"`
+	DisplayName      string             `json:"displayName,omitempty"`
+	SystemRole       string             `json:"systemRole,omitempty"`
+	EmailVerifiedAt  *time.Time         `json:"emailVerifiedAt,omitempty"`
+	TwoFactorEnabled bool               `json:"twoFactorEnabled"`
+	DisabledAt       *time.Time         `json:"disabledAt,omitempty"`
+	DeletedAt        *time.Time         `json:"deletedAt,omitempty"`
+	CreatedAt        time.Time          `json:"createdAt"`
+}
+
+func NewAdminUser(user *User) AdminUser {
+	return AdminUser{
+		ID:               user.ID,
+		Name:             user.Name,
+		Email:            user.Email,
+		DisplayName:      user.DisplayName,
+		SystemRole:       user.SystemRole,
+		EmailVerifiedAt:  user.EmailVerifiedAt,
+		TwoFactorEnabled: user.TwoFactor != nil && user.TwoFactor.EnabledAt != nil,
+		DisabledAt:       user.DisabledAt,
+		DeletedAt:        user.DeletedAt,
+		CreatedAt:        user.ID.Timestamp(),
+	}
+}
+
+type AdminUserPage struct {
+	Users []AdminUser `json:"users"`
+	// Next is the Before of the next page, empty on the last one.
+	Next string `json:"next,omitempty"`
+}
+
+type AdminUserDetail struct {
+	AdminUser
+	Groups []GroupMember `json:"groups"`
+}
+
+type AdminUsecase interface {
+	FetchUsers(c context.Context, query *UserQuery) (AdminUserPage, error)
+	GetUser(c context.Context, userID string) (AdminUserDetail, error)
+	// DisableUser blocks new logins and ends every session and personal
+	// access token of the user.
+	DisableUser(c context.Context, userID string, accessTokenExpiry int) error
+	EnableUser(c context.Context, userID string) error
+	// ForceLogout ends every session of the user.
+	ForceLogout(c context.Context, userID string, accessTokenExpiry int) error
+	// ResetTwoFactor removes the second factor of a user who lost it.
+	ResetTwoFactor(c context.Context, userID string) error
+}
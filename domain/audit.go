@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionAuditEvent = "audit_events"
+)
+
+const (
+	AuditActionSignup            = "signup"
+	AuditActionLogin             = "login"
+	AuditActionLoginTwoFactor    = "login_two_factor"
+	AuditActionTokenRefresh      = "token_refresh"
+	AuditActionLogout            = "logout"
+	AuditActionLogoutAll         = "logout_all"
+	AuditActionPasswordChange    = "password_change"
+	AuditActionPasswordReset     = "password_reset"
+	AuditActionEmailChange       = "email_change"
+	AuditActionTwoFactorEnable   = "two_factor_enable"
+	AuditActionTwoFactorDisable  = "two_factor_disable"
+	AuditActionSessionRevoke     = "session_revoke"
+	AuditActionAccessTokenCreate = "access_token_create"
+	AuditActionAccessTokenRevoke = "access_token_revoke"
+	AuditActionGroupRoleChange   = "group_role_change"
+	AuditActionAccountDelete     = "account_delete"
+	AuditActionAccountErase      = "account_erase"
+	AuditActionAccountDisable    = "account_disable"
+	AuditActionAccountEnable     = "account_enable"
+	AuditActionForceLogout       = "force_logout"
+	AuditActionTwoFactorReset    = "two_factor_reset"
+)
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+// AuditReasonInternal is the reason recorded for failures that are not
+// expected domain errors, so that database and other internal error messages
+// stay out of the log.
+const AuditReasonInternal = "internal_error"
+
+var ErrInvalidAuditCursor = errors.New("Invalid page cursor")
+
+const (
+	defaultAuditPageSize int64 = 50
+	maxAuditPageSize     int64 = 200
+)
+
+// AuditEvent records a security-relevant action. UserID is the account the
+// action concerns and ActorID the user who took it; they differ when one user
+// changes another's role. Either is empty when the user is not known, such as
+// for a login with an unknown email. Reason is the message of the domain error
+// a failed action ended with, or AuditReasonInternal.
+type AuditEvent struct {
+	ID         primitive.ObjectID `bson:"_id" json:"id"`
+	Action     string             `bson:"action" json:"action"`
+	Outcome    string             `bson:"outcome" json:"outcome"`
+	Reason     string             `bson:"reason,omitempty" json:"reason,omitempty"`
+	UserID     string             `bson:"userID,omitempty" json:"userID,omitempty"`
+	ActorID    string             `bson:"actorID,omitempty" json:"actorID,omitempty"`
+	IP         string             `bson:"ip" json:"ip"`
+	UserAgent  string             `bson:"userAgent" json:"userAgent"`
+	Details    map[string]string  `bson:"details,omitempty" json:"details,omitempty"`
+	OccurredAt time.Time          `bson:"occurredAt" json:"occurredAt"`
+}
+
+type AuditQuery struct {
+	// UserID is only honoured for operators; users always see their own
+	// events.
+	UserID string `form:"userID"`
+	Action string `form:"action"`
+	// Before is the ID of the last event of the previous page.
+	Before string `form:"before"`
+	Limit  int64  `form:"limit" binding:"omitempty,min=1"`
+}
+
+// PageSize returns the requested limit within the allowed range.
+func (q *AuditQuery) PageSize() int64 {
+	if q.Limit <= 0 {
+		return defaultAuditPageSize
+	}
+	if q.Limit > maxAuditPageSize {
+		return maxAuditPageSize
+	}
+	return q.Limit
+}
+
+type AuditEventPage struct {
+	Events []AuditEvent `json:"events"`
+	// Next is the Before of the next page, empty on the last one.
+	Next string `json:"next,omitempty"`
+}
+
+// AuditLogger records events without waiting for the store. Events may be
+// lost if the store cannot keep up.
+type AuditLogger interface {
+	Log(event AuditEvent)
+}
+
+type AuditRepository interface {
+	Insert(c context.Context, events []AuditEvent) error
+	// Fetch returns the events matching the query, newest first. A user ID
+	// matches events the user is the subject or the actor of. A limit of 0
+	// returns every event.
+	Fetch(c context.Context, query *AuditQuery, limit int64) ([]AuditEvent, error)
+	// AnonymizeUser removes the email from the details of the events the user
+	// is the subject or the actor of. It is the only change made to events.
+	AnonymizeUser(c context.Context, userID string) error
+}
+
+type AuditUsecase interface {
+	FetchByUserID(c context.Context, userID string, query *AuditQuery) (AuditEventPage, error)
+	// Fetch returns the events of every user, for operators.
+	Fetch(c context.Context, query *AuditQuery) (AuditEventPage, error)
+}
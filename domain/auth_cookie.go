@@ -0,0 +1,22 @@
+package domain
+
+import "errors"
+
+// Browser clients pass ?mode=cookie when they sign in to get the tokens as
+// HttpOnly cookies rather than in the body. Unsafe requests authenticated by
+// these cookies must repeat the CSRF cookie in the CSRF header.
+const (
+	TokenModeCookie    = "cookie"
+	AccessTokenCookie  = "access_token"
+	RefreshTokenCookie = "refresh_token"
+	CSRFTokenCookie    = "csrf_token"
+	CSRFTokenHeader    = "X-CSRF-Token"
+)
+
+var ErrInvalidCSRFToken = errors.New("Missing or invalid CSRF token")
+
+// CookieLoginResponse answers a sign-in in cookie mode. The CSRF token is
+// also set as a readable cookie.
+type CookieLoginResponse struct {
+	CSRFToken string `json:"csrfToken"`
+}
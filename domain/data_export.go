@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionDataExport = "data_exports"
+)
+
+const (
+	DataExportStatusPending = "pending"
+	DataExportStatusRunning = "running"
+	DataExportStatusReady   = "ready"
+	DataExportStatusFailed  = "failed"
+)
+
+var (
+	ErrDataExportNotFound = errors.New("Data export not found")
+	ErrDataExportNotReady = errors.New("Data export is not ready yet")
+)
+
+// DataExport is a request for an archive of everything stored about a user.
+// It is built in the background; DownloadURL is set once it is ready.
+type DataExport struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	UserID      primitive.ObjectID `bson:"userID" json:"-"`
+	Status      string             `bson:"status" json:"status"`
+	FileKey     string             `bson:"fileKey,omitempty" json:"-"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+	ClaimedAt   *time.Time         `bson:"claimedAt,omitempty" json:"-"`
+	CompletedAt *time.Time         `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+	ExpiresAt   *time.Time         `bson:"expiresAt,omitempty" json:"expiresAt,omitempty"`
+	DownloadURL string             `bson:"-" json:"downloadURL,omitempty"`
+}
+
+type DataExportRepository interface {
+	Create(c context.Context, export *DataExport) error
+	GetByID(c context.Context, id string, userID string) (DataExport, error)
+	// GetUnfinishedByUserID returns a pending or running export of the user.
+	GetUnfinishedByUserID(c context.Context, userID string) (DataExport, error)
+	FetchByUserID(c context.Context, userID string) ([]DataExport, error)
+	// Claim marks the oldest pending export as running and returns it. An
+	// export claimed before staleBefore is claimed again, as its worker is
+	// assumed to have stopped.
+	Claim(c context.Context, now time.Time, staleBefore time.Time) (DataExport, error)
+	Complete(c context.Context, id primitive.ObjectID, status string, fileKey string, completedAt time.Time, expiresAt time.Time) error
+	FetchExpired(c context.Context, now time.Time) ([]DataExport, error)
+	Delete(c context.Context, id primitive.ObjectID) error
+	DeleteAllForUser(c context.Context, userID string) error
+}
+
+type DataExportUsecase interface {
+	// RequestExport returns the unfinished export of the user, if any,
+	// instead of starting another one.
+	RequestExport(c context.Context, userID string) (DataExport, error)
+	GetExport(c context.Context, userID string, exportID string) (DataExport, error)
+	OpenExport(c context.Context, userID string, exportID string) (io.ReadCloser, error)
+	// ProcessPending builds the archives of the pending exports, which stay
+	// available for expiryHour hours, and deletes the expired ones.
+	ProcessPending(c context.Context, expiryHour int) error
+}
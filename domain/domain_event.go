@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionOutbox = "outbox"
+
+	// OutboxMaxAttempts is the number of failed deliveries after which an
+	// event is left in the outbox for manual inspection.
+	OutboxMaxAttempts = 10
+)
+
+const (
+	DomainEventExpenseAdded    = "expense_added"
+	DomainEventPaymentRecorded = "payment_recorded"
+	DomainEventMemberJoined    = "member_joined"
+	DomainEventMemberLeft      = "member_left"
+)
+
+type DomainEventPayload interface {
+	EventType() string
+	AggregateID() string
+}
+
+type ExpenseAdded struct {
+	GroupID   string `bson:"groupID" json:"groupID"`
+	ExpenseID string `bson:"expenseID" json:"expenseID"`
+	PaidBy    string `bson:"paidBy" json:"paidBy"`
+	Amount    int64  `bson:"amount" json:"amount"`
+	Currency  string `bson:"currency" json:"currency"`
+}
+
+func (e ExpenseAdded) EventType() string   { return DomainEventExpenseAdded }
+func (e ExpenseAdded) AggregateID() string { return e.GroupID }
+
+type PaymentRecorded struct {
+	GroupID    string `bson:"groupID" json:"groupID"`
+	PaymentID  string `bson:"paymentID" json:"paymentID"`
+	FromUserID string `bson:"fromUserID" json:"fromUserID"`
+	ToUserID   string `bson:"toUserID" json:"toUserID"`
+	Amount     int64  `bson:"amount" json:"amount"`
+	Currency   string `bson:"currency" json:"currency"`
+}
+
+func (e PaymentRecorded) EventType() string   { return DomainEventPaymentRecorded }
+func (e PaymentRecorded) AggregateID() string { return e.GroupID }
+
+type MemberJoined struct {
+	GroupID string `bson:"groupID" json:"groupID"`
+	UserID  string `bson:"userID" json:"userID"`
+	Role    string `bson:"role" json:"role"`
+}
+
+func (e MemberJoined) EventType() string   { return DomainEventMemberJoined }
+func (e MemberJoined) AggregateID() string { return e.GroupID }
+
+// MemberLeft is recorded both when a member leaves and when they are removed.
+type MemberLeft struct {
+	GroupID string `bson:"groupID" json:"groupID"`
+	UserID  string `bson:"userID" json:"userID"`
+}
+
+func (e MemberLeft) EventType() string   { return DomainEventMemberLeft }
+func (e MemberLeft) AggregateID() string { return e.GroupID }
+
+// DomainEvent is the outbox record of a payload. Its ID is stable across
+// redeliveries so that subscribers can deduplicate.
+type DomainEvent struct {
+	ID           primitive.ObjectID `bson:"_id" json:"id"`
+	Type         string             `bson:"type" json:"type"`
+	AggregateID  string             `bson:"aggregateID" json:"aggregateID"`
+	Payload      bson.Raw           `bson:"payload" json:"-"`
+	OccurredAt   time.Time          `bson:"occurredAt" json:"occurredAt"`
+	DispatchedAt *time.Time         `bson:"dispatchedAt,omitempty" json:"dispatchedAt,omitempty"`
+	Attempts     int                `bson:"attempts" json:"attempts"`
+}
+
+func NewDomainEvent(payload DomainEventPayload) (DomainEvent, error) {
+	raw, err := bson.Marshal(payload)
+	if err != nil {
+		return DomainEvent{}, err
+	}
+	return DomainEvent{
+		ID:          primitive.NewObjectID(),
+		Type:        payload.EventType(),
+		AggregateID: payload.AggregateID(),
+		Payload:     raw,
+		OccurredAt:  time.Now(),
+	}, nil
+}
+
+func (e DomainEvent) DecodePayload(v interface{}) error {
+	return bson.Unmarshal(e.Payload, v)
+}
+
+type OutboxRepository interface {
+	Add(c context.Context, events ...DomainEvent) error
+	FetchPending(c context.Context, limit int64) ([]DomainEvent, error)
+	MarkDispatched(c context.Context, id primitive.ObjectID) error
+	MarkFailed(c context.Context, id primitive.ObjectID) error
+}
+
+// Transactor runs fn in a Mongo transaction. Repositories called with the
+// context passed to fn take part in the same session.
+type Transactor interface {
+	WithTransaction(c context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+// What an account with an unverified email may do.
+const (
+	UnverifiedEmailPolicyAllow    = "allow"
+	UnverifiedEmailPolicyReadOnly = "read_only"
+	UnverifiedEmailPolicyBlock    = "block"
+)
+
+var (
+	ErrInvalidVerificationToken = errors.New("Verification token is invalid or expired")
+	ErrEmailAlreadyVerified     = errors.New("Email is already verified")
+)
+
+type VerifyEmailRequest struct {
+	Token string `form:"token" binding:"required"`
+}
+
+type EmailVerificationUsecase interface {
+	SendVerification(c context.Context, user *User, verifyURL string, expiryMinute int) error
+	Resend(c context.Context, userID string, verifyURL string, expiryMinute int, limitPerHour int) error
+	Verify(c context.Context, token string) error
+}
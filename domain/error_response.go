@@ -3,3 +3,14 @@ package domain
 type ErrorResponse struct {
 	Message string `json:"message"`
 }
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type ValidationErrorResponse struct {
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors"`
+}
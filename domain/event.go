@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	EventTypeResync = "resync"
+)
+
+type Event struct {
+	ID        string      `json:"id"`
+	GroupID   string      `json:"groupID"`
+	Type      string      `json:"type"`
+	Data      interface{} `json:"data,omitempty"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+type EventSubscription struct {
+	// Backlog holds the logged events published after the requested ID.
+	Backlog []Event
+	// Complete is false when some events after the requested ID were already
+	// evicted from the log, or the ID is from before a restart, and the
+	// client has to reload the group state.
+	Complete bool
+	Events   <-chan Event
+}
+
+type EventBroker interface {
+	Publish(groupID string, eventType string, data interface{}) Event
+	Subscribe(groupID string, lastEventID string) (subscription EventSubscription, unsubscribe func())
+}
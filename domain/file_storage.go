@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+// FileStorage keeps uploaded files under keys such as "avatars/<name>.jpg".
+type FileStorage interface {
+	Save(c context.Context, key string, contentType string, content io.Reader) error
+	Open(c context.Context, key string) (io.ReadCloser, error)
+	Delete(c context.Context, key string) error
+	// URL is where clients can download the file.
+	URL(key string) string
+}
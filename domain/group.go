@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionGroup       = "groups"
+	CollectionGroupMember = "group_members"
+)
+
+const (
+	GroupRoleOwner  = "owner"
+	GroupRoleAdmin  = "admin"
+	GroupRoleMember = "member"
+	GroupRoleViewer = "viewer"
+)
+
+const (
+	GroupPermissionView          = "group:view"
+	GroupPermissionWriteExpenses = "expenses:write"
+	GroupPermissionManageMembers = "members:manage"
+	GroupPermissionDelete        = "group:delete"
+)
+
+var groupRolePermissions = map[string][]string{
+	GroupRoleOwner:  {GroupPermissionView, GroupPermissionWriteExpenses, GroupPermissionManageMembers, GroupPermissionDelete},
+	GroupRoleAdmin:  {GroupPermissionView, GroupPermissionWriteExpenses, GroupPermissionManageMembers},
+	GroupRoleMember: {GroupPermissionView, GroupPermissionWriteExpenses},
+	GroupRoleViewer: {GroupPermissionView},
+}
+
+// groupRoleRanks orders the roles. Members can only be managed by someone
+// ranked above both their current and their new role.
+var groupRoleRanks = map[string]int{
+	GroupRoleOwner:  4,
+	GroupRoleAdmin:  3,
+	GroupRoleMember: 2,
+	GroupRoleViewer: 1,
+}
+
+var (
+	ErrForbidden           = errors.New("You do not have permission to do this")
+	ErrGroupNotFound       = errors.New("Group not found")
+	ErrGroupMemberNotFound = errors.New("Group member not found")
+	ErrGroupMemberExists   = errors.New("User is already a member of this group")
+	ErrUnknownGroupRole    = errors.New("Unknown group role")
+	ErrGroupOwnerImmutable = errors.New("The group owner cannot be changed or removed")
+	ErrGroupClosed         = errors.New("Group is closed")
+)
+
+func GroupRoleAllows(role string, permission string) bool {
+	for _, p := range groupRolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupRoleOutranks reports whether role is ranked above other.
+func GroupRoleOutranks(role string, other string) bool {
+	return groupRoleRanks[role] > groupRoleRanks[other]
+}
+
+func IsGroupRole(role string) bool {
+	_, ok := groupRoleRanks[role]
+	return ok
+}
+
+// Group is a set of members who share debts. LateFee, if set, is charged on
+// the debts of the group that are overdue. A closed group takes no new debts
+// and its wallet is settled.
+type Group struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	Name      string             `bson:"name" json:"name"`
+	LateFee   *LateFeePolicy     `bson:"lateFee,omitempty" json:"lateFee,omitempty"`
+	CreatedBy primitive.ObjectID `bson:"createdBy" json:"createdBy"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	ClosedAt  *time.Time         `bson:"closedAt,omitempty" json:"closedAt,omitempty"`
+}
+
+type GroupMember struct {
+	ID        primitive.ObjectID `bson:"_id" json:"-"`
+	GroupID   primitive.ObjectID `bson:"groupID" json:"groupID"`
+	UserID    primitive.ObjectID `bson:"userID" json:"userID"`
+	Role      string             `bson:"role" json:"role"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+type GroupRequest struct {
+	Name string `form:"name" binding:"required"`
+}
+
+type GroupMemberRequest struct {
+	UserID string `form:"userID" binding:"required"`
+	Role   string `form:"role" binding:"required"`
+}
+
+type GroupMemberRoleRequest struct {
+	Role string `form:"role" binding:"required"`
+}
+
+type GroupRepository interface {
+	Create(c context.Context, group *Group) error
+	GetByID(c context.Context, id string) (Group, error)
+	// SetLateFee sets the late fee policy of the group, or removes it if nil.
+	SetLateFee(c context.Context, id string, policy *LateFeePolicy) error
+	// FetchWithLateFee returns the open groups with a late fee policy.
+	FetchWithLateFee(c context.Context) ([]Group, error)
+	// Close fails with ErrGroupClosed if the group is already closed.
+	Close(c context.Context, id string) error
+}
+
+type GroupMemberRepository interface {
+	Create(c context.Context, member *GroupMember) error
+	GetByGroupAndUser(c context.Context, groupID string, userID string) (GroupMember, error)
+	FetchByGroupID(c context.Context, groupID string) ([]GroupMember, error)
+	FetchByUserID(c context.Context, userID string) ([]GroupMember, error)
+	UpdateRole(c context.Context, groupID string, userID string, role string) error
+	Delete(c context.Context, groupID string, userID string) error
+}
+
+type GroupUsecase interface {
+	Create(c context.Context, group *Group) error
+	// Authorize returns the caller's membership if their role in the group
+	// grants the permission, and ErrForbidden otherwise.
+	Authorize(c context.Context, groupID string, userID string, permission string) (GroupMember, error)
+	FetchMembers(c context.Context, groupID string) ([]GroupMember, error)
+	AddMember(c context.Context, actor *GroupMember, userID string, role string) (GroupMember, error)
+	UpdateMemberRole(c context.Context, actor *GroupMember, userID string, role string) error
+	RemoveMember(c context.Context, actor *GroupMember, userID string) error
+	// SetLateFee sets the late fee policy of the group, or removes it if nil.
+	SetLateFee(c context.Context, actor *GroupMember, policy *LateFeePolicy) (Group, error)
+	// Close closes the group for good and refunds what is left in its wallet
+	// to the contributors, in proportion to their contributions.
+	Close(c context.Context, actor *GroupMember) (Group, error)
+}
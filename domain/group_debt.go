@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionGroupDebt   = "group_debts"
+	CollectionGroupLedger = "group_ledger"
+)
+
+const (
+	LateFeeFlat          = "flat"
+	LateFeeDailyInterest = "daily_interest"
+)
+
+const (
+	GroupLedgerDebt    = "debt"
+	GroupLedgerPayment = "payment"
+	GroupLedgerLateFee = "late_fee"
+	GroupLedgerWaiver  = "waiver"
+)
+
+var (
+	ErrInvalidLateFee             = errors.New("A flat late fee needs an amount and daily interest needs a rate")
+	ErrGroupDebtNotFound          = errors.New("Debt not found")
+	ErrGroupDebtSelf              = errors.New("Debtor and creditor must be different members")
+	ErrGroupDebtParticipant       = errors.New("Debtor and creditor must be members of the group")
+	ErrGroupDebtNotCreditor       = errors.New("Only the creditor can record payments")
+	ErrGroupPaymentExceedsBalance = errors.New("Payment exceeds the remaining balance")
+	ErrGroupDebtChanged           = errors.New("Debt was modified concurrently, please retry")
+	ErrGroupChargeNotFound        = errors.New("Late charge not found")
+	ErrGroupChargeWaived          = errors.New("Late charge is already waived")
+	ErrGroupChargePaid            = errors.New("Late charge is already paid")
+)
+
+// LateFeePolicy charges the debts of a group that are still unpaid GraceDays
+// after their due date. A flat fee is charged once, daily interest is charged
+// on the overdue amount for every further day. DailyRateBP is in basis points.
+type LateFeePolicy struct {
+	Type        string `bson:"type" json:"type"`
+	Amount      int64  `bson:"amount,omitempty" json:"amount,omitempty"`
+	DailyRateBP int    `bson:"dailyRateBP,omitempty" json:"dailyRateBP,omitempty"`
+	GraceDays   int    `bson:"graceDays" json:"graceDays"`
+}
+
+func (p *LateFeePolicy) Valid() bool {
+	switch p.Type {
+	case LateFeeFlat:
+		return p.Amount > 0 && p.GraceDays >= 0
+	case LateFeeDailyInterest:
+		return p.DailyRateBP > 0 && p.GraceDays >= 0
+	}
+	return false
+}
+
+// GroupDebt is what DebtorID owes CreditorID within a group, in minor
+// currency units. Charges are the late fees less the waived ones, and Balance
+// is what is left of Amount and Charges. Late fees are charged up to
+// ChargedThrough.
+type GroupDebt struct {
+	ID             primitive.ObjectID `bson:"_id" json:"id"`
+	GroupID        primitive.ObjectID `bson:"groupID" json:"groupID"`
+	DebtorID       primitive.ObjectID `bson:"debtorID" json:"debtorID"`
+	CreditorID     primitive.ObjectID `bson:"creditorID" json:"creditorID"`
+	Amount         int64              `bson:"amount" json:"amount"`
+	Currency       string             `bson:"currency" json:"currency"`
+	Description    string             `bson:"description" json:"description"`
+	DueDate        *time.Time         `bson:"dueDate,omitempty" json:"dueDate,omitempty"`
+	Paid           int64              `bson:"paid" json:"paid"`
+	Charges        int64              `bson:"charges" json:"charges"`
+	Balance        int64              `bson:"balance" json:"balance"`
+	ChargedThrough *time.Time         `bson:"chargedThrough,omitempty" json:"chargedThrough,omitempty"`
+	SettledAt      *time.Time         `bson:"settledAt,omitempty" json:"settledAt,omitempty"`
+	CreatedBy      primitive.ObjectID `bson:"createdBy" json:"createdBy"`
+	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
+	// Revision is raised by every update, so that concurrent ones conflict.
+	Revision int64 `bson:"revision" json:"-"`
+}
+
+// GroupLedgerEntry records a change of a debt. Entries are never changed or
+// removed, a waiver is a second entry that reverses the late fee given by
+// EntryID. CreatedBy is empty for the late fees the worker charges.
+type GroupLedgerEntry struct {
+	ID        primitive.ObjectID  `bson:"_id" json:"id"`
+	GroupID   primitive.ObjectID  `bson:"groupID" json:"groupID"`
+	DebtID    primitive.ObjectID  `bson:"debtID" json:"debtID"`
+	Type      string              `bson:"type" json:"type"`
+	Amount    int64               `bson:"amount" json:"amount"`
+	Through   *time.Time          `bson:"through,omitempty" json:"through,omitempty"`
+	EntryID   *primitive.ObjectID `bson:"entryID,omitempty" json:"entryID,omitempty"`
+	CreatedBy *primitive.ObjectID `bson:"createdBy,omitempty" json:"createdBy,omitempty"`
+	CreatedAt time.Time           `bson:"createdAt" json:"createdAt"`
+}
+
+type LateFeeRequest struct {
+	Type        string `form:"type" json:"type" binding:"required,oneof=flat daily_interest"`
+	Amount      int64  `form:"amount" json:"amount" binding:"gte=0"`
+	DailyRateBP int    `form:"dailyRateBP" json:"dailyRateBP" binding:"gte=0,lte=10000"`
+	GraceDays   int    `form:"graceDays" json:"graceDays" binding:"gte=0,lte=365"`
+}
+
+type GroupDebtRequest struct {
+	DebtorID    string    `form:"debtorID" json:"debtorID" binding:"required"`
+	CreditorID  string    `form:"creditorID" json:"creditorID" binding:"required"`
+	Amount      int64     `form:"amount" json:"amount" binding:"required,gt=0"`
+	Currency    string    `form:"currency" json:"currency" binding:"required,len=3"`
+	Description string    `form:"description" json:"description" binding:"required,max=200"`
+	DueDate     time.Time `form:"dueDate" json:"dueDate" time_format:"2006-01-02"`
+}
+
+type GroupPaymentRequest struct {
+	Amount int64 `form:"amount" json:"amount" binding:"required,gt=0"`
+}
+
+type GroupDebtRepository interface {
+	Create(c context.Context, debt *GroupDebt) error
+	GetByID(c context.Context, groupID string, id string) (GroupDebt, error)
+	FetchByGroupID(c context.Context, groupID string) ([]GroupDebt, error)
+	// FetchByUserID returns the debts the user owes or is owed, in all groups.
+	FetchByUserID(c context.Context, userID string) ([]GroupDebt, error)
+	// FetchOverdue returns the unpaid debts of the group due before dueBefore.
+	FetchOverdue(c context.Context, groupID primitive.ObjectID, dueBefore time.Time) ([]GroupDebt, error)
+	// Update stores the amounts and dates of the debt. It fails with
+	// ErrGroupDebtChanged if the debt was updated since it was read.
+	Update(c context.Context, debt *GroupDebt) error
+}
+
+type GroupLedgerRepository interface {
+	Create(c context.Context, entries ...GroupLedgerEntry) error
+	FetchByGroupID(c context.Context, groupID string) ([]GroupLedgerEntry, error)
+	FetchByDebtID(c context.Context, debtID primitive.ObjectID) ([]GroupLedgerEntry, error)
+}
+
+type GroupDebtUsecase interface {
+	Create(c context.Context, actor *GroupMember, request *GroupDebtRequest) (GroupDebt, error)
+	FetchByGroupID(c context.Context, groupID string) ([]GroupDebt, error)
+	FetchLedger(c context.Context, groupID string) ([]GroupLedgerEntry, error)
+	RecordPayment(c context.Context, actor *GroupMember, debtID string, amount int64) (GroupDebt, error)
+	// WaiveCharge reverses a late fee. It is reserved to the members who
+	// manage the group.
+	WaiveCharge(c context.Context, actor *GroupMember, debtID string, entryID string) (GroupDebt, error)
+	// ApplyLateFees charges the overdue debts of all groups with a late fee
+	// policy. Fees already charged are not charged again.
+	ApplyLateFees(c context.Context, now time.Time) error
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionGroupWallet = "group_wallets"
+	CollectionWalletEntry = "wallet_entries"
+)
+
+const (
+	GroupWalletStatusOpen   = "open"
+	GroupWalletStatusClosed = "closed"
+)
+
+const (
+	WalletEntryContribution = "contribution"
+	WalletEntryExpense      = "expense"
+	WalletEntryRefund       = "refund"
+)
+
+// WalletPartyID stands for the wallet in the events of payments into and out
+// of it and of expenses paid from it.
+const WalletPartyID = "wallet"
+
+var (
+	ErrGroupWalletNotFound     = errors.New("Group has no wallet")
+	ErrGroupWalletExists       = errors.New("Group already has a wallet")
+	ErrGroupWalletClosed       = errors.New("Group wallet is closed")
+	ErrGroupWalletInsufficient = errors.New("Expense exceeds the wallet balance")
+	ErrGroupWalletChanged      = errors.New("Group wallet was modified concurrently, please retry")
+	ErrWalletParticipant       = errors.New("Expense participants must be members of the group")
+)
+
+// GroupWallet is a pot the members of a group pay into. Balance is the
+// amount left, in minor currency units.
+type GroupWallet struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	GroupID   primitive.ObjectID `bson:"groupID" json:"groupID"`
+	Currency  string             `bson:"currency" json:"currency"`
+	Balance   int64              `bson:"balance" json:"balance"`
+	Status    string             `bson:"status" json:"status"`
+	CreatedBy primitive.ObjectID `bson:"createdBy" json:"createdBy"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	ClosedAt  *time.Time         `bson:"closedAt,omitempty" json:"closedAt,omitempty"`
+}
+
+type WalletShare struct {
+	UserID primitive.ObjectID `bson:"userID" json:"userID"`
+	Amount int64              `bson:"amount" json:"amount"`
+}
+
+// WalletEntry is a contribution or refund of UserID, or an expense UserID
+// paid from the wallet on behalf of the members in Shares.
+type WalletEntry struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	WalletID    primitive.ObjectID `bson:"walletID" json:"walletID"`
+	GroupID     primitive.ObjectID `bson:"groupID" json:"groupID"`
+	Type        string             `bson:"type" json:"type"`
+	UserID      primitive.ObjectID `bson:"userID" json:"userID"`
+	Amount      int64              `bson:"amount" json:"amount"`
+	Description string             `bson:"description,omitempty" json:"description,omitempty"`
+	Shares      []WalletShare      `bson:"shares,omitempty" json:"shares,omitempty"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+type GroupWalletRequest struct {
+	Currency string `form:"currency" json:"currency" binding:"required,len=3"`
+}
+
+type WalletContributionRequest struct {
+	Amount int64 `form:"amount" json:"amount" binding:"required,gt=0"`
+}
+
+type WalletExpenseRequest struct {
+	Amount      int64  `form:"amount" json:"amount" binding:"required,gt=0"`
+	Description string `form:"description" json:"description" binding:"required,max=200"`
+	// Participants share the expense equally, all members if empty.
+	Participants []string `form:"participants" json:"participants"`
+}
+
+// WalletMemberBalance is what a member put into the wallet and their share of
+// what was spent from it. Net is what the wallet owes them.
+type WalletMemberBalance struct {
+	UserID      primitive.ObjectID `json:"userID"`
+	Contributed int64              `json:"contributed"`
+	Share       int64              `json:"share"`
+	Refunded    int64              `json:"refunded"`
+	Net         int64              `json:"net"`
+}
+
+type GroupWalletSummary struct {
+	Wallet      GroupWallet           `json:"wallet"`
+	Contributed int64                 `json:"contributed"`
+	Spent       int64                 `json:"spent"`
+	Refunded    int64                 `json:"refunded"`
+	Members     []WalletMemberBalance `json:"members"`
+	Entries     []WalletEntry         `json:"entries"`
+}
+
+type GroupWalletRepository interface {
+	Create(c context.Context, wallet *GroupWallet) error
+	GetByGroupID(c context.Context, groupID string) (GroupWallet, error)
+	// AddToBalance fails with ErrGroupWalletChanged if the wallet was closed
+	// or its balance changed since it was read.
+	AddToBalance(c context.Context, wallet *GroupWallet, amount int64) error
+	Close(c context.Context, wallet *GroupWallet) error
+}
+
+type WalletEntryRepository interface {
+	Create(c context.Context, entries ...WalletEntry) error
+	FetchByWalletID(c context.Context, walletID primitive.ObjectID) ([]WalletEntry, error)
+	// FetchByUserID returns the entries of the user and the expenses they
+	// have a share in, oldest first.
+	FetchByUserID(c context.Context, userID string) ([]WalletEntry, error)
+}
+
+type GroupWalletUsecase interface {
+	Open(c context.Context, actor *GroupMember, currency string) (GroupWallet, error)
+	GetSummary(c context.Context, groupID string) (GroupWalletSummary, error)
+	Contribute(c context.Context, actor *GroupMember, amount int64) (WalletEntry, error)
+	AddExpense(c context.Context, actor *GroupMember, request *WalletExpenseRequest) (WalletEntry, error)
+}
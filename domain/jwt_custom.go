@@ -5,12 +5,20 @@ import (
 )
 
 type JwtCustomClaims struct {
-	Name string `json:"name"`
-	ID   string `json:"id"`
+	Name          string `json:"name"`
+	ID            string `json:"id"`
+	Type          string `json:"typ"`
+	EmailVerified bool   `json:"ev"`
+	SessionID     string `json:"sid,omitempty"`
+	// SystemRole is read from the user when the token is issued. Endpoints
+	// that require it check the user again.
+	SystemRole string `json:"srl,omitempty"`
 	jwt.StandardClaims
 }
 
 type JwtCustomRefreshClaims struct {
-	ID string `json:"id"`
+	ID       string `json:"id"`
+	FamilyID string `json:"fid"`
+	Type     string `json:"typ"`
 	jwt.StandardClaims
 }
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionLoan = "loans"
+)
+
+const (
+	LoanStatusPending  = "pending"
+	LoanStatusActive   = "active"
+	LoanStatusRepaid   = "repaid"
+	LoanStatusDeclined = "declined"
+)
+
+const (
+	LoanScheduleEqualPrincipal = "equal_principal"
+	LoanScheduleAmortized      = "amortized"
+)
+
+var (
+	ErrLoanNotFound            = errors.New("Loan not found")
+	ErrLoanSelf                = errors.New("Lender and borrower must be different users")
+	ErrLoanNotPending          = errors.New("Loan is not awaiting confirmation")
+	ErrLoanNotActive           = errors.New("Loan is not active")
+	ErrLoanAlreadyConfirmed    = errors.New("Loan is already confirmed by this user")
+	ErrLoanNotLender           = errors.New("Only the lender can record repayments")
+	ErrRepaymentExceedsBalance = errors.New("Repayment exceeds the remaining balance")
+	ErrLoanChanged             = errors.New("Loan was modified concurrently, please retry")
+)
+
+// Amounts are stored in minor currency units (e.g. cents) and interest rates
+// in basis points per year.
+type Installment struct {
+	Number    int       `bson:"number" json:"number"`
+	DueDate   time.Time `bson:"dueDate" json:"dueDate"`
+	Principal int64     `bson:"principal" json:"principal"`
+	Interest  int64     `bson:"interest" json:"interest"`
+	Amount    int64     `bson:"amount" json:"amount"`
+}
+
+type Repayment struct {
+	ID         primitive.ObjectID `bson:"_id" json:"id"`
+	Amount     int64              `bson:"amount" json:"amount"`
+	PaidAt     time.Time          `bson:"paidAt" json:"paidAt"`
+	Note       string             `bson:"note,omitempty" json:"note,omitempty"`
+	RecordedBy primitive.ObjectID `bson:"recordedBy" json:"recordedBy"`
+}
+
+type Loan struct {
+	ID                   primitive.ObjectID `bson:"_id" json:"id"`
+	LenderID             primitive.ObjectID `bson:"lenderID" json:"lenderID"`
+	BorrowerID           primitive.ObjectID `bson:"borrowerID" json:"borrowerID"`
+	Principal            int64              `bson:"principal" json:"principal"`
+	Currency             string             `bson:"currency" json:"currency"`
+	AnnualInterestRateBP int                `bson:"annualInterestRateBP" json:"annualInterestRateBP"`
+	ScheduleType         string             `bson:"scheduleType" json:"scheduleType"`
+	Installments         []Installment      `bson:"installments" json:"installments"`
+	Repayments           []Repayment        `bson:"repayments" json:"repayments"`
+	Status               string             `bson:"status" json:"status"`
+	LenderConfirmedAt    *time.Time         `bson:"lenderConfirmedAt,omitempty" json:"lenderConfirmedAt,omitempty"`
+	BorrowerConfirmedAt  *time.Time         `bson:"borrowerConfirmedAt,omitempty" json:"borrowerConfirmedAt,omitempty"`
+	CreatedBy            primitive.ObjectID `bson:"createdBy" json:"createdBy"`
+	CreatedAt            time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+func (l *Loan) IsParty(userID primitive.ObjectID) bool {
+	return l.LenderID == userID || l.BorrowerID == userID
+}
+
+type LoanRequest struct {
+	CounterpartyID       string    `form:"counterpartyID" json:"counterpartyID" binding:"required"`
+	Role                 string    `form:"role" json:"role" binding:"required,oneof=lender borrower"`
+	Principal            int64     `form:"principal" json:"principal" binding:"required,gt=0"`
+	Currency             string    `form:"currency" json:"currency" binding:"required,len=3"`
+	AnnualInterestRateBP int       `form:"annualInterestRateBP" json:"annualInterestRateBP" binding:"gte=0,lte=100000"`
+	ScheduleType         string    `form:"scheduleType" json:"scheduleType" binding:"required,oneof=equal_principal amortized"`
+	Installments         int       `form:"installments" json:"installments" binding:"required,gte=1,lte=600"`
+	FirstDueDate         time.Time `form:"firstDueDate" json:"firstDueDate" time_format:"2006-01-02" binding:"required"`
+}
+
+type RepaymentRequest struct {
+	Amount int64     `form:"amount" json:"amount" binding:"required,gt=0"`
+	PaidAt time.Time `form:"paidAt" json:"paidAt" time_format:"2006-01-02"`
+	Note   string    `form:"note" json:"note" binding:"max=200"`
+}
+
+type InstallmentStatus struct {
+	Installment
+	Paid      int64 `json:"paid"`
+	Remaining int64 `json:"remaining"`
+	Overdue   bool  `json:"overdue"`
+}
+
+type LoanBalance struct {
+	LoanID             primitive.ObjectID  `json:"loanID"`
+	Currency           string              `json:"currency"`
+	Status             string              `json:"status"`
+	TotalDue           int64               `json:"totalDue"`
+	Repaid             int64               `json:"repaid"`
+	Remaining          int64               `json:"remaining"`
+	RemainingPrincipal int64               `json:"remainingPrincipal"`
+	Overdue            int64               `json:"overdue"`
+	NextInstallment    *InstallmentStatus  `json:"nextInstallment,omitempty"`
+	Installments       []InstallmentStatus `json:"installments"`
+}
+
+type LoanRepository interface {
+	Create(c context.Context, loan *Loan) error
+	GetByID(c context.Context, id string) (Loan, error)
+	FetchByUserID(c context.Context, userID string) ([]Loan, error)
+	UpdateConfirmation(c context.Context, loan *Loan) error
+	AddRepayment(c context.Context, loan *Loan, repayment Repayment, status string) error
+}
+
+type LoanUsecase interface {
+	Create(c context.Context, loan *Loan) error
+	GetByID(c context.Context, loanID string, userID string) (Loan, error)
+	FetchByUserID(c context.Context, userID string) ([]Loan, error)
+	Confirm(c context.Context, loanID string, userID string) (Loan, error)
+	Decline(c context.Context, loanID string, userID string) (Loan, error)
+	AddRepayment(c context.Context, loanID string, userID string, repayment Repayment) (Loan, error)
+	GetBalance(c context.Context, loanID string, userID string) (*LoanBalance, error)
+}
@@ -15,7 +15,10 @@ type LoginResponse struct {
 }
 
 type LoginUsecase interface {
-	GetUserByEmail(c context.Context, email string) (User, error)
-	CreateAccessToken(user *User, secret string, expiry int) (accessToken string, err error)
-	CreateRefreshToken(user *User, secret string, expiry int) (refreshToken string, err error)
+	// Authenticate checks the credentials and throttles repeated failures per
+	// account and per client IP. Unknown emails fail like wrong passwords.
+	Authenticate(c context.Context, email string, password string, clientIP string) (User, error)
+	CreateAccessToken(user *User, sessionID string, secret string, expiry int) (accessToken string, err error)
+	// CreateRefreshToken starts a new session for the client.
+	CreateRefreshToken(c context.Context, user *User, client SessionClient, secret string, expiry int) (refreshToken string, sessionID string, err error)
 }
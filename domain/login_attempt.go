@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	CollectionLoginAttempt = "login_attempts"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("Invalid credentials")
+	ErrLoginLocked        = errors.New("Too many failed login attempts, try again later")
+)
+
+// InvalidCredentialsError matches ErrInvalidCredentials and tells which
+// account the failed login was for, if any. It must not be shown to clients.
+type InvalidCredentialsError struct {
+	UserID string
+}
+
+func (e *InvalidCredentialsError) Error() string {
+	return ErrInvalidCredentials.Error()
+}
+
+func (e *InvalidCredentialsError) Is(target error) bool {
+	return target == ErrInvalidCredentials
+}
+
+// LoginLockedError matches ErrLoginLocked and tells when to try again.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return ErrLoginLocked.Error()
+}
+
+func (e *LoginLockedError) Is(target error) bool {
+	return target == ErrLoginLocked
+}
+
+type LoginAttempts struct {
+	Failures      int       `bson:"failures"`
+	LastFailureAt time.Time `bson:"lastFailureAt"`
+}
+
+// LoginAttemptStore counts failed logins per key, such as an account or an
+// IP address. A failure more than resetAfter after the previous one starts
+// the count again.
+type LoginAttemptStore interface {
+	Get(c context.Context, key string) (LoginAttempts, error)
+	RecordFailure(c context.Context, key string, now time.Time, resetAfter time.Duration) (LoginAttempts, error)
+	Reset(c context.Context, key string) error
+}
+
+type LoginThrottlePolicy struct {
+	// Failures allowed before each further attempt on the account is delayed.
+	FreeAttempts int
+	// Delay after the first delayed failure, doubled for each one after it.
+	BaseDelay          time.Duration
+	MaxAccountFailures int
+	MaxIPFailures      int
+	LockoutDuration    time.Duration
+}
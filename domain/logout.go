@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type LogoutRequest struct {
+	RefreshToken string `form:"refreshToken"`
+}
+
+type LogoutUsecase interface {
+	ExtractRefreshClaimsFromToken(requestToken string, secret string) (*JwtCustomRefreshClaims, error)
+	// Logout ends the session of the access token. Tokens issued before
+	// sessions existed are only ended through the given refresh token.
+	Logout(c context.Context, userID string, tokenID string, sessionID string, expiresAt time.Time, refreshClaims *JwtCustomRefreshClaims, accessTokenExpiry int) error
+	LogoutAll(c context.Context, userID string, accessTokenExpiry int) error
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+)
+
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(c context.Context, message MailMessage) error
+}
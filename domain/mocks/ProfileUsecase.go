@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 
 	domain "github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	mock "github.com/stretchr/testify/mock"
@@ -14,6 +15,92 @@ type ProfileUsecase struct {
 	mock.Mock
 }
 
+// ChangeEmail provides a mock function with given fields: c, userID, sessionID, password, email
+func (_m *ProfileUsecase) ChangeEmail(c context.Context, userID string, sessionID string, password string, email string) (domain.User, error) {
+	ret := _m.Called(c, userID, sessionID, password, email)
+
+	var r0 domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) domain.User); ok {
+		r0 = rf(c, userID, sessionID, password, email)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(c, userID, sessionID, password, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangePassword provides a mock function with given fields: c, userID, sessionID, currentPassword, newPassword, accessTokenExpiry
+func (_m *ProfileUsecase) ChangePassword(c context.Context, userID string, sessionID string, currentPassword string, newPassword string, accessTokenExpiry int) error {
+	ret := _m.Called(c, userID, sessionID, currentPassword, newPassword, accessTokenExpiry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, int) error); ok {
+		r0 = rf(c, userID, sessionID, currentPassword, newPassword, accessTokenExpiry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAccount provides a mock function with given fields: c, userID, sessionID, password, accessTokenExpiry
+func (_m *ProfileUsecase) DeleteAccount(c context.Context, userID string, sessionID string, password string, accessTokenExpiry int) error {
+	ret := _m.Called(c, userID, sessionID, password, accessTokenExpiry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int) error); ok {
+		r0 = rf(c, userID, sessionID, password, accessTokenExpiry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAvatar provides a mock function with given fields: c, userID
+func (_m *ProfileUsecase) DeleteAvatar(c context.Context, userID string) (*domain.Profile, error) {
+	ret := _m.Called(c, userID)
+
+	var r0 *domain.Profile
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Profile); ok {
+		r0 = rf(c, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Profile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EraseAccount provides a mock function with given fields: c, userID, sessionID, password, accessTokenExpiry
+func (_m *ProfileUsecase) EraseAccount(c context.Context, userID string, sessionID string, password string, accessTokenExpiry int) error {
+	ret := _m.Called(c, userID, sessionID, password, accessTokenExpiry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int) error); ok {
+		r0 = rf(c, userID, sessionID, password, accessTokenExpiry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetProfileByID provides a mock function with given fields: c, userID
 func (_m *ProfileUsecase) GetProfileByID(c context.Context, userID string) (*domain.Profile, error) {
 	ret := _m.Called(c, userID)
@@ -37,6 +124,52 @@ func (_m *ProfileUsecase) GetProfileByID(c context.Context, userID string) (*dom
 	return r0, r1
 }
 
+// UpdateAvatar provides a mock function with given fields: c, userID, image
+func (_m *ProfileUsecase) UpdateAvatar(c context.Context, userID string, image io.Reader) (*domain.Profile, error) {
+	ret := _m.Called(c, userID, image)
+
+	var r0 *domain.Profile
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader) *domain.Profile); ok {
+		r0 = rf(c, userID, image)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Profile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, io.Reader) error); ok {
+		r1 = rf(c, userID, image)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProfile provides a mock function with given fields: c, userID, request
+func (_m *ProfileUsecase) UpdateProfile(c context.Context, userID string, request *domain.UpdateProfileRequest) (*domain.Profile, error) {
+	ret := _m.Called(c, userID, request)
+
+	var r0 *domain.Profile
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.UpdateProfileRequest) *domain.Profile); ok {
+		r0 = rf(c, userID, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Profile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.UpdateProfileRequest) error); ok {
+		r1 = rf(c, userID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewProfileUsecase interface {
 	mock.TestingT
 	Cleanup(func())
@@ -14,20 +14,20 @@ type RefreshTokenUsecase struct {
 	mock.Mock
 }
 
-// CreateAccessToken provides a mock function with given fields: user, secret, expiry
-func (_m *RefreshTokenUsecase) CreateAccessToken(user *domain.User, secret string, expiry int) (string, error) {
-	ret := _m.Called(user, secret, expiry)
+// CreateAccessToken provides a mock function with given fields: user, sessionID, secret, expiry
+func (_m *RefreshTokenUsecase) CreateAccessToken(user *domain.User, sessionID string, secret string, expiry int) (string, error) {
+	ret := _m.Called(user, sessionID, secret, expiry)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(*domain.User, string, int) string); ok {
-		r0 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(0).(func(*domain.User, string, string, int) string); ok {
+		r0 = rf(user, sessionID, secret, expiry)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*domain.User, string, int) error); ok {
-		r1 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(1).(func(*domain.User, string, string, int) error); ok {
+		r1 = rf(user, sessionID, secret, expiry)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -35,20 +35,20 @@ func (_m *RefreshTokenUsecase) CreateAccessToken(user *domain.User, secret strin
 	return r0, r1
 }
 
-// CreateRefreshToken provides a mock function with given fields: user, secret, expiry
-func (_m *RefreshTokenUsecase) CreateRefreshToken(user *domain.User, secret string, expiry int) (string, error) {
-	ret := _m.Called(user, secret, expiry)
+// CreateRefreshToken provides a mock function with given fields: c, user, familyID, client, secret, expiry
+func (_m *RefreshTokenUsecase) CreateRefreshToken(c context.Context, user *domain.User, familyID string, client domain.SessionClient, secret string, expiry int) (string, error) {
+	ret := _m.Called(c, user, familyID, client, secret, expiry)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(*domain.User, string, int) string); ok {
-		r0 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, string, domain.SessionClient, string, int) string); ok {
+		r0 = rf(c, user, familyID, client, secret, expiry)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*domain.User, string, int) error); ok {
-		r1 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User, string, domain.SessionClient, string, int) error); ok {
+		r1 = rf(c, user, familyID, client, secret, expiry)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,15 +56,17 @@ func (_m *RefreshTokenUsecase) CreateRefreshToken(user *domain.User, secret stri
 	return r0, r1
 }
 
-// ExtractIDFromToken provides a mock function with given fields: requestToken, secret
-func (_m *RefreshTokenUsecase) ExtractIDFromToken(requestToken string, secret string) (string, error) {
+// ExtractClaimsFromToken provides a mock function with given fields: requestToken, secret
+func (_m *RefreshTokenUsecase) ExtractClaimsFromToken(requestToken string, secret string) (*domain.JwtCustomRefreshClaims, error) {
 	ret := _m.Called(requestToken, secret)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+	var r0 *domain.JwtCustomRefreshClaims
+	if rf, ok := ret.Get(0).(func(string, string) *domain.JwtCustomRefreshClaims); ok {
 		r0 = rf(requestToken, secret)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.JwtCustomRefreshClaims)
+		}
 	}
 
 	var r1 error
@@ -98,6 +100,20 @@ func (_m *RefreshTokenUsecase) GetUserByID(c context.Context, id string) (domain
 	return r0, r1
 }
 
+// Rotate provides a mock function with given fields: c, claims, accessTokenExpiry
+func (_m *RefreshTokenUsecase) Rotate(c context.Context, claims *domain.JwtCustomRefreshClaims, accessTokenExpiry int) error {
+	ret := _m.Called(c, claims, accessTokenExpiry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.JwtCustomRefreshClaims, int) error); ok {
+		r0 = rf(c, claims, accessTokenExpiry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewRefreshTokenUsecase interface {
 	mock.TestingT
 	Cleanup(func())
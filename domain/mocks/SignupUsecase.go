@@ -28,20 +28,20 @@ func (_m *SignupUsecase) Create(c context.Context, user *domain.User) error {
 	return r0
 }
 
-// CreateAccessToken provides a mock function with given fields: user, secret, expiry
-func (_m *SignupUsecase) CreateAccessToken(user *domain.User, secret string, expiry int) (string, error) {
-	ret := _m.Called(user, secret, expiry)
+// CreateAccessToken provides a mock function with given fields: user, sessionID, secret, expiry
+func (_m *SignupUsecase) CreateAccessToken(user *domain.User, sessionID string, secret string, expiry int) (string, error) {
+	ret := _m.Called(user, sessionID, secret, expiry)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(*domain.User, string, int) string); ok {
-		r0 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(0).(func(*domain.User, string, string, int) string); ok {
+		r0 = rf(user, sessionID, secret, expiry)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*domain.User, string, int) error); ok {
-		r1 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(1).(func(*domain.User, string, string, int) error); ok {
+		r1 = rf(user, sessionID, secret, expiry)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -49,25 +49,32 @@ func (_m *SignupUsecase) CreateAccessToken(user *domain.User, secret string, exp
 	return r0, r1
 }
 
-// CreateRefreshToken provides a mock function with given fields: user, secret, expiry
-func (_m *SignupUsecase) CreateRefreshToken(user *domain.User, secret string, expiry int) (string, error) {
-	ret := _m.Called(user, secret, expiry)
+// CreateRefreshToken provides a mock function with given fields: c, user, client, secret, expiry
+func (_m *SignupUsecase) CreateRefreshToken(c context.Context, user *domain.User, client domain.SessionClient, secret string, expiry int) (string, string, error) {
+	ret := _m.Called(c, user, client, secret, expiry)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(*domain.User, string, int) string); ok {
-		r0 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, domain.SessionClient, string, int) string); ok {
+		r0 = rf(c, user, client, secret, expiry)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(*domain.User, string, int) error); ok {
-		r1 = rf(user, secret, expiry)
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User, domain.SessionClient, string, int) string); ok {
+		r1 = rf(c, user, client, secret, expiry)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
 
-	return r0, r1
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *domain.User, domain.SessionClient, string, int) error); ok {
+		r2 = rf(c, user, client, secret, expiry)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // GetUserByEmail provides a mock function with given fields: c, email
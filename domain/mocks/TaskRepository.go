@@ -28,6 +28,20 @@ func (_m *TaskRepository) Create(c context.Context, task *domain.Task) error {
 	return r0
 }
 
+// DeleteAllForUser provides a mock function with given fields: c, userID
+func (_m *TaskRepository) DeleteAllForUser(c context.Context, userID string) error {
+	ret := _m.Called(c, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FetchByUserID provides a mock function with given fields: c, userID
 func (_m *TaskRepository) FetchByUserID(c context.Context, userID string) ([]domain.Task, error) {
 	ret := _m.Called(c, userID)
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	domain "github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	mock "github.com/stretchr/testify/mock"
@@ -14,6 +15,48 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// AddIdentity provides a mock function with given fields: c, id, identity
+func (_m *UserRepository) AddIdentity(c context.Context, id string, identity domain.ExternalIdentity) error {
+	ret := _m.Called(c, id, identity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.ExternalIdentity) error); ok {
+		r0 = rf(c, id, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Anonymize provides a mock function with given fields: c, id, deletedAt
+func (_m *UserRepository) Anonymize(c context.Context, id string, deletedAt time.Time) error {
+	ret := _m.Called(c, id, deletedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(c, id, deletedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfirmEmail provides a mock function with given fields: c, id, email, verifiedAt
+func (_m *UserRepository) ConfirmEmail(c context.Context, id string, email string, verifiedAt time.Time) error {
+	ret := _m.Called(c, id, email, verifiedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(c, id, email, verifiedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: c, user
 func (_m *UserRepository) Create(c context.Context, user *domain.User) error {
 	ret := _m.Called(c, user)
@@ -28,13 +71,13 @@ func (_m *UserRepository) Create(c context.Context, user *domain.User) error {
 	return r0
 }
 
-// Fetch provides a mock function with given fields: c
-func (_m *UserRepository) Fetch(c context.Context) ([]domain.User, error) {
-	ret := _m.Called(c)
+// Fetch provides a mock function with given fields: c, query, limit
+func (_m *UserRepository) Fetch(c context.Context, query *domain.UserQuery, limit int64) ([]domain.User, error) {
+	ret := _m.Called(c, query, limit)
 
 	var r0 []domain.User
-	if rf, ok := ret.Get(0).(func(context.Context) []domain.User); ok {
-		r0 = rf(c)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.UserQuery, int64) []domain.User); ok {
+		r0 = rf(c, query, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.User)
@@ -42,8 +85,8 @@ func (_m *UserRepository) Fetch(c context.Context) ([]domain.User, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(c)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.UserQuery, int64) error); ok {
+		r1 = rf(c, query, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -93,6 +136,174 @@ func (_m *UserRepository) GetByID(c context.Context, id string) (domain.User, er
 	return r0, r1
 }
 
+// GetByIdentity provides a mock function with given fields: c, provider, subject
+func (_m *UserRepository) GetByIdentity(c context.Context, provider string, subject string) (domain.User, error) {
+	ret := _m.Called(c, provider, subject)
+
+	var r0 domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.User); ok {
+		r0 = rf(c, provider, subject)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, provider, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkEmailVerified provides a mock function with given fields: c, id, email, verifiedAt
+func (_m *UserRepository) MarkEmailVerified(c context.Context, id string, email string, verifiedAt time.Time) error {
+	ret := _m.Called(c, id, email, verifiedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(c, id, email, verifiedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetAvatar provides a mock function with given fields: c, id, key
+func (_m *UserRepository) SetAvatar(c context.Context, id string, key string) (string, error) {
+	ret := _m.Called(c, id, key)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(c, id, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, id, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetDisabled provides a mock function with given fields: c, id, disabledAt
+func (_m *UserRepository) SetDisabled(c context.Context, id string, disabledAt *time.Time) error {
+	ret := _m.Called(c, id, disabledAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time) error); ok {
+		r0 = rf(c, id, disabledAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPendingEmail provides a mock function with given fields: c, id, email
+func (_m *UserRepository) SetPendingEmail(c context.Context, id string, email string) error {
+	ret := _m.Called(c, id, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, id, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetTwoFactor provides a mock function with given fields: c, id, twoFactor
+func (_m *UserRepository) SetTwoFactor(c context.Context, id string, twoFactor *domain.TwoFactor) error {
+	ret := _m.Called(c, id, twoFactor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.TwoFactor) error); ok {
+		r0 = rf(c, id, twoFactor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePassword provides a mock function with given fields: c, id, password
+func (_m *UserRepository) UpdatePassword(c context.Context, id string, password string) error {
+	ret := _m.Called(c, id, password)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, id, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateProfile provides a mock function with given fields: c, id, request
+func (_m *UserRepository) UpdateProfile(c context.Context, id string, request *domain.UpdateProfileRequest) error {
+	ret := _m.Called(c, id, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.UpdateProfileRequest) error); ok {
+		r0 = rf(c, id, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseRecoveryCode provides a mock function with given fields: c, id, codeHash
+func (_m *UserRepository) UseRecoveryCode(c context.Context, id string, codeHash string) (bool, error) {
+	ret := _m.Called(c, id, codeHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(c, id, codeHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, id, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UseTwoFactorStep provides a mock function with given fields: c, id, step
+func (_m *UserRepository) UseTwoFactorStep(c context.Context, id string, step int64) (bool, error) {
+	ret := _m.Called(c, id, step)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) bool); ok {
+		r0 = rf(c, id, step)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(c, id, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewUserRepository interface {
 	mock.TestingT
 	Cleanup(func())
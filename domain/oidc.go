@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionOIDCState = "oidc_states"
+)
+
+var (
+	ErrInvalidOIDCState     = errors.New("Sign-in request is invalid or expired")
+	ErrOIDCEmailNotVerified = errors.New("The provider did not confirm your email address")
+	ErrOIDCAccountExists    = errors.New("An account with this email already exists, sign in with your password to link it")
+	ErrOIDCProviderRejected = errors.New("The provider rejected the sign-in")
+	ErrInvalidOIDCIdentity  = errors.New("The provider returned an invalid identity token")
+)
+
+// ExternalIdentity links a user to the subject of an OpenID Connect
+// provider.
+type ExternalIdentity struct {
+	Provider string    `bson:"provider" json:"provider"`
+	Subject  string    `bson:"subject" json:"subject"`
+	LinkedAt time.Time `bson:"linkedAt" json:"linkedAt"`
+}
+
+// OIDCIdentity holds the verified claims of an ID token.
+type OIDCIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// OIDCState is the server side half of an authorization request. The state
+// sent to the provider is only stored as a hash.
+type OIDCState struct {
+	ID           primitive.ObjectID `bson:"_id"`
+	Provider     string             `bson:"provider"`
+	StateHash    string             `bson:"stateHash"`
+	Nonce        string             `bson:"nonce"`
+	CodeVerifier string             `bson:"codeVerifier"`
+	CreatedAt    time.Time          `bson:"createdAt"`
+	ExpiresAt    time.Time          `bson:"expiresAt"`
+	UsedAt       *time.Time         `bson:"usedAt,omitempty"`
+}
+
+type OIDCStateRepository interface {
+	Create(c context.Context, state *OIDCState) error
+	// Consume marks an unused, unexpired state as used and returns it.
+	Consume(c context.Context, provider string, stateHash string) (OIDCState, error)
+}
+
+type OIDCProvider interface {
+	Name() string
+	AuthCodeURL(c context.Context, state string, nonce string, codeChallenge string) (string, error)
+	// Exchange redeems the authorization code and verifies the returned ID
+	// token, including its nonce.
+	Exchange(c context.Context, code string, codeVerifier string, nonce string) (OIDCIdentity, error)
+}
+
+type OIDCUsecase interface {
+	// Begin starts an authorization request and returns the provider URL to
+	// redirect to and the state the callback has to present.
+	Begin(c context.Context, expiry time.Duration) (authURL string, state string, err error)
+	// Complete finishes the request and returns the linked user, creating one
+	// on the first sign-in.
+	Complete(c context.Context, state string, code string) (User, error)
+}
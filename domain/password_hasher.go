@@ -0,0 +1,9 @@
+package domain
+
+// PasswordHasher hashes passwords for storage. Verify also accepts hashes
+// made with older algorithms or parameters and reports that they should be
+// replaced with a fresh hash.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(hash string, password string) (match bool, needsRehash bool)
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrPasswordRejected = errors.New("Password does not meet the requirements")
+)
+
+const (
+	PasswordViolationTooShort      = "too_short"
+	PasswordViolationTooLong       = "too_long"
+	PasswordViolationContainsEmail = "contains_email"
+	PasswordViolationContainsName  = "contains_name"
+	PasswordViolationBreached      = "breached"
+)
+
+// PasswordPolicyError matches ErrPasswordRejected and lists every rule the
+// password broke.
+type PasswordPolicyError struct {
+	Violations []FieldError
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return ErrPasswordRejected.Error()
+}
+
+func (e *PasswordPolicyError) Is(target error) bool {
+	return target == ErrPasswordRejected
+}
+
+// BreachedPasswordChecker tells whether a password is known from a breach.
+type BreachedPasswordChecker interface {
+	IsBreached(c context.Context, password string) (bool, error)
+}
+
+// PasswordPolicy returns a *PasswordPolicyError for a password it rejects.
+// The user is nil when it is not known yet, which skips the checks against
+// the email and name.
+type PasswordPolicy interface {
+	Check(c context.Context, password string, user *User) error
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrInvalidResetToken = errors.New("Reset token is invalid or expired")
+	ErrTooManyRequests   = errors.New("Too many requests, try again later")
+)
+
+type ForgotPasswordRequest struct {
+	Email string `form:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `form:"token" binding:"required"`
+	Password string `form:"password" binding:"required"`
+}
+
+type PasswordResetUsecase interface {
+	RequestReset(c context.Context, email string, ip string, resetURL string, expiryMinute int, emailLimitPerHour int, ipLimitPerHour int) error
+	ResetPassword(c context.Context, token string, password string, accessTokenExpiry int) (userID string, err error)
+}
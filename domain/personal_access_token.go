@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionPersonalAccessToken = "personal_access_tokens"
+)
+
+// PersonalAccessTokenPrefix tells personal access tokens apart from JWTs in
+// the Authorization header.
+const PersonalAccessTokenPrefix = "pat_"
+
+const (
+	ScopeProfileRead   = "profile:read"
+	ScopeProfileWrite  = "profile:write"
+	ScopeTasksRead     = "tasks:read"
+	ScopeTasksWrite    = "tasks:write"
+	ScopeLoansRead     = "loans:read"
+	ScopeLoansWrite    = "loans:write"
+	ScopeGroupsRead    = "groups:read"
+	ScopeGroupsWrite   = "groups:write"
+	ScopeExpensesRead  = "expenses:read"
+	ScopeExpensesWrite = "expenses:write"
+)
+
+var Scopes = []string{
+	ScopeProfileRead,
+	ScopeProfileWrite,
+	ScopeTasksRead,
+	ScopeTasksWrite,
+	ScopeLoansRead,
+	ScopeLoansWrite,
+	ScopeGroupsRead,
+	ScopeGroupsWrite,
+	ScopeExpensesRead,
+	ScopeExpensesWrite,
+}
+
+var (
+	ErrPersonalAccessTokenNotFound = errors.New("Personal access token not found")
+	ErrInvalidPersonalAccessToken  = errors.New("Personal access token is invalid, expired or revoked")
+	ErrUnknownScope                = errors.New("Unknown scope")
+)
+
+type PersonalAccessToken struct {
+	ID         primitive.ObjectID `bson:"_id" json:"id"`
+	UserID     primitive.ObjectID `bson:"userID" json:"-"`
+	Name       string             `bson:"name" json:"name"`
+	Scopes     []string           `bson:"scopes" json:"scopes"`
+	TokenHash  string             `bson:"tokenHash" json:"-"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+	ExpiresAt  time.Time          `bson:"expiresAt" json:"expiresAt"`
+	LastUsedAt *time.Time         `bson:"lastUsedAt,omitempty" json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time         `bson:"revokedAt,omitempty" json:"revokedAt,omitempty"`
+}
+
+func (t *PersonalAccessToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type PersonalAccessTokenRequest struct {
+	Name          string   `form:"name" binding:"required"`
+	Scopes        []string `form:"scopes" binding:"required,min=1"`
+	ExpiresInDays int      `form:"expiresInDays" binding:"required,min=1,max=365"`
+}
+
+// PersonalAccessTokenResponse carries the plain token, which is only shown
+// once at creation.
+type PersonalAccessTokenResponse struct {
+	PersonalAccessToken
+	Token string `json:"token"`
+}
+
+type PersonalAccessTokenRepository interface {
+	Create(c context.Context, token *PersonalAccessToken) error
+	FetchByUserID(c context.Context, userID string) ([]PersonalAccessToken, error)
+	GetByHash(c context.Context, tokenHash string) (PersonalAccessToken, error)
+	Revoke(c context.Context, id string, userID string) error
+	RevokeAllForUser(c context.Context, userID string) error
+	UpdateLastUsed(c context.Context, id primitive.ObjectID, lastUsedAt time.Time) error
+}
+
+type PersonalAccessTokenUsecase interface {
+	Create(c context.Context, userID string, request *PersonalAccessTokenRequest) (PersonalAccessTokenResponse, error)
+	FetchByUserID(c context.Context, userID string) ([]PersonalAccessToken, error)
+	Revoke(c context.Context, userID string, id string) error
+	Authenticate(c context.Context, token string) (PersonalAccessToken, error)
+}
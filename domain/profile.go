@@ -1,12 +1,76 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	ErrIncorrectPassword        = errors.New("Current password is incorrect")
+	ErrReauthenticationRequired = errors.New("Sign in again to confirm this change")
+	ErrEmailTaken               = errors.New("Email is already in use")
+	ErrOutstandingBalance       = errors.New("Account has loans with an outstanding balance")
+	ErrInvalidImage             = errors.New("File is not a supported image")
+)
 
 type Profile struct {
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	DisplayName string `json:"displayName,omitempty"`
+	AvatarURL   string `json:"avatarURL,omitempty"`
+	Currency    string `json:"currency,omitempty"`
+	Locale      string `json:"locale,omitempty"`
+	Timezone    string `json:"timezone,omitempty"`
+}
+
+// UpdateProfileRequest leaves the fields that are not sent unchanged. An
+// empty value clears a field.
+type UpdateProfileRequest struct {
+	DisplayName *string `json:"displayName" binding:"omitempty,max=50"`
+	Currency    *string `json:"currency" binding:"omitempty,iso4217"`
+	Locale      *string `json:"locale" binding:"omitempty,bcp47_language_tag"`
+	Timezone    *string `json:"timezone" binding:"omitempty,timezone"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `form:"currentPassword" json:"currentPassword"`
+	NewPassword     string `form:"newPassword" json:"newPassword" binding:"required"`
+}
+
+type ChangeEmailRequest struct {
+	Email    string `form:"email" json:"email" binding:"required,email"`
+	Password string `form:"password" json:"password"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `form:"password" json:"password"`
 }
 
+// The account changes ask for the current password, failing with a
+// LoginLockedError after too many wrong ones. Accounts without a password,
+// which only sign in through an OpenID Connect provider, must instead make the
+// change from a session they signed in to recently, or get
+// ErrReauthenticationRequired.
 type ProfileUsecase interface {
 	GetProfileByID(c context.Context, userID string) (*Profile, error)
+	UpdateProfile(c context.Context, userID string, request *UpdateProfileRequest) (*Profile, error)
+	// UpdateAvatar resizes the image and replaces the previous avatar.
+	UpdateAvatar(c context.Context, userID string, image io.Reader) (*Profile, error)
+	DeleteAvatar(c context.Context, userID string) (*Profile, error)
+	// ChangePassword signs the user out of every session but the current one.
+	ChangePassword(c context.Context, userID string, sessionID string, currentPassword string, newPassword string, accessTokenExpiry int) error
+	// ChangeEmail stores the email as pending and returns the updated user to
+	// send the verification to. The email of the account only changes once
+	// the link is used.
+	ChangeEmail(c context.Context, userID string, sessionID string, password string, email string) (User, error)
+	// DeleteAccount declines the pending loans of the user and replaces the
+	// account with an anonymous tombstone, so that loans with other users
+	// keep their history.
+	DeleteAccount(c context.Context, userID string, sessionID string, password string, accessTokenExpiry int) error
+	// EraseAccount deletes the account like DeleteAccount, even with an
+	// outstanding balance, and also removes the records that only concern the
+	// user. Group ledgers and loans keep pointing at the tombstone. Audit
+	// events are kept as the security record, without the email of the user.
+	EraseAccount(c context.Context, userID string, sessionID string, password string, accessTokenExpiry int) error
 }
@@ -2,10 +2,26 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionRefreshToken = "refresh_tokens"
+)
+
+var (
+	ErrInvalidRefreshToken  = errors.New("Refresh token is invalid or expired")
+	ErrRefreshTokenNotFound = errors.New("Refresh token not found")
+	ErrRefreshTokenRevoked  = errors.New("Refresh token revoked")
+	ErrRefreshTokenReused   = errors.New("Refresh token reuse detected")
 )
 
 type RefreshTokenRequest struct {
-	RefreshToken string `form:"refreshToken" binding:"required"`
+	// RefreshToken is read from its cookie when empty.
+	RefreshToken string `form:"refreshToken"`
 }
 
 type RefreshTokenResponse struct {
@@ -13,9 +29,30 @@ type RefreshTokenResponse struct {
 	RefreshToken string `json:"refreshToken"`
 }
 
+// RefreshToken is the server-side record of an issued refresh token. Tokens
+// rotated from the same login share a FamilyID.
+type RefreshToken struct {
+	ID        string             `bson:"_id"`
+	FamilyID  string             `bson:"familyID"`
+	UserID    primitive.ObjectID `bson:"userID"`
+	CreatedAt time.Time          `bson:"createdAt"`
+	ExpiresAt time.Time          `bson:"expiresAt"`
+	UsedAt    *time.Time         `bson:"usedAt,omitempty"`
+	RevokedAt *time.Time         `bson:"revokedAt,omitempty"`
+}
+
+type RefreshTokenRepository interface {
+	Create(c context.Context, token *RefreshToken) error
+	GetByID(c context.Context, id string) (RefreshToken, error)
+	MarkUsed(c context.Context, id string) (bool, error)
+	RevokeFamily(c context.Context, familyID string) error
+	RevokeAllForUser(c context.Context, userID string) error
+}
+
 type RefreshTokenUsecase interface {
 	GetUserByID(c context.Context, id string) (User, error)
-	CreateAccessToken(user *User, secret string, expiry int) (accessToken string, err error)
-	CreateRefreshToken(user *User, secret string, expiry int) (refreshToken string, err error)
-	ExtractIDFromToken(requestToken string, secret string) (string, error)
+	CreateAccessToken(user *User, sessionID string, secret string, expiry int) (accessToken string, err error)
+	CreateRefreshToken(c context.Context, user *User, familyID string, client SessionClient, secret string, expiry int) (refreshToken string, err error)
+	ExtractClaimsFromToken(requestToken string, secret string) (*JwtCustomRefreshClaims, error)
+	Rotate(c context.Context, claims *JwtCustomRefreshClaims, accessTokenExpiry int) error
 }
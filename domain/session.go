@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionSession = "sessions"
+)
+
+var ErrSessionNotFound = errors.New("Session not found")
+
+// SessionClient describes the device a login or refresh came from.
+type SessionClient struct {
+	UserAgent string
+	IP        string
+}
+
+// Session is a login on one device. Its ID is the family ID of the refresh
+// tokens rotated from that login and the sid claim of its access tokens.
+type Session struct {
+	ID         string             `bson:"_id" json:"id"`
+	UserID     primitive.ObjectID `bson:"userID" json:"-"`
+	UserAgent  string             `bson:"userAgent" json:"userAgent"`
+	IP         string             `bson:"ip" json:"ip"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+	LastSeenAt time.Time          `bson:"lastSeenAt" json:"lastSeenAt"`
+	ExpiresAt  time.Time          `bson:"expiresAt" json:"expiresAt"`
+	RevokedAt  *time.Time         `bson:"revokedAt,omitempty" json:"-"`
+	Current    bool               `bson:"-" json:"current"`
+}
+
+type SessionRepository interface {
+	Create(c context.Context, session *Session) error
+	// Touch records a refresh of the session from the given client.
+	Touch(c context.Context, id string, client SessionClient, lastSeenAt time.Time, expiresAt time.Time) error
+	// FetchActiveByUserID returns the unrevoked, unexpired sessions, most
+	// recently seen first.
+	FetchActiveByUserID(c context.Context, userID string) ([]Session, error)
+	Revoke(c context.Context, id string, userID string) error
+	RevokeAllForUser(c context.Context, userID string) error
+	// FetchByUserID returns every session record, revoked and expired ones
+	// included.
+	FetchByUserID(c context.Context, userID string) ([]Session, error)
+	DeleteAllForUser(c context.Context, userID string) error
+}
+
+type SessionUsecase interface {
+	FetchByUserID(c context.Context, userID string, currentSessionID string) ([]Session, error)
+	// Revoke ends the session: its refresh tokens stop working and its
+	// access tokens are rejected until they expire.
+	Revoke(c context.Context, userID string, sessionID string, accessTokenExpiry int) error
+}
@@ -18,6 +18,7 @@ type SignupResponse struct {
 type SignupUsecase interface {
 	Create(c context.Context, user *User) error
 	GetUserByEmail(c context.Context, email string) (User, error)
-	CreateAccessToken(user *User, secret string, expiry int) (accessToken string, err error)
-	CreateRefreshToken(user *User, secret string, expiry int) (refreshToken string, err error)
+	CreateAccessToken(user *User, sessionID string, secret string, expiry int) (accessToken string, err error)
+	// CreateRefreshToken starts a new session for the client.
+	CreateRefreshToken(c context.Context, user *User, client SessionClient, secret string, expiry int) (refreshToken string, sessionID string, err error)
 }
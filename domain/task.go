@@ -19,6 +19,7 @@ type Task struct {
 type TaskRepository interface {
 	Create(c context.Context, task *Task) error
 	FetchByUserID(c context.Context, userID string) ([]Task, error)
+	DeleteAllForUser(c context.Context, userID string) error
 }
 
 type TaskUsecase interface {
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	CollectionTokenRevocation = "token_revocations"
+)
+
+// TokenRevocationStore tracks access tokens that must be rejected before they
+// expire. Entries can be dropped once expiresAt has passed.
+type TokenRevocationStore interface {
+	RevokeToken(c context.Context, tokenID string, expiresAt time.Time) error
+	RevokeSessionTokens(c context.Context, sessionID string, expiresAt time.Time) error
+	RevokeUserTokens(c context.Context, userID string, issuedBefore time.Time, expiresAt time.Time) error
+	IsRevoked(c context.Context, tokenID string, sessionID string, userID string, issuedAt time.Time) (bool, error)
+}
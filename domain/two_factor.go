@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var (
+	ErrInvalidTwoFactorCode    = errors.New("Invalid two-factor code")
+	ErrInvalidChallengeToken   = errors.New("Login challenge is invalid or expired")
+	ErrTwoFactorNotEnrolled    = errors.New("Two-factor authentication is not enrolled")
+	ErrTwoFactorAlreadyEnabled = errors.New("Two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("Two-factor authentication is not enabled")
+)
+
+// TwoFactor is the TOTP state of a user. It is enrolled without EnabledAt and
+// only enforced once the user has confirmed a first code. RecoveryCodes holds
+// hashes of the unused codes, salted with RecoverySalt.
+type TwoFactor struct {
+	Secret        string     `bson:"secret"`
+	EnabledAt     *time.Time `bson:"enabledAt,omitempty"`
+	LastStep      int64      `bson:"lastStep"`
+	RecoverySalt  string     `bson:"recoverySalt"`
+	RecoveryCodes []string   `bson:"recoveryCodes"`
+}
+
+type JwtChallengeClaims struct {
+	ID   string `json:"id"`
+	Type string `json:"typ"`
+	jwt.StandardClaims
+}
+
+type TwoFactorEnrollResponse struct {
+	Secret        string   `json:"secret"`
+	URI           string   `json:"uri"`
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `form:"code" binding:"required"`
+}
+
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `form:"challengeToken" binding:"required"`
+	Code           string `form:"code" binding:"required"`
+}
+
+type LoginChallengeResponse struct {
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	ChallengeToken    string `json:"challengeToken"`
+}
+
+type TwoFactorUsecase interface {
+	Enroll(c context.Context, userID string, issuer string) (TwoFactorEnrollResponse, error)
+	Enable(c context.Context, userID string, code string) error
+	// Disable counts wrong codes towards the same lockout as VerifyChallenge.
+	Disable(c context.Context, userID string, code string) error
+	CreateChallengeToken(user *User, secret string, expiryMinute int) (string, error)
+	// VerifyChallenge accepts a TOTP or an unused recovery code and returns
+	// the user the challenge was issued for. A challenge can only be answered
+	// once, and too many wrong codes fail with a LoginLockedError.
+	VerifyChallenge(c context.Context, challengeToken string, code string, secret string) (User, error)
+}
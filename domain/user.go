@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -10,16 +12,70 @@ const (
 	CollectionUser = "users"
 )
 
+// DeletedUserName replaces the name of a deleted account.
+const DeletedUserName = "Deleted user"
+
+// SystemRoleOperator is given to the staff who run the service. It is set in
+// the database only.
+const SystemRoleOperator = "operator"
+
+var (
+	ErrUserNotFound    = errors.New("User not found")
+	ErrAccountDisabled = errors.New("Account is disabled")
+)
+
 type User struct {
 	ID       primitive.ObjectID `bson:"_id"`
 	Name     string             `bson:"name"`
 	Email    string             `bson:"email"`
 	Password string             `bson:"password"`
+
+	EmailVerifiedAt *time.Time         `bson:"emailVerifiedAt,omitempty"`
+	TwoFactor       *TwoFactor         `bson:"twoFactor,omitempty"`
+	Identities      []ExternalIdentity `bson:"identities,omitempty"`
+	// PendingEmail replaces Email once the link sent to it is used.
+	PendingEmail string `bson:"pendingEmail,omitempty"`
+
+	DisplayName string `bson:"displayName,omitempty"`
+	// AvatarKey locates the avatar in the file storage.
+	AvatarKey string `bson:"avatarKey,omitempty"`
+	Currency  string `bson:"currency,omitempty"`
+	Locale    string `bson:"locale,omitempty"`
+	Timezone  string `bson:"timezone,omitempty"`
+
+	SystemRole string `bson:"systemRole,omitempty"`
+	// DisabledAt is set while an operator has disabled the account.
+	DisabledAt *time.Time `bson:"disabledAt,omitempty"`
+	DeletedAt  *time.Time `bson:"deletedAt,omitempty"`
 }
 
 type UserRepository interface {
 	Create(c context.Context, user *User) error
-	Fetch(c context.Context) ([]User, error)
+	// Fetch returns the users matching the query without their credentials,
+	// newest first.
+	Fetch(c context.Context, query *UserQuery, limit int64) ([]User, error)
 	GetByEmail(c context.Context, email string) (User, error)
 	GetByID(c context.Context, id string) (User, error)
+	UpdatePassword(c context.Context, id string, password string) error
+	MarkEmailVerified(c context.Context, id string, email string, verifiedAt time.Time) error
+	SetTwoFactor(c context.Context, id string, twoFactor *TwoFactor) error
+	// UseTwoFactorStep records a TOTP time step and reports false if it, or
+	// a later one, was already used.
+	UseTwoFactorStep(c context.Context, id string, step int64) (bool, error)
+	UseRecoveryCode(c context.Context, id string, codeHash string) (bool, error)
+	GetByIdentity(c context.Context, provider string, subject string) (User, error)
+	AddIdentity(c context.Context, id string, identity ExternalIdentity) error
+	SetPendingEmail(c context.Context, id string, email string) error
+	// ConfirmEmail makes the pending email, if it is still the given one, the
+	// verified email of the user.
+	ConfirmEmail(c context.Context, id string, email string, verifiedAt time.Time) error
+	UpdateProfile(c context.Context, id string, request *UpdateProfileRequest) error
+	// SetAvatar returns the key of the replaced avatar, if any. An empty key
+	// removes the avatar.
+	SetAvatar(c context.Context, id string, key string) (previousKey string, err error)
+	// Anonymize removes the personal data and credentials of the user but
+	// keeps the document, which other records still refer to.
+	Anonymize(c context.Context, id string, deletedAt time.Time) error
+	// SetDisabled disables the account, or enables it if disabledAt is nil.
+	SetDisabled(c context.Context, id string, disabledAt *time.Time) error
 }
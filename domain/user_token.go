@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CollectionUserToken = "user_tokens"
+)
+
+const (
+	UserTokenPurposePasswordReset     = "password_reset"
+	UserTokenPurposeEmailVerification = "email_verification"
+)
+
+// UserToken is a single-use token sent to a user out of band. Only the hash of
+// the token is stored.
+type UserToken struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	UserID    primitive.ObjectID `bson:"userID"`
+	Purpose   string             `bson:"purpose"`
+	Email     string             `bson:"email,omitempty"`
+	RequestIP string             `bson:"requestIP,omitempty"`
+	TokenHash string             `bson:"tokenHash"`
+	CreatedAt time.Time          `bson:"createdAt"`
+	ExpiresAt time.Time          `bson:"expiresAt"`
+	UsedAt    *time.Time         `bson:"usedAt,omitempty"`
+}
+
+type UserTokenRepository interface {
+	Create(c context.Context, token *UserToken) error
+	// Consume marks an unused, unexpired token as used and returns it.
+	Consume(c context.Context, purpose string, tokenHash string) (UserToken, error)
+	CountSince(c context.Context, userID string, purpose string, since time.Time) (int64, error)
+	CountByIPSince(c context.Context, ip string, purpose string, since time.Time) (int64, error)
+}
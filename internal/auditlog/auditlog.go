@@ -0,0 +1,100 @@
+// Package auditlog writes audit events in the background so that requests
+// never wait for the audit store.
+package auditlog
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	batchSize     = 100
+	flushInterval = time.Second
+)
+
+type Logger struct {
+	auditRepository domain.AuditRepository
+	events          chan domain.AuditEvent
+	dropped         atomic.Int64
+	contextTimeout  time.Duration
+}
+
+// NewLogger buffers up to bufferSize events. Events logged while the buffer
+// is full are dropped, and their number is logged with the next write.
+func NewLogger(auditRepository domain.AuditRepository, bufferSize int, timeout time.Duration) *Logger {
+	return &Logger{
+		auditRepository: auditRepository,
+		events:          make(chan domain.AuditEvent, bufferSize),
+		contextTimeout:  timeout,
+	}
+}
+
+func (l *Logger) Log(event domain.AuditEvent) {
+	if event.ID.IsZero() {
+		event.ID = primitive.NewObjectID()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	select {
+	case l.events <- event:
+	default:
+		l.dropped.Add(1)
+	}
+}
+
+// Run writes the logged events in batches until ctx is done, then writes the
+// events still buffered.
+func (l *Logger) Run(ctx context.Context) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]domain.AuditEvent, 0, batchSize)
+	for {
+		select {
+		case event := <-l.events:
+			batch = append(batch, event)
+			if len(batch) < batchSize {
+				continue
+			}
+		case <-ticker.C:
+		case <-ctx.Done():
+			for {
+				select {
+				case event := <-l.events:
+					batch = append(batch, event)
+				default:
+					l.flush(context.Background(), batch)
+					return
+				}
+			}
+		}
+
+		l.flush(ctx, batch)
+		batch = make([]domain.AuditEvent, 0, batchSize)
+	}
+}
+
+// flush logs a failed write rather than retrying it, so that a store that is
+// down cannot build up memory.
+func (l *Logger) flush(c context.Context, batch []domain.AuditEvent) {
+	if dropped := l.dropped.Swap(0); dropped > 0 {
+		log.Println("Audit events dropped: ", dropped)
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, l.contextTimeout)
+	defer cancel()
+
+	if err := l.auditRepository.Insert(ctx, batch); err != nil {
+		log.Println("Audit write failed: ", err)
+	}
+}
@@ -0,0 +1,55 @@
+package auditlog_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/auditlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLogger(t *testing.T) {
+	t.Run("log does not wait for a full buffer", func(t *testing.T) {
+		logger := auditlog.NewLogger(new(mocks.AuditRepository), 1, time.Second)
+
+		done := make(chan struct{})
+		go func() {
+			logger.Log(domain.AuditEvent{Action: domain.AuditActionLogin})
+			logger.Log(domain.AuditEvent{Action: domain.AuditActionLogin})
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Log blocked")
+		}
+	})
+
+	t.Run("run writes the buffered events on shutdown", func(t *testing.T) {
+		mockAuditRepository := new(mocks.AuditRepository)
+		written := make(chan []domain.AuditEvent, 1)
+		mockAuditRepository.On("Insert", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			written <- args.Get(1).([]domain.AuditEvent)
+		}).Return(nil).Once()
+
+		logger := auditlog.NewLogger(mockAuditRepository, 10, time.Second)
+		logger.Log(domain.AuditEvent{Action: domain.AuditActionSignup, UserID: "user"})
+		logger.Log(domain.AuditEvent{Action: domain.AuditActionLogin, UserID: "user"})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		logger.Run(ctx)
+
+		events := <-written
+		assert.Len(t, events, 2)
+		assert.Equal(t, domain.AuditActionSignup, events[0].Action)
+		assert.False(t, events[0].ID.IsZero())
+		assert.False(t, events[0].OccurredAt.IsZero())
+		mockAuditRepository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,49 @@
+// Package debtutil computes the late fees of group debts.
+package debtutil
+
+import (
+	"math"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+)
+
+// LateFee returns the late fee the policy adds to the debt at now and the
+// date it is charged through. It returns a zero amount when nothing is due.
+// Daily interest is charged for whole days, on what is left of the debt
+// without its fees, so it should be applied daily.
+func LateFee(policy *domain.LateFeePolicy, debt *domain.GroupDebt, now time.Time) (int64, time.Time) {
+	if policy == nil || debt.DueDate == nil || debt.Balance <= 0 {
+		return 0, time.Time{}
+	}
+
+	start := debt.DueDate.AddDate(0, 0, policy.GraceDays)
+	if !start.Before(now) {
+		return 0, time.Time{}
+	}
+
+	switch policy.Type {
+	case domain.LateFeeFlat:
+		if debt.ChargedThrough != nil {
+			return 0, time.Time{}
+		}
+		return policy.Amount, start
+	case domain.LateFeeDailyInterest:
+		if debt.ChargedThrough != nil && debt.ChargedThrough.After(start) {
+			start = *debt.ChargedThrough
+		}
+		base := debt.Amount - debt.Paid
+		if debt.Balance < base {
+			base = debt.Balance
+		}
+		days := int(now.Sub(start) / (24 * time.Hour))
+		amount := int64(math.Round(float64(base) * float64(policy.DailyRateBP) / 10000 * float64(days)))
+		// Days too short to charge anything are charged with the next ones.
+		if amount <= 0 {
+			return 0, time.Time{}
+		}
+		return amount, start.AddDate(0, 0, days)
+	}
+
+	return 0, time.Time{}
+}
@@ -0,0 +1,71 @@
+package debtutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/debtutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLateFee(t *testing.T) {
+	dueDate := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+	newDebt := func() *domain.GroupDebt {
+		return &domain.GroupDebt{Amount: 1000, Paid: 500, Balance: 500, DueDate: &dueDate}
+	}
+
+	t.Run("flat fee once", func(t *testing.T) {
+		policy := &domain.LateFeePolicy{Type: domain.LateFeeFlat, Amount: 25, GraceDays: 3}
+		debt := newDebt()
+
+		amount, _ := debtutil.LateFee(policy, debt, time.Date(2026, time.February, 2, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, int64(0), amount)
+
+		amount, through := debtutil.LateFee(policy, debt, time.Date(2026, time.February, 4, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, int64(25), amount)
+		assert.Equal(t, time.Date(2026, time.February, 3, 0, 0, 0, 0, time.UTC), through)
+
+		debt.ChargedThrough = &through
+		amount, _ = debtutil.LateFee(policy, debt, time.Date(2026, time.February, 20, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, int64(0), amount)
+	})
+
+	t.Run("daily interest on the remaining amount", func(t *testing.T) {
+		policy := &domain.LateFeePolicy{Type: domain.LateFeeDailyInterest, DailyRateBP: 100}
+		debt := newDebt()
+
+		amount, through := debtutil.LateFee(policy, debt, time.Date(2026, time.February, 3, 12, 0, 0, 0, time.UTC))
+		// 1% of the remaining 500 for three whole days.
+		assert.Equal(t, int64(15), amount)
+		assert.Equal(t, time.Date(2026, time.February, 3, 0, 0, 0, 0, time.UTC), through)
+
+		debt.ChargedThrough = &through
+		debt.Charges = amount
+		debt.Balance += amount
+		amount, _ = debtutil.LateFee(policy, debt, time.Date(2026, time.February, 3, 18, 0, 0, 0, time.UTC))
+		assert.Equal(t, int64(0), amount)
+
+		// Fees are not charged interest.
+		amount, _ = debtutil.LateFee(policy, debt, time.Date(2026, time.February, 4, 1, 0, 0, 0, time.UTC))
+		assert.Equal(t, int64(5), amount)
+	})
+
+	t.Run("settled or without due date", func(t *testing.T) {
+		policy := &domain.LateFeePolicy{Type: domain.LateFeeFlat, Amount: 25}
+		now := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+		debt := newDebt()
+		debt.Balance = 0
+		amount, _ := debtutil.LateFee(policy, debt, now)
+		assert.Equal(t, int64(0), amount)
+
+		debt = newDebt()
+		debt.DueDate = nil
+		amount, _ = debtutil.LateFee(policy, debt, now)
+		assert.Equal(t, int64(0), amount)
+
+		amount, _ = debtutil.LateFee(nil, newDebt(), now)
+		assert.Equal(t, int64(0), amount)
+	})
+}
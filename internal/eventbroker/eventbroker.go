@@ -0,0 +1,199 @@
+package eventbroker
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+)
+
+const (
+	DefaultLogSize = 100
+	DefaultIdleTTL = 30 * time.Minute
+
+	subscriberBufferSize = 16
+)
+
+type loggedEvent struct {
+	seq   uint64
+	event domain.Event
+}
+
+type groupLog struct {
+	// base is the sequence number after which the log holds every event of
+	// the group. Clients that saw an earlier one may have missed events.
+	base        uint64
+	events      []loggedEvent
+	subscribers map[chan domain.Event]struct{}
+	idleSince   time.Time
+}
+
+// broker keeps a bounded log of the latest events of every group so that
+// reconnecting clients can resume from the last event they have seen.
+//
+// Event IDs are "<epoch>-<sequence>". The epoch is new for every broker, so
+// IDs from before a restart are recognised and answered with a resync rather
+// than matched against unrelated events. The sequence is shared by all
+// groups, so a group whose log was dropped never reuses an ID.
+type broker struct {
+	mu        sync.Mutex
+	logSize   int
+	idleTTL   time.Duration
+	epoch     string
+	seq       uint64
+	groups    map[string]*groupLog
+	lastSweep time.Time
+}
+
+// NewEventBroker drops the log of a group that has had no subscribers and no
+// events for idleTTL.
+func NewEventBroker(logSize int, idleTTL time.Duration) domain.EventBroker {
+	if logSize <= 0 {
+		logSize = DefaultLogSize
+	}
+	if idleTTL <= 0 {
+		idleTTL = DefaultIdleTTL
+	}
+	return &broker{
+		logSize:   logSize,
+		idleTTL:   idleTTL,
+		epoch:     strconv.FormatInt(time.Now().UnixNano(), 36),
+		groups:    make(map[string]*groupLog),
+		lastSweep: time.Now(),
+	}
+}
+
+func (b *broker) group(groupID string, now time.Time) *groupLog {
+	g, ok := b.groups[groupID]
+	if !ok {
+		g = &groupLog{
+			base:        b.seq,
+			subscribers: make(map[chan domain.Event]struct{}),
+			idleSince:   now,
+		}
+		b.groups[groupID] = g
+	}
+	return g
+}
+
+// sweep drops idle groups, at most once per idleTTL.
+func (b *broker) sweep(now time.Time) {
+	if now.Sub(b.lastSweep) < b.idleTTL {
+		return
+	}
+	b.lastSweep = now
+
+	for groupID, g := range b.groups {
+		if len(g.subscribers) == 0 && now.Sub(g.idleSince) >= b.idleTTL {
+			delete(b.groups, groupID)
+		}
+	}
+}
+
+func (b *broker) Publish(groupID string, eventType string, data interface{}) domain.Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.sweep(now)
+
+	g := b.group(groupID, now)
+	g.idleSince = now
+	b.seq++
+	event := domain.Event{
+		ID:        b.epoch + "-" + strconv.FormatUint(b.seq, 10),
+		GroupID:   groupID,
+		Type:      eventType,
+		Data:      data,
+		CreatedAt: now,
+	}
+
+	g.events = append(g.events, loggedEvent{seq: b.seq, event: event})
+	if len(g.events) > b.logSize {
+		evicted := len(g.events) - b.logSize
+		g.base = g.events[evicted-1].seq
+		g.events = g.events[evicted:]
+	}
+
+	for ch := range g.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// The subscriber is too slow to keep up. Closing its channel ends
+			// the stream and the client resumes from the log on reconnect.
+			delete(g.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return event
+}
+
+func (b *broker) Subscribe(groupID string, lastEventID string) (domain.EventSubscription, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.sweep(now)
+
+	g := b.group(groupID, now)
+
+	subscription := domain.EventSubscription{Complete: true}
+	if lastEventID != "" {
+		seq, ok := b.parseID(lastEventID)
+		switch {
+		case !ok:
+			// The ID is from another broker, e.g. before a restart.
+			subscription.Complete = false
+		case seq < g.base:
+			subscription.Complete = false
+			subscription.Backlog = g.since(seq)
+		default:
+			subscription.Backlog = g.since(seq)
+		}
+	}
+
+	ch := make(chan domain.Event, subscriberBufferSize)
+	g.subscribers[ch] = struct{}{}
+	subscription.Events = ch
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if _, ok := g.subscribers[ch]; ok {
+				delete(g.subscribers, ch)
+				close(ch)
+			}
+			g.idleSince = time.Now()
+		})
+	}
+
+	return subscription, unsubscribe
+}
+
+// parseID returns the sequence number of an ID issued by this broker.
+func (b *broker) parseID(id string) (uint64, bool) {
+	epoch, seq, found := strings.Cut(id, "-")
+	if !found || epoch != b.epoch {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil || n > b.seq {
+		return 0, false
+	}
+	return n, true
+}
+
+func (g *groupLog) since(seq uint64) []domain.Event {
+	var events []domain.Event
+	for _, logged := range g.events {
+		if logged.seq > seq {
+			events = append(events, logged.event)
+		}
+	}
+	return events
+}
@@ -0,0 +1,128 @@
+package eventbroker_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/eventbroker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribe(t *testing.T) {
+
+	t.Run("live", func(t *testing.T) {
+		broker := eventbroker.NewEventBroker(10, time.Minute)
+
+		subscription, unsubscribe := broker.Subscribe("group", "")
+		defer unsubscribe()
+
+		published := broker.Publish("group", "expense_added", nil)
+		broker.Publish("other", "expense_added", nil)
+
+		event := <-subscription.Events
+		assert.Equal(t, published.ID, event.ID)
+		assert.Equal(t, "group", event.GroupID)
+		assert.Empty(t, subscription.Backlog)
+		assert.True(t, subscription.Complete)
+		assert.Len(t, subscription.Events, 0)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		broker := eventbroker.NewEventBroker(10, time.Minute)
+
+		var ids []string
+		for i := 0; i < 5; i++ {
+			ids = append(ids, broker.Publish("group", "expense_added", i).ID)
+			broker.Publish("other", "expense_added", i)
+		}
+
+		subscription, unsubscribe := broker.Subscribe("group", ids[2])
+		defer unsubscribe()
+
+		assert.True(t, subscription.Complete)
+		assert.Len(t, subscription.Backlog, 2)
+		assert.Equal(t, ids[3], subscription.Backlog[0].ID)
+		assert.Equal(t, ids[4], subscription.Backlog[1].ID)
+	})
+
+	t.Run("evicted", func(t *testing.T) {
+		broker := eventbroker.NewEventBroker(3, time.Minute)
+
+		var ids []string
+		for i := 0; i < 10; i++ {
+			ids = append(ids, broker.Publish("group", "expense_added", i).ID)
+		}
+
+		subscription, unsubscribe := broker.Subscribe("group", ids[1])
+		defer unsubscribe()
+
+		assert.False(t, subscription.Complete)
+		assert.Len(t, subscription.Backlog, 3)
+		assert.Equal(t, ids[7], subscription.Backlog[0].ID)
+	})
+
+	t.Run("id from another broker", func(t *testing.T) {
+		previous := eventbroker.NewEventBroker(3, time.Minute)
+		id := previous.Publish("group", "expense_added", nil).ID
+
+		broker := eventbroker.NewEventBroker(3, time.Minute)
+		broker.Publish("group", "expense_added", nil)
+		broker.Publish("group", "expense_added", nil)
+
+		subscription, unsubscribe := broker.Subscribe("group", id)
+		defer unsubscribe()
+
+		assert.False(t, subscription.Complete)
+		assert.Empty(t, subscription.Backlog)
+
+		subscription, unsubscribe = broker.Subscribe("group", "42")
+		defer unsubscribe()
+		assert.False(t, subscription.Complete)
+	})
+
+	t.Run("idle group dropped", func(t *testing.T) {
+		broker := eventbroker.NewEventBroker(3, 10*time.Millisecond)
+
+		id := broker.Publish("group", "expense_added", nil).ID
+		broker.Publish("group", "expense_added", nil)
+		time.Sleep(20 * time.Millisecond)
+		broker.Publish("other", "expense_added", nil)
+
+		// The log of the group is gone, so the client has to reload rather
+		// than be told nothing happened.
+		subscription, unsubscribe := broker.Subscribe("group", id)
+		defer unsubscribe()
+		assert.False(t, subscription.Complete)
+		assert.Empty(t, subscription.Backlog)
+	})
+
+	t.Run("subscribed group kept", func(t *testing.T) {
+		broker := eventbroker.NewEventBroker(3, 10*time.Millisecond)
+
+		_, unsubscribe := broker.Subscribe("group", "")
+		defer unsubscribe()
+		id := broker.Publish("group", "expense_added", nil).ID
+		broker.Publish("group", "expense_added", nil)
+		time.Sleep(20 * time.Millisecond)
+		broker.Publish("other", "expense_added", nil)
+
+		subscription, unsubscribe := broker.Subscribe("group", id)
+		defer unsubscribe()
+		assert.True(t, subscription.Complete)
+		assert.Len(t, subscription.Backlog, 1)
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		broker := eventbroker.NewEventBroker(3, time.Minute)
+
+		subscription, unsubscribe := broker.Subscribe("group", "")
+		unsubscribe()
+		unsubscribe()
+
+		_, ok := <-subscription.Events
+		assert.False(t, ok)
+
+		broker.Publish("group", "expense_added", nil)
+	})
+
+}
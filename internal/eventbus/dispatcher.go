@@ -0,0 +1,82 @@
+package eventbus
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+)
+
+const (
+	DefaultPollInterval = time.Second
+
+	dispatchBatchSize = 100
+)
+
+// Dispatcher relays the events written to the outbox to the bus. An event is
+// only marked as dispatched once every subscriber has handled it, so failed
+// events are retried on the next poll.
+type Dispatcher struct {
+	outboxRepository domain.OutboxRepository
+	bus              *Bus
+	pollInterval     time.Duration
+	contextTimeout   time.Duration
+}
+
+func NewDispatcher(outboxRepository domain.OutboxRepository, bus *Bus, pollInterval time.Duration, timeout time.Duration) *Dispatcher {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	return &Dispatcher{
+		outboxRepository: outboxRepository,
+		bus:              bus,
+		pollInterval:     pollInterval,
+		contextTimeout:   timeout,
+	}
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DispatchPending(ctx); err != nil {
+			log.Println("Outbox dispatch failed: ", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) DispatchPending(c context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(c, d.contextTimeout)
+	defer cancel()
+
+	events, err := d.outboxRepository.FetchPending(ctx, dispatchBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	dispatched := 0
+	for _, event := range events {
+		if err := d.bus.Dispatch(ctx, event); err != nil {
+			log.Println(err)
+			if err := d.outboxRepository.MarkFailed(ctx, event.ID); err != nil {
+				return dispatched, err
+			}
+			continue
+		}
+
+		if err := d.outboxRepository.MarkDispatched(ctx, event.ID); err != nil {
+			return dispatched, err
+		}
+		dispatched++
+	}
+
+	return dispatched, nil
+}
@@ -0,0 +1,89 @@
+package eventbus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/eventbroker"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/eventbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDispatchPending(t *testing.T) {
+	event, err := domain.NewDomainEvent(domain.ExpenseAdded{
+		GroupID:   "group",
+		ExpenseID: "expense",
+		PaidBy:    "user",
+		Amount:    1250,
+		Currency:  "EUR",
+	})
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockOutboxRepository := new(mocks.OutboxRepository)
+		mockOutboxRepository.On("FetchPending", mock.Anything, mock.Anything).Return([]domain.DomainEvent{event}, nil).Once()
+		mockOutboxRepository.On("MarkDispatched", mock.Anything, event.ID).Return(nil).Once()
+
+		broker := eventbroker.NewEventBroker(10, time.Minute)
+		subscription, unsubscribe := broker.Subscribe("group", "")
+		defer unsubscribe()
+
+		bus := eventbus.NewBus()
+		bus.Subscribe("realtime", eventbus.RelayToBroker(broker), domain.DomainEventExpenseAdded)
+
+		d := eventbus.NewDispatcher(mockOutboxRepository, bus, time.Second, time.Second*2)
+
+		dispatched, err := d.DispatchPending(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, dispatched)
+
+		published := <-subscription.Events
+		assert.Equal(t, domain.DomainEventExpenseAdded, published.Type)
+		assert.Equal(t, event.ID.Hex(), published.Data.(map[string]interface{})["eventID"])
+
+		mockOutboxRepository.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockOutboxRepository := new(mocks.OutboxRepository)
+		mockOutboxRepository.On("FetchPending", mock.Anything, mock.Anything).Return([]domain.DomainEvent{event}, nil).Once()
+		mockOutboxRepository.On("MarkFailed", mock.Anything, event.ID).Return(nil).Once()
+
+		bus := eventbus.NewBus()
+		bus.Subscribe("webhooks", func(ctx context.Context, event domain.DomainEvent) error {
+			return errors.New("Unexpected")
+		}, domain.DomainEventExpenseAdded)
+
+		d := eventbus.NewDispatcher(mockOutboxRepository, bus, time.Second, time.Second*2)
+
+		dispatched, err := d.DispatchPending(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, dispatched)
+
+		mockOutboxRepository.AssertExpectations(t)
+	})
+
+}
+
+func TestDeduplicate(t *testing.T) {
+	event, err := domain.NewDomainEvent(domain.MemberJoined{GroupID: "group", UserID: "user", Role: "member"})
+	assert.NoError(t, err)
+
+	calls := 0
+	handler := eventbus.Deduplicate(func(ctx context.Context, event domain.DomainEvent) error {
+		calls++
+		return nil
+	}, 10)
+
+	assert.NoError(t, handler(context.Background(), event))
+	assert.NoError(t, handler(context.Background(), event))
+
+	assert.Equal(t, 1, calls)
+}
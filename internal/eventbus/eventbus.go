@@ -0,0 +1,107 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+)
+
+type Handler func(ctx context.Context, event domain.DomainEvent) error
+
+type subscriber struct {
+	name    string
+	handler Handler
+}
+
+// Bus delivers domain events to the subscribers registered for their type.
+// Delivery is at-least-once, so handlers should deduplicate by event ID.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]subscriber
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[string][]subscriber),
+	}
+}
+
+func (b *Bus) Subscribe(name string, handler Handler, eventTypes ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, eventType := range eventTypes {
+		b.subscribers[eventType] = append(b.subscribers[eventType], subscriber{name: name, handler: handler})
+	}
+}
+
+func (b *Bus) Dispatch(ctx context.Context, event domain.DomainEvent) error {
+	b.mu.RLock()
+	subscribers := b.subscribers[event.Type]
+	b.mu.RUnlock()
+
+	var failed []string
+	for _, s := range subscribers {
+		if err := s.handler(ctx, event); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", s.name, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("dispatching %s event %s failed: %s", event.Type, event.ID.Hex(), strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// Deduplicate drops events the wrapped handler has already processed
+// successfully, remembering the IDs of the last size events.
+func Deduplicate(handler Handler, size int) Handler {
+	var mu sync.Mutex
+	seen := make(map[string]struct{}, size)
+	order := make([]string, 0, size)
+
+	return func(ctx context.Context, event domain.DomainEvent) error {
+		id := event.ID.Hex()
+
+		mu.Lock()
+		_, ok := seen[id]
+		mu.Unlock()
+		if ok {
+			return nil
+		}
+
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if _, ok := seen[id]; ok {
+			return nil
+		}
+		if len(order) >= size {
+			delete(seen, order[0])
+			order = order[1:]
+		}
+		seen[id] = struct{}{}
+		order = append(order, id)
+		return nil
+	}
+}
+
+// RelayToBroker forwards domain events to the real-time channels of the
+// group they belong to.
+func RelayToBroker(broker domain.EventBroker) Handler {
+	return func(ctx context.Context, event domain.DomainEvent) error {
+		var data map[string]interface{}
+		if err := event.DecodePayload(&data); err != nil {
+			return err
+		}
+		data["eventID"] = event.ID.Hex()
+		broker.Publish(event.AggregateID, event.Type, data)
+		return nil
+	}
+}
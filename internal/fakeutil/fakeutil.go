@@ -1 +1,44 @@
 package fakeutil
+
+import (
+	"context"
+	"sync"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+)
+
+// Mailer records messages instead of sending them.
+type Mailer struct {
+	mu       sync.Mutex
+	messages []domain.MailMessage
+}
+
+func NewMailer() *Mailer {
+	return &Mailer{}
+}
+
+func (m *Mailer) Send(c context.Context, message domain.MailMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func (m *Mailer) Messages() []domain.MailMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]domain.MailMessage(nil), m.messages...)
+}
+
+// Transactor runs the function without a transaction, for tests.
+type Transactor struct{}
+
+func NewTransactor() *Transactor {
+	return &Transactor{}
+}
+
+func (t *Transactor) WithTransaction(c context.Context, fn func(ctx context.Context) error) error {
+	return fn(c)
+}
@@ -0,0 +1,85 @@
+// Package imageutil turns uploaded images into square JPEG thumbnails.
+package imageutil
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+
+	// Formats accepted by Thumbnail.
+	_ "image/gif"
+	_ "image/png"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+)
+
+const jpegQuality = 85
+
+// Thumbnail crops the center square of the image and scales it down to at
+// most size pixels wide. Images with more than maxPixels pixels are rejected
+// before they are decoded.
+func Thumbnail(r io.Reader, size int, maxPixels int) ([]byte, error) {
+	var buf bytes.Buffer
+	config, _, err := image.DecodeConfig(io.TeeReader(r, &buf))
+	if err != nil {
+		return nil, domain.ErrInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPixels {
+		return nil, domain.ErrInvalidImage
+	}
+
+	src, _, err := image.Decode(io.MultiReader(&buf, r))
+	if err != nil {
+		return nil, domain.ErrInvalidImage
+	}
+
+	bounds := src.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+	))
+	if size > side {
+		size = side
+	}
+
+	var out bytes.Buffer
+	err = jpeg.Encode(&out, scale(src, crop, size), &jpeg.Options{Quality: jpegQuality})
+	if err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// scale averages the source pixels that fall into each destination pixel,
+// over a white background for transparent images.
+func scale(src image.Image, crop image.Rectangle, size int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	side := crop.Dx()
+	for y := 0; y < size; y++ {
+		y0, y1 := crop.Min.Y+y*side/size, crop.Min.Y+(y+1)*side/size
+		for x := 0; x < size; x++ {
+			x0, x1 := crop.Min.X+x*side/size, crop.Min.X+(x+1)*side/size
+
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					// Colors are premultiplied, so adding what is missing
+					// from opaque puts them over white.
+					r += uint64(cr + 0xffff - ca)
+					g += uint64(cg + 0xffff - ca)
+					b += uint64(cb + 0xffff - ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: 0xffff})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,126 @@
+package loanutil
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+)
+
+// Schedule splits principal into n monthly installments, the first one due on
+// firstDueDate. Interest accrues monthly on the outstanding principal.
+func Schedule(scheduleType string, principal int64, annualInterestRateBP int, n int, firstDueDate time.Time) ([]domain.Installment, error) {
+	if principal <= 0 || n <= 0 {
+		return nil, fmt.Errorf("Invalid loan terms")
+	}
+
+	rate := float64(annualInterestRateBP) / 10000 / 12
+
+	var payment int64
+	switch scheduleType {
+	case domain.LoanScheduleEqualPrincipal:
+	case domain.LoanScheduleAmortized:
+		if rate == 0 {
+			break
+		}
+		payment = int64(math.Round(float64(principal) * rate / (1 - math.Pow(1+rate, -float64(n)))))
+	default:
+		return nil, fmt.Errorf("Unknown schedule type: %s", scheduleType)
+	}
+
+	installments := make([]domain.Installment, n)
+	balance := principal
+	for i := 0; i < n; i++ {
+		interest := int64(math.Round(float64(balance) * rate))
+
+		var part int64
+		switch {
+		case i == n-1:
+			part = balance
+		case payment > 0:
+			part = payment - interest
+		default:
+			part = principal / int64(n)
+		}
+		if part < 0 {
+			part = 0
+		}
+		if part > balance {
+			part = balance
+		}
+		balance -= part
+
+		installments[i] = domain.Installment{
+			Number:    i + 1,
+			DueDate:   addMonths(firstDueDate, i),
+			Principal: part,
+			Interest:  interest,
+			Amount:    part + interest,
+		}
+	}
+
+	return installments, nil
+}
+
+// addMonths keeps due dates at the end of shorter months instead of letting
+// them overflow into the next one, e.g. Jan 31 + 1 month is Feb 28.
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	lastDay := time.Date(year, month+time.Month(months)+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	hour, min, sec := t.Clock()
+	return time.Date(year, month+time.Month(months), day, hour, min, sec, t.Nanosecond(), t.Location())
+}
+
+// Balance allocates the repayments of a loan to its installments in order,
+// covering the interest of an installment before its principal.
+func Balance(loan *domain.Loan, now time.Time) *domain.LoanBalance {
+	balance := &domain.LoanBalance{
+		LoanID:       loan.ID,
+		Currency:     loan.Currency,
+		Status:       loan.Status,
+		Installments: make([]domain.InstallmentStatus, len(loan.Installments)),
+	}
+
+	for _, repayment := range loan.Repayments {
+		balance.Repaid += repayment.Amount
+	}
+
+	available := balance.Repaid
+	principalPaid := int64(0)
+	for i, installment := range loan.Installments {
+		paid := installment.Amount
+		if available < paid {
+			paid = available
+		}
+		available -= paid
+
+		if paid > installment.Interest {
+			principalPaid += paid - installment.Interest
+		}
+
+		status := domain.InstallmentStatus{
+			Installment: installment,
+			Paid:        paid,
+			Remaining:   installment.Amount - paid,
+		}
+		status.Overdue = status.Remaining > 0 && installment.DueDate.Before(now)
+
+		balance.TotalDue += installment.Amount
+		if status.Overdue {
+			balance.Overdue += status.Remaining
+		}
+		balance.Installments[i] = status
+		if status.Remaining > 0 && balance.NextInstallment == nil {
+			balance.NextInstallment = &balance.Installments[i]
+		}
+	}
+
+	balance.Remaining = balance.TotalDue - balance.Repaid
+	balance.RemainingPrincipal = loan.Principal - principalPaid
+
+	return balance
+}
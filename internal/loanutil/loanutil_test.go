@@ -0,0 +1,90 @@
+package loanutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/loanutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func sum(installments []domain.Installment) (principal int64, total int64) {
+	for _, installment := range installments {
+		principal += installment.Principal
+		total += installment.Amount
+	}
+	return principal, total
+}
+
+func TestSchedule(t *testing.T) {
+	firstDueDate := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("equal principal", func(t *testing.T) {
+		installments, err := loanutil.Schedule(domain.LoanScheduleEqualPrincipal, 100000, 1200, 3, firstDueDate)
+
+		assert.NoError(t, err)
+		assert.Len(t, installments, 3)
+		assert.Equal(t, int64(33333), installments[0].Principal)
+		assert.Equal(t, int64(1000), installments[0].Interest)
+		assert.Equal(t, int64(33334), installments[2].Principal)
+		assert.Equal(t, time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC), installments[2].DueDate)
+
+		principal, _ := sum(installments)
+		assert.Equal(t, int64(100000), principal)
+	})
+
+	t.Run("amortized", func(t *testing.T) {
+		installments, err := loanutil.Schedule(domain.LoanScheduleAmortized, 100000, 1200, 12, firstDueDate)
+
+		assert.NoError(t, err)
+		assert.Len(t, installments, 12)
+		for _, installment := range installments[:11] {
+			assert.Equal(t, int64(8885), installment.Amount)
+		}
+
+		principal, _ := sum(installments)
+		assert.Equal(t, int64(100000), principal)
+	})
+
+	t.Run("interest free", func(t *testing.T) {
+		installments, err := loanutil.Schedule(domain.LoanScheduleAmortized, 1000, 0, 4, firstDueDate)
+
+		assert.NoError(t, err)
+
+		principal, total := sum(installments)
+		assert.Equal(t, int64(1000), principal)
+		assert.Equal(t, int64(1000), total)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := loanutil.Schedule("balloon", 1000, 0, 4, firstDueDate)
+
+		assert.Error(t, err)
+	})
+
+}
+
+func TestBalance(t *testing.T) {
+	firstDueDate := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+	installments, err := loanutil.Schedule(domain.LoanScheduleEqualPrincipal, 3000, 1200, 3, firstDueDate)
+	assert.NoError(t, err)
+
+	loan := &domain.Loan{
+		Principal:    3000,
+		Status:       domain.LoanStatusActive,
+		Installments: installments,
+		Repayments:   []domain.Repayment{{Amount: 1500}},
+	}
+
+	balance := loanutil.Balance(loan, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, int64(3060), balance.TotalDue)
+	assert.Equal(t, int64(1500), balance.Repaid)
+	assert.Equal(t, int64(1560), balance.Remaining)
+	assert.Equal(t, int64(1550), balance.RemainingPrincipal)
+	assert.Equal(t, int64(550), balance.Overdue)
+	assert.Equal(t, 2, balance.NextInstallment.Number)
+	assert.Equal(t, int64(470), balance.NextInstallment.Paid)
+	assert.Equal(t, time.Date(2026, time.February, 28, 0, 0, 0, 0, time.UTC), balance.NextInstallment.DueDate)
+}
@@ -0,0 +1,56 @@
+package mailutil
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+)
+
+type smtpMailer struct {
+	address string
+	auth    smtp.Auth
+	from    string
+}
+
+// NewSMTPMailer sends plain text mail through an SMTP relay. Authentication
+// is skipped when no user is given.
+func NewSMTPMailer(host string, port string, user string, pass string, from string) domain.Mailer {
+	var auth smtp.Auth
+	if user != "" {
+		auth = smtp.PlainAuth("", user, pass, host)
+	}
+	return &smtpMailer{
+		address: net.JoinHostPort(host, port),
+		auth:    auth,
+		from:    from,
+	}
+}
+
+func (m *smtpMailer) Send(c context.Context, message domain.MailMessage) error {
+	if strings.ContainsAny(message.To, "\r\n") || strings.ContainsAny(message.Subject, "\r\n") {
+		return fmt.Errorf("Invalid mail header")
+	}
+
+	body := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		m.from, message.To, message.Subject, message.Body)
+
+	return smtp.SendMail(m.address, m.auth, m.from, []string{message.To}, []byte(body))
+}
+
+type logMailer struct{}
+
+// NewLogMailer writes mail to the log instead of sending it. It is meant for
+// local development only, as the log then contains the links sent to users.
+func NewLogMailer() domain.Mailer {
+	return &logMailer{}
+}
+
+func (m *logMailer) Send(c context.Context, message domain.MailMessage) error {
+	log.Printf("Mail to %s: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
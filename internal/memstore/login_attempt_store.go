@@ -0,0 +1,82 @@
+package memstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+)
+
+type loginAttempt struct {
+	attempts  domain.LoginAttempts
+	expiresAt time.Time
+}
+
+// loginAttemptStore keeps failure counters in process memory, so each
+// instance counts on its own.
+type loginAttemptStore struct {
+	mu        sync.Mutex
+	attempts  map[string]loginAttempt
+	lastSweep time.Time
+}
+
+func NewLoginAttemptStore() domain.LoginAttemptStore {
+	return &loginAttemptStore{
+		attempts:  make(map[string]loginAttempt),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *loginAttemptStore) Get(c context.Context, key string) (domain.LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok || time.Now().After(attempt.expiresAt) {
+		return domain.LoginAttempts{}, nil
+	}
+	return attempt.attempts, nil
+}
+
+func (s *loginAttemptStore) RecordFailure(c context.Context, key string, now time.Time, resetAfter time.Duration) (domain.LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep()
+
+	attempt := s.attempts[key]
+	if now.Sub(attempt.attempts.LastFailureAt) > resetAfter {
+		attempt.attempts.Failures = 0
+	}
+	attempt.attempts.Failures++
+	attempt.attempts.LastFailureAt = now
+	attempt.expiresAt = now.Add(resetAfter)
+	s.attempts[key] = attempt
+
+	return attempt.attempts, nil
+}
+
+func (s *loginAttemptStore) Reset(c context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
+
+// sweep drops expired counters, at most once per sweepInterval. The caller
+// must hold the lock.
+func (s *loginAttemptStore) sweep() {
+	now := time.Now()
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, attempt := range s.attempts {
+		if now.After(attempt.expiresAt) {
+			delete(s.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package memstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+)
+
+const sweepInterval = time.Minute
+
+type revocation struct {
+	issuedBefore time.Time
+	expiresAt    time.Time
+}
+
+// tokenRevocationStore keeps revocations in process memory. It suits single
+// instance deployments and tests; revocations are lost on restart.
+type tokenRevocationStore struct {
+	mu        sync.RWMutex
+	tokens    map[string]revocation
+	sessions  map[string]revocation
+	users     map[string]revocation
+	lastSweep time.Time
+}
+
+func NewTokenRevocationStore() domain.TokenRevocationStore {
+	return &tokenRevocationStore{
+		tokens:    make(map[string]revocation),
+		sessions:  make(map[string]revocation),
+		users:     make(map[string]revocation),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *tokenRevocationStore) RevokeToken(c context.Context, tokenID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep()
+	s.tokens[tokenID] = revocation{expiresAt: expiresAt}
+	return nil
+}
+
+func (s *tokenRevocationStore) RevokeSessionTokens(c context.Context, sessionID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep()
+	s.sessions[sessionID] = revocation{expiresAt: expiresAt}
+	return nil
+}
+
+func (s *tokenRevocationStore) RevokeUserTokens(c context.Context, userID string, issuedBefore time.Time, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep()
+	s.users[userID] = revocation{issuedBefore: issuedBefore, expiresAt: expiresAt}
+	return nil
+}
+
+func (s *tokenRevocationStore) IsRevoked(c context.Context, tokenID string, sessionID string, userID string, issuedAt time.Time) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.tokens[tokenID]; ok {
+		return true, nil
+	}
+	if _, ok := s.sessions[sessionID]; sessionID != "" && ok {
+		return true, nil
+	}
+	if r, ok := s.users[userID]; ok && issuedAt.Before(r.issuedBefore) {
+		return true, nil
+	}
+	return false, nil
+}
+
+// sweep drops expired entries, at most once per sweepInterval. The caller
+// must hold the write lock.
+func (s *tokenRevocationStore) sweep() {
+	now := time.Now()
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for id, r := range s.tokens {
+		if now.After(r.expiresAt) {
+			delete(s.tokens, id)
+		}
+	}
+	for id, r := range s.sessions {
+		if now.After(r.expiresAt) {
+			delete(s.sessions, id)
+		}
+	}
+	for id, r := range s.users {
+		if now.After(r.expiresAt) {
+			delete(s.users, id)
+		}
+	}
+}
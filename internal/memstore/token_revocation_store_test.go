@@ -0,0 +1,64 @@
+package memstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/memstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenRevocationStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("token", func(t *testing.T) {
+		store := memstore.NewTokenRevocationStore()
+
+		err := store.RevokeToken(ctx, "jti-1", now.Add(time.Hour))
+		assert.NoError(t, err)
+
+		revoked, err := store.IsRevoked(ctx, "jti-1", "sid-1", "user-1", now)
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+
+		revoked, err = store.IsRevoked(ctx, "jti-2", "sid-1", "user-1", now)
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+	})
+
+	t.Run("session", func(t *testing.T) {
+		store := memstore.NewTokenRevocationStore()
+
+		err := store.RevokeSessionTokens(ctx, "sid-1", now.Add(time.Hour))
+		assert.NoError(t, err)
+
+		revoked, err := store.IsRevoked(ctx, "jti-1", "sid-1", "user-1", now)
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+
+		revoked, err = store.IsRevoked(ctx, "jti-2", "sid-2", "user-1", now)
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+	})
+
+	t.Run("user", func(t *testing.T) {
+		store := memstore.NewTokenRevocationStore()
+
+		err := store.RevokeUserTokens(ctx, "user-1", now, now.Add(time.Hour))
+		assert.NoError(t, err)
+
+		revoked, err := store.IsRevoked(ctx, "jti-1", "sid-1", "user-1", now.Add(-time.Minute))
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+
+		revoked, err = store.IsRevoked(ctx, "jti-2", "sid-1", "user-1", now.Add(time.Minute))
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+
+		revoked, err = store.IsRevoked(ctx, "jti-3", "sid-2", "user-2", now.Add(-time.Minute))
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+	})
+}
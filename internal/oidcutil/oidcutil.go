@@ -0,0 +1,305 @@
+// Package oidcutil implements the relying party side of the OpenID Connect
+// authorization code flow with PKCE.
+package oidcutil
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	jwt "github.com/golang-jwt/jwt/v4"
+)
+
+// keyRefreshInterval limits how often an unknown kid makes us fetch the
+// provider's keys again.
+const keyRefreshInterval = time.Minute
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type idTokenClaims struct {
+	Nonce           string `json:"nonce"`
+	Email           string `json:"email"`
+	EmailVerified   bool   `json:"email_verified"`
+	Name            string `json:"name"`
+	AuthorizedParty string `json:"azp"`
+	jwt.RegisteredClaims
+}
+
+// Provider is an OpenID Connect provider. Its endpoints are discovered on
+// first use.
+type Provider struct {
+	name         string
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	client       *http.Client
+
+	mu            sync.Mutex
+	config        *discovery
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+func NewProvider(name string, issuer string, clientID string, clientSecret string, redirectURL string) *Provider {
+	return &Provider{
+		name:         name,
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *Provider) Name() string {
+	return p.name
+}
+
+func (p *Provider) AuthCodeURL(c context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	config, err := p.discover(c)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.clientID},
+		"redirect_uri":          {p.redirectURL},
+		"scope":                 {"openid email profile"},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(config.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return config.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+func (p *Provider) Exchange(c context.Context, code string, codeVerifier string, nonce string) (domain.OIDCIdentity, error) {
+	config, err := p.discover(c)
+	if err != nil {
+		return domain.OIDCIdentity{}, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.redirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(c, http.MethodPost, config.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return domain.OIDCIdentity{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.clientID), url.QueryEscape(p.clientSecret))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return domain.OIDCIdentity{}, err
+	}
+	defer resp.Body.Close()
+
+	var token tokenResponse
+	err = json.NewDecoder(resp.Body).Decode(&token)
+	if err != nil && resp.StatusCode == http.StatusOK {
+		return domain.OIDCIdentity{}, err
+	}
+	if resp.StatusCode != http.StatusOK || token.Error != "" {
+		return domain.OIDCIdentity{}, fmt.Errorf("%w: %s %s", domain.ErrOIDCProviderRejected, token.Error, token.ErrorDescription)
+	}
+
+	return p.verify(c, token.IDToken, nonce)
+}
+
+func (p *Provider) verify(c context.Context, idToken string, nonce string) (domain.OIDCIdentity, error) {
+	claims := &idTokenClaims{}
+	methods := []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.key(c, kid)
+	}, jwt.WithValidMethods(methods))
+	if err != nil {
+		return domain.OIDCIdentity{}, fmt.Errorf("%w: %v", domain.ErrInvalidOIDCIdentity, err)
+	}
+
+	switch {
+	case claims.Issuer != p.issuer:
+		return domain.OIDCIdentity{}, fmt.Errorf("%w: unexpected issuer", domain.ErrInvalidOIDCIdentity)
+	case !claims.VerifyAudience(p.clientID, true):
+		return domain.OIDCIdentity{}, fmt.Errorf("%w: unexpected audience", domain.ErrInvalidOIDCIdentity)
+	case len(claims.Audience) > 1 && claims.AuthorizedParty != p.clientID:
+		return domain.OIDCIdentity{}, fmt.Errorf("%w: unexpected authorized party", domain.ErrInvalidOIDCIdentity)
+	case claims.ExpiresAt == nil:
+		return domain.OIDCIdentity{}, fmt.Errorf("%w: missing expiry", domain.ErrInvalidOIDCIdentity)
+	case claims.Subject == "":
+		return domain.OIDCIdentity{}, fmt.Errorf("%w: missing subject", domain.ErrInvalidOIDCIdentity)
+	case claims.Nonce != nonce:
+		return domain.OIDCIdentity{}, fmt.Errorf("%w: nonce mismatch", domain.ErrInvalidOIDCIdentity)
+	}
+
+	return domain.OIDCIdentity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+func (p *Provider) discover(c context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.config != nil {
+		return p.config, nil
+	}
+
+	var config discovery
+	err := p.getJSON(c, p.issuer+"/.well-known/openid-configuration", &config)
+	if err != nil {
+		return nil, err
+	}
+	if config.Issuer != p.issuer {
+		return nil, fmt.Errorf("oidc: discovery returned issuer %q, expected %q", config.Issuer, p.issuer)
+	}
+
+	p.config = &config
+	return p.config, nil
+}
+
+// key returns the provider key with the given kid, fetching the key set again
+// if the provider has rotated its keys.
+func (p *Provider) key(c context.Context, kid string) (crypto.PublicKey, error) {
+	config, err := p.discover(c)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	if time.Since(p.keysFetchedAt) < keyRefreshInterval {
+		return nil, errors.New("oidc: unknown signing key")
+	}
+
+	var set domain.JSONWebKeySet
+	err = p.getJSON(c, config.JWKSURI, &set)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseJWK(jwk)
+		if err != nil {
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, errors.New("oidc: unknown signing key")
+}
+
+func (p *Provider) getJSON(c context.Context, u string, v interface{}) error {
+	req, err := http.NewRequestWithContext(c, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc: GET %s: %s", u, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func parseJWK(jwk domain.JSONWebKey) (crypto.PublicKey, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("oidc: unsupported curve %q", jwk.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, fmt.Errorf("oidc: unsupported key type %q", jwk.KeyType)
+}
+
+// CodeChallenge derives the S256 PKCE challenge of a code verifier.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
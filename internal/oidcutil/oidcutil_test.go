@@ -0,0 +1,171 @@
+package oidcutil_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/oidcutil"
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	clientID     = "client-id"
+	clientSecret = "client-secret"
+	redirectURL  = "http://localhost:8080/login/oidc/callback"
+)
+
+// issuer is a minimal OpenID Connect provider. It issues one code per
+// authorization request and checks the PKCE verifier on redemption.
+type issuer struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+	// claims are changed by the tests to produce invalid ID tokens.
+	claims jwt.MapClaims
+
+	challenge string
+	nonce     string
+}
+
+func newIssuer(t *testing.T) *issuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	is := &issuer{key: key, claims: jwt.MapClaims{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 is.URL,
+			"authorization_endpoint": is.URL + "/authorize",
+			"token_endpoint":         is.URL + "/token",
+			"jwks_uri":               is.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(domain.JSONWebKeySet{Keys: []domain.JSONWebKey{{
+			KeyType: "RSA",
+			KeyID:   "test",
+			Use:     "sig",
+			N:       base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if id != clientID || secret != clientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+			return
+		}
+		if r.PostFormValue("code") != "code" || oidcutil.CodeChallenge(r.PostFormValue("code_verifier")) != is.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		claims := jwt.MapClaims{
+			"iss":            is.URL,
+			"sub":            "subject",
+			"aud":            clientID,
+			"exp":            time.Now().Add(time.Minute).Unix(),
+			"iat":            time.Now().Unix(),
+			"nonce":          is.nonce,
+			"email":          "test@example.com",
+			"email_verified": true,
+			"name":           "Test Name",
+		}
+		for k, v := range is.claims {
+			claims[k] = v
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test"
+		idToken, err := token.SignedString(key)
+		assert.NoError(t, err)
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": idToken, "token_type": "Bearer"})
+	})
+	is.Server = httptest.NewServer(mux)
+	t.Cleanup(is.Close)
+	return is
+}
+
+// authorize follows the authorization URL the way a user agent would and
+// records what the provider would remember about the request.
+func (is *issuer) authorize(t *testing.T, authURL string) {
+	u, err := url.Parse(authURL)
+	assert.NoError(t, err)
+	assert.Equal(t, is.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+
+	query := u.Query()
+	assert.Equal(t, clientID, query.Get("client_id"))
+	assert.Equal(t, redirectURL, query.Get("redirect_uri"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	is.challenge = query.Get("code_challenge")
+	is.nonce = query.Get("nonce")
+}
+
+func TestProvider(t *testing.T) {
+	verifier := "a-code-verifier-that-is-long-enough-for-pkce-checks"
+
+	begin := func(t *testing.T, claims jwt.MapClaims) (*issuer, *oidcutil.Provider) {
+		is := newIssuer(t)
+		is.claims = claims
+		provider := oidcutil.NewProvider("test", is.URL, clientID, clientSecret, redirectURL)
+
+		authURL, err := provider.AuthCodeURL(context.Background(), "state", "nonce", oidcutil.CodeChallenge(verifier))
+		assert.NoError(t, err)
+		is.authorize(t, authURL)
+		return is, provider
+	}
+
+	t.Run("success", func(t *testing.T) {
+		_, provider := begin(t, nil)
+
+		identity, err := provider.Exchange(context.Background(), "code", verifier, "nonce")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.OIDCIdentity{
+			Subject:       "subject",
+			Email:         "test@example.com",
+			EmailVerified: true,
+			Name:          "Test Name",
+		}, identity)
+	})
+
+	t.Run("wrong verifier", func(t *testing.T) {
+		_, provider := begin(t, nil)
+
+		_, err := provider.Exchange(context.Background(), "code", "another-verifier", "nonce")
+		assert.ErrorIs(t, err, domain.ErrOIDCProviderRejected)
+	})
+
+	t.Run("wrong nonce", func(t *testing.T) {
+		_, provider := begin(t, nil)
+
+		_, err := provider.Exchange(context.Background(), "code", verifier, "another-nonce")
+		assert.ErrorIs(t, err, domain.ErrInvalidOIDCIdentity)
+	})
+
+	t.Run("invalid id token", func(t *testing.T) {
+		for name, claims := range map[string]jwt.MapClaims{
+			"audience": {"aud": "another-client"},
+			"issuer":   {"iss": "https://attacker.example.com"},
+			"expired":  {"exp": time.Now().Add(-time.Minute).Unix()},
+			"subject":  {"sub": ""},
+		} {
+			_, provider := begin(t, claims)
+
+			_, err := provider.Exchange(context.Background(), "code", verifier, "nonce")
+			assert.ErrorIs(t, err, domain.ErrInvalidOIDCIdentity, name)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package passwordutil
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const hashPrefixLength = 5
+
+// BreachList is a local copy of breached password hashes, kept by hash
+// prefix the same way as the k-anonymity range API of Have I Been Pwned, so
+// that a lookup only ever touches the suffixes that share a prefix.
+type BreachList struct {
+	suffixes map[string]map[string]struct{}
+}
+
+// LoadBreachList reads a file with one uppercase or lowercase SHA-1 hash per
+// line, optionally followed by ":" and a count as in the downloadable Have I
+// Been Pwned lists. Empty lines and lines starting with "#" are skipped.
+func LoadBreachList(path string) (*BreachList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	list := &BreachList{suffixes: make(map[string]map[string]struct{})}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		hash, _, _ := strings.Cut(text, ":")
+		hash = strings.ToUpper(hash)
+		if len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("passwordutil: %s:%d: not a SHA-1 hash", path, line)
+		}
+		_, err = hex.DecodeString(hash)
+		if err != nil {
+			return nil, fmt.Errorf("passwordutil: %s:%d: not a SHA-1 hash", path, line)
+		}
+		list.add(hash)
+	}
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (l *BreachList) add(hash string) {
+	prefix, suffix := hash[:hashPrefixLength], hash[hashPrefixLength:]
+	if l.suffixes[prefix] == nil {
+		l.suffixes[prefix] = make(map[string]struct{})
+	}
+	l.suffixes[prefix][suffix] = struct{}{}
+}
+
+func (l *BreachList) IsBreached(c context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	_, ok := l.suffixes[hash[:hashPrefixLength]][hash[hashPrefixLength:]]
+	return ok, nil
+}
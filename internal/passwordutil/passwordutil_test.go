@@ -0,0 +1,61 @@
+package passwordutil_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/passwordutil"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestArgon2idHasher(t *testing.T) {
+	params := passwordutil.Argon2idParams{Memory: 64, Iterations: 2, Parallelism: 1}
+	hasher := passwordutil.NewArgon2idHasher(params)
+
+	t.Run("hash and verify", func(t *testing.T) {
+		hash, err := hasher.Hash("password")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=2,p=1$"))
+
+		match, needsRehash := hasher.Verify(hash, "password")
+		assert.True(t, match)
+		assert.False(t, needsRehash)
+
+		match, _ = hasher.Verify(hash, "wrong")
+		assert.False(t, match)
+
+		other, err := hasher.Hash("password")
+		assert.NoError(t, err)
+		assert.NotEqual(t, hash, other)
+	})
+
+	t.Run("bcrypt", func(t *testing.T) {
+		hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+		assert.NoError(t, err)
+
+		match, needsRehash := hasher.Verify(string(hash), "password")
+		assert.True(t, match)
+		assert.True(t, needsRehash)
+
+		match, needsRehash = hasher.Verify(string(hash), "wrong")
+		assert.False(t, match)
+		assert.False(t, needsRehash)
+	})
+
+	t.Run("weaker parameters", func(t *testing.T) {
+		hash, err := passwordutil.NewArgon2idHasher(passwordutil.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1}).Hash("password")
+		assert.NoError(t, err)
+
+		match, needsRehash := hasher.Verify(hash, "password")
+		assert.True(t, match)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("unparseable hash", func(t *testing.T) {
+		for _, hash := range []string{"", "password", "$argon2id$v=19$m=64,t=0,p=1$c2FsdA$a2V5"} {
+			match, _ := hasher.Verify(hash, "password")
+			assert.False(t, match, hash)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package passwordutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+)
+
+// BcryptMaxBytes is the length after which bcrypt ignores the rest of a
+// password.
+const BcryptMaxBytes = 72
+
+// minIdentifierLength keeps short parts of an email or name, such as initials,
+// from rejecting most passwords.
+const minIdentifierLength = 3
+
+type PolicyParams struct {
+	// MinLength is counted in characters.
+	MinLength int
+	// MaxBytes is counted in bytes of UTF-8.
+	MaxBytes int
+}
+
+type policy struct {
+	params   PolicyParams
+	breaches domain.BreachedPasswordChecker
+}
+
+// NewPolicy returns a password policy. Passwords are not screened for
+// breaches when breaches is nil.
+func NewPolicy(params PolicyParams, breaches domain.BreachedPasswordChecker) domain.PasswordPolicy {
+	return &policy{params: params, breaches: breaches}
+}
+
+func (p *policy) Check(c context.Context, password string, user *domain.User) error {
+	var violations []domain.FieldError
+	violate := func(code string, message string) {
+		violations = append(violations, domain.FieldError{Field: "password", Code: code, Message: message})
+	}
+
+	if utf8.RuneCountInString(password) < p.params.MinLength {
+		violate(domain.PasswordViolationTooShort, fmt.Sprintf("Password must be at least %d characters long", p.params.MinLength))
+	}
+	if p.params.MaxBytes > 0 && len(password) > p.params.MaxBytes {
+		violate(domain.PasswordViolationTooLong, fmt.Sprintf("Password must be at most %d bytes long", p.params.MaxBytes))
+	}
+
+	if user != nil {
+		lower := strings.ToLower(password)
+		if containsAny(lower, emailIdentifiers(user.Email)) {
+			violate(domain.PasswordViolationContainsEmail, "Password must not contain your email")
+		}
+		if containsAny(lower, nameIdentifiers(user.Name)) {
+			violate(domain.PasswordViolationContainsName, "Password must not contain your name")
+		}
+	}
+
+	if p.breaches != nil {
+		breached, err := p.breaches.IsBreached(c, password)
+		if err != nil {
+			return err
+		}
+		if breached {
+			violate(domain.PasswordViolationBreached, "Password has appeared in a data breach, choose another one")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &domain.PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+func emailIdentifiers(email string) []string {
+	email = strings.ToLower(email)
+	local, _, _ := strings.Cut(email, "@")
+	return []string{email, local}
+}
+
+func nameIdentifiers(name string) []string {
+	fields := strings.Fields(strings.ToLower(name))
+	return append(fields, strings.Join(fields, ""))
+}
+
+func containsAny(password string, identifiers []string) bool {
+	for _, identifier := range identifiers {
+		if utf8.RuneCountInString(identifier) >= minIdentifierLength && strings.Contains(password, identifier) {
+			return true
+		}
+	}
+	return false
+}
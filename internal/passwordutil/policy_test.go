@@ -0,0 +1,90 @@
+package passwordutil_test
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/passwordutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeBreachList(t *testing.T, lines ...string) string {
+	path := filepath.Join(t.TempDir(), "breaches.txt")
+	err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600)
+	assert.NoError(t, err)
+	return path
+}
+
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return hex.EncodeToString(sum[:])
+}
+
+func violations(t *testing.T, err error) []string {
+	var rejected *domain.PasswordPolicyError
+	if !errors.As(err, &rejected) {
+		t.Fatalf("expected a password policy error, got %v", err)
+	}
+	assert.ErrorIs(t, err, domain.ErrPasswordRejected)
+
+	var codes []string
+	for _, violation := range rejected.Violations {
+		assert.Equal(t, "password", violation.Field)
+		codes = append(codes, violation.Code)
+	}
+	return codes
+}
+
+func TestPolicy(t *testing.T) {
+	breaches, err := passwordutil.LoadBreachList(writeBreachList(t,
+		"# breached passwords",
+		strings.ToUpper(sha1Hex("password123")),
+		sha1Hex("letmein12345")+":42",
+	))
+	assert.NoError(t, err)
+
+	policy := passwordutil.NewPolicy(passwordutil.PolicyParams{MinLength: 10, MaxBytes: passwordutil.BcryptMaxBytes}, breaches)
+	user := &domain.User{Name: "Jo Tester", Email: "jo.doe@example.com"}
+
+	t.Run("accepted", func(t *testing.T) {
+		assert.NoError(t, policy.Check(context.Background(), "correct horse battery", user))
+	})
+
+	t.Run("length", func(t *testing.T) {
+		assert.Equal(t, []string{domain.PasswordViolationTooShort}, violations(t, policy.Check(context.Background(), "short", nil)))
+		assert.Equal(t, []string{domain.PasswordViolationTooLong}, violations(t, policy.Check(context.Background(), strings.Repeat("a", 73), nil)))
+		// Characters are counted for the minimum and bytes for the maximum.
+		assert.NoError(t, policy.Check(context.Background(), strings.Repeat("é", 10), nil))
+		assert.Equal(t, []string{domain.PasswordViolationTooLong}, violations(t, policy.Check(context.Background(), strings.Repeat("é", 37), nil)))
+	})
+
+	t.Run("email and name", func(t *testing.T) {
+		assert.Equal(t, []string{domain.PasswordViolationContainsEmail}, violations(t, policy.Check(context.Background(), "my JO.DOE account", user)))
+		assert.Equal(t, []string{domain.PasswordViolationContainsName}, violations(t, policy.Check(context.Background(), "tester forever", user)))
+		// Short parts such as "jo" are not matched.
+		assert.NoError(t, policy.Check(context.Background(), "jogging every day", user))
+		assert.NoError(t, policy.Check(context.Background(), "tester forever", nil))
+	})
+
+	t.Run("breached", func(t *testing.T) {
+		assert.Equal(t, []string{domain.PasswordViolationBreached}, violations(t, policy.Check(context.Background(), "password123", nil)))
+		assert.Equal(t, []string{domain.PasswordViolationBreached}, violations(t, policy.Check(context.Background(), "letmein12345", nil)))
+	})
+
+	t.Run("several violations", func(t *testing.T) {
+		codes := violations(t, policy.Check(context.Background(), "tester", user))
+		assert.Equal(t, []string{domain.PasswordViolationTooShort, domain.PasswordViolationContainsName}, codes)
+	})
+
+	t.Run("invalid breach list", func(t *testing.T) {
+		_, err := passwordutil.LoadBreachList(writeBreachList(t, "not a hash"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,89 @@
+// Package storageutil provides the adapters of domain.FileStorage.
+package storageutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+)
+
+type localStorage struct {
+	dir     string
+	urlPath string
+}
+
+// NewLocalStorage keeps files in a directory that the server exposes under
+// urlPath.
+func NewLocalStorage(dir string, urlPath string) domain.FileStorage {
+	return &localStorage{dir: dir, urlPath: strings.TrimSuffix(urlPath, "/")}
+}
+
+func (s *localStorage) Save(c context.Context, key string, contentType string, content io.Reader) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(name), 0o755)
+	if err != nil {
+		return err
+	}
+
+	// The file is written under a temporary name first so that it is never
+	// served half written.
+	file, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), name)
+}
+
+func (s *localStorage) Open(c context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(name)
+}
+
+func (s *localStorage) Delete(c context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(name)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *localStorage) URL(key string) string {
+	return s.urlPath + "/" + key
+}
+
+// path rejects keys that would leave the storage directory.
+func (s *localStorage) path(key string) (string, error) {
+	if key == "" || path.IsAbs(key) || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return "", fmt.Errorf("storageutil: invalid key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
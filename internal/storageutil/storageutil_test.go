@@ -0,0 +1,39 @@
+package storageutil_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/storageutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	dir := t.TempDir()
+	storage := storageutil.NewLocalStorage(dir, "/uploads/")
+
+	t.Run("save and delete", func(t *testing.T) {
+		err := storage.Save(context.Background(), "avatars/a.jpg", "image/jpeg", strings.NewReader("content"))
+		assert.NoError(t, err)
+		assert.Equal(t, "/uploads/avatars/a.jpg", storage.URL("avatars/a.jpg"))
+
+		content, err := os.ReadFile(filepath.Join(dir, "avatars", "a.jpg"))
+		assert.NoError(t, err)
+		assert.Equal(t, "content", string(content))
+
+		assert.NoError(t, storage.Delete(context.Background(), "avatars/a.jpg"))
+		assert.NoError(t, storage.Delete(context.Background(), "avatars/a.jpg"))
+		_, err = os.Stat(filepath.Join(dir, "avatars", "a.jpg"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("keys outside the directory", func(t *testing.T) {
+		for _, key := range []string{"", "..", "../a.jpg", "avatars/../../a.jpg", "/etc/passwd", "avatars//a.jpg"} {
+			err := storage.Save(context.Background(), key, "image/jpeg", strings.NewReader("content"))
+			assert.Error(t, err, key)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package tokenutil_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func writePEM(t *testing.T, name string, blockType string, der []byte) string {
+	file := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+	assert.NoError(t, err)
+	return file
+}
+
+func TestKeySet(t *testing.T) {
+	user := &domain.User{ID: primitive.NewObjectID(), Name: "Test Name"}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	rsaFile := writePEM(t, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(edPrivate)
+	assert.NoError(t, err)
+	edFile := writePEM(t, "ed25519.pem", "PRIVATE KEY", der)
+	der, err = x509.MarshalPKIXPublicKey(edPublic)
+	assert.NoError(t, err)
+	edPublicFile := writePEM(t, "ed25519.pub.pem", "PUBLIC KEY", der)
+
+	t.Run("rotation", func(t *testing.T) {
+		oldKeys, err := tokenutil.LoadKeySet(rsaFile, nil)
+		assert.NoError(t, err)
+		newKeys, err := tokenutil.LoadKeySet(edFile, []string{rsaFile})
+		assert.NoError(t, err)
+
+		oldManager := tokenutil.NewTokenManager("issuer", "audience", 0, oldKeys)
+		newManager := tokenutil.NewTokenManager("issuer", "audience", 0, newKeys)
+
+		oldToken, err := oldManager.CreateAccessToken(user, "sid", "", 1)
+		assert.NoError(t, err)
+		newToken, err := newManager.CreateAccessToken(user, "sid", "", 1)
+		assert.NoError(t, err)
+
+		_, err = newManager.ValidateAccessToken(oldToken, "")
+		assert.NoError(t, err)
+		_, err = newManager.ValidateAccessToken(newToken, "")
+		assert.NoError(t, err)
+		_, err = oldManager.ValidateAccessToken(newToken, "")
+		assert.ErrorIs(t, err, tokenutil.ErrInvalidToken)
+
+		jwks := newManager.JWKS()
+		assert.Len(t, jwks.Keys, 2)
+		assert.Equal(t, "OKP", jwks.Keys[0].KeyType)
+		assert.Equal(t, "EdDSA", jwks.Keys[0].Algorithm)
+		assert.Equal(t, "RSA", jwks.Keys[1].KeyType)
+		assert.Equal(t, "RS256", jwks.Keys[1].Algorithm)
+		assert.Equal(t, "AQAB", jwks.Keys[1].E)
+	})
+
+	t.Run("verification only key", func(t *testing.T) {
+		signer, err := tokenutil.LoadKeySet(edFile, nil)
+		assert.NoError(t, err)
+		verifier, err := tokenutil.LoadKeySet(rsaFile, []string{edPublicFile})
+		assert.NoError(t, err)
+
+		token, err := tokenutil.NewTokenManager("issuer", "audience", 0, signer).CreateAccessToken(user, "sid", "", 1)
+		assert.NoError(t, err)
+
+		_, err = tokenutil.NewTokenManager("issuer", "audience", 0, verifier).ValidateAccessToken(token, "")
+		assert.NoError(t, err)
+		assert.Equal(t, signer.JWKS().Keys[0].KeyID, verifier.JWKS().Keys[1].KeyID)
+
+		_, err = tokenutil.LoadKeySet(edPublicFile, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("hmac token rejected", func(t *testing.T) {
+		keys, err := tokenutil.LoadKeySet(rsaFile, nil)
+		assert.NoError(t, err)
+
+		token, err := tokenutil.NewTokenManager("issuer", "audience", 0, nil).CreateAccessToken(user, "sid", secret, 1)
+		assert.NoError(t, err)
+
+		_, err = tokenutil.NewTokenManager("issuer", "audience", 0, keys).ValidateAccessToken(token, secret)
+		assert.ErrorIs(t, err, tokenutil.ErrInvalidToken)
+	})
+
+	t.Run("thumbprint", func(t *testing.T) {
+		// RFC 7638 section 3.1.
+		n := "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+		modulus, err := base64.RawURLEncoding.DecodeString(n)
+		assert.NoError(t, err)
+
+		key, err := tokenutil.NewKey(&rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: 65537})
+		assert.NoError(t, err)
+		assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", key.ID)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		_, err := tokenutil.LoadKeySet(filepath.Join(t.TempDir(), "missing.pem"), nil)
+		assert.Error(t, err)
+
+		file := writePEM(t, "cert.pem", "CERTIFICATE", []byte("x"))
+		_, err = tokenutil.LoadKeySet(file, nil)
+		assert.True(t, err != nil && strings.Contains(err.Error(), "unexpected PEM block"))
+	})
+}
@@ -1,21 +1,84 @@
 package tokenutil
 
 import (
-	"fmt"
+	"errors"
 	"time"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	jwt "github.com/golang-jwt/jwt/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-func CreateAccessToken(user *domain.User, secret string, expiry int) (accessToken string, err error) {
-	exp := time.Now().Add(time.Hour * time.Duration(expiry)).Unix()
+const (
+	TokenTypeAccess    = "access"
+	TokenTypeRefresh   = "refresh"
+	TokenTypeChallenge = "2fa_challenge"
+)
+
+var (
+	ErrInvalidToken = errors.New("Invalid token")
+	ErrTokenExpired = errors.New("Token is expired")
+)
+
+// TokenManager issues and validates the tokens of this service. Every token
+// carries the configured issuer and audience and a typ claim, so an access
+// token is never accepted where a refresh token is expected and vice versa.
+//
+// With a key set, access tokens are signed asymmetrically so that other
+// services can verify them from the published JWKS; the secret arguments are
+// then ignored for access tokens. Refresh tokens are only ever read by this
+// service and stay HMAC signed.
+type TokenManager struct {
+	issuer   string
+	audience string
+	leeway   time.Duration
+	keys     *KeySet
+	now      func() time.Time
+}
+
+func NewTokenManager(issuer string, audience string, leeway time.Duration, keys *KeySet) *TokenManager {
+	return &TokenManager{
+		issuer:   issuer,
+		audience: audience,
+		leeway:   leeway,
+		keys:     keys,
+		now:      time.Now,
+	}
+}
+
+// JWKS returns the public keys that verify access tokens. It is empty when
+// access tokens are HMAC signed.
+func (tm *TokenManager) JWKS() domain.JSONWebKeySet {
+	if tm.keys == nil {
+		return domain.JSONWebKeySet{Keys: []domain.JSONWebKey{}}
+	}
+	return tm.keys.JWKS()
+}
+
+func (tm *TokenManager) standardClaims(tokenID string, expiry int) jwt.StandardClaims {
+	now := tm.now()
+	return jwt.StandardClaims{
+		Id:        tokenID,
+		Issuer:    tm.issuer,
+		Audience:  tm.audience,
+		IssuedAt:  now.Unix(),
+		NotBefore: now.Unix(),
+		ExpiresAt: now.Add(time.Hour * time.Duration(expiry)).Unix(),
+	}
+}
+
+func (tm *TokenManager) CreateAccessToken(user *domain.User, sessionID string, secret string, expiry int) (accessToken string, err error) {
 	claims := &domain.JwtCustomClaims{
-		Name: user.Name,
-		ID:   user.ID.Hex(),
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: exp,
-		},
+		Name:           user.Name,
+		ID:             user.ID.Hex(),
+		Type:           TokenTypeAccess,
+		EmailVerified:  user.EmailVerifiedAt != nil,
+		SessionID:      sessionID,
+		SystemRole:     user.SystemRole,
+		StandardClaims: tm.standardClaims(primitive.NewObjectID().Hex(), expiry),
+	}
+	if tm.keys != nil {
+		return tm.keys.sign(claims)
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	t, err := token.SignedString([]byte(secret))
@@ -25,12 +88,12 @@ func CreateAccessToken(user *domain.User, secret string, expiry int) (accessToke
 	return t, err
 }
 
-func CreateRefreshToken(user *domain.User, secret string, expiry int) (refreshToken string, err error) {
+func (tm *TokenManager) CreateRefreshToken(user *domain.User, tokenID string, familyID string, secret string, expiry int) (refreshToken string, err error) {
 	claimsRefresh := &domain.JwtCustomRefreshClaims{
-		ID: user.ID.Hex(),
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Hour * time.Duration(expiry)).Unix(),
-		},
+		ID:             user.ID.Hex(),
+		FamilyID:       familyID,
+		Type:           TokenTypeRefresh,
+		StandardClaims: tm.standardClaims(tokenID, expiry),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claimsRefresh)
 	rt, err := token.SignedString([]byte(secret))
@@ -40,36 +103,95 @@ func CreateRefreshToken(user *domain.User, secret string, expiry int) (refreshTo
 	return rt, err
 }
 
-func IsAuthorized(requestToken string, secret string) (bool, error) {
-	_, err := jwt.Parse(requestToken, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(secret), nil
-	})
+// CreateChallengeToken proves that the password step of a two-factor login
+// succeeded. Like refresh tokens it is only read by this service.
+func (tm *TokenManager) CreateChallengeToken(user *domain.User, secret string, expiryMinute int) (string, error) {
+	claims := &domain.JwtChallengeClaims{
+		ID:             user.ID.Hex(),
+		Type:           TokenTypeChallenge,
+		StandardClaims: tm.standardClaims(primitive.NewObjectID().Hex(), 0),
+	}
+	claims.ExpiresAt = tm.now().Add(time.Minute * time.Duration(expiryMinute)).Unix()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
+func (tm *TokenManager) ValidateChallengeToken(requestToken string, secret string) (*domain.JwtChallengeClaims, error) {
+	claims := &domain.JwtChallengeClaims{}
+	methods, keyFunc := hmacKey(secret)
+	err := tm.parse(requestToken, methods, keyFunc, claims, &claims.StandardClaims)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	if claims.Type != TokenTypeChallenge || claims.ID == "" {
+		return nil, ErrInvalidToken
 	}
-	return true, nil
+
+	return claims, nil
 }
 
-func ExtractIDFromToken(requestToken string, secret string) (string, error) {
-	token, err := jwt.Parse(requestToken, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(secret), nil
-	})
+func (tm *TokenManager) ValidateAccessToken(requestToken string, secret string) (*domain.JwtCustomClaims, error) {
+	claims := &domain.JwtCustomClaims{}
+	methods, keyFunc := hmacKey(secret)
+	if tm.keys != nil {
+		methods, keyFunc = tm.keys.methods(), tm.keys.keyFunc
+	}
+	err := tm.parse(requestToken, methods, keyFunc, claims, &claims.StandardClaims)
+	if err != nil {
+		return nil, err
+	}
 
+	if claims.Type != TokenTypeAccess || claims.ID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+func (tm *TokenManager) ValidateRefreshToken(requestToken string, secret string) (*domain.JwtCustomRefreshClaims, error) {
+	claims := &domain.JwtCustomRefreshClaims{}
+	methods, keyFunc := hmacKey(secret)
+	err := tm.parse(requestToken, methods, keyFunc, claims, &claims.StandardClaims)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
+	if claims.Type != TokenTypeRefresh || claims.ID == "" || claims.FamilyID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
 
-	if !ok && !token.Valid {
-		return "", fmt.Errorf("Invalid Token")
+// parse verifies the signature and the registered claims. The jwt library's
+// own claim validation has no leeway, so it is skipped and done here instead.
+func (tm *TokenManager) parse(requestToken string, methods []string, keyFunc jwt.Keyfunc, claims jwt.Claims, standard *jwt.StandardClaims) error {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(methods),
+		jwt.WithoutClaimsValidation(),
+	)
+	_, err := parser.ParseWithClaims(requestToken, claims, keyFunc)
+	if err != nil {
+		return ErrInvalidToken
 	}
 
-	return claims["id"].(string), nil
+	now := tm.now()
+	if !standard.VerifyExpiresAt(now.Add(-tm.leeway).Unix(), true) {
+		return ErrTokenExpired
+	}
+	if !standard.VerifyNotBefore(now.Add(tm.leeway).Unix(), false) ||
+		!standard.VerifyIssuer(tm.issuer, true) ||
+		!standard.VerifyAudience(tm.audience, true) ||
+		standard.Id == "" {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+func hmacKey(secret string) ([]string, jwt.Keyfunc) {
+	return []string{jwt.SigningMethodHS256.Alg()}, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}
 }
@@ -0,0 +1,111 @@
+package tokenutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const secret = "secret"
+
+func sign(t *testing.T, claims jwt.Claims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	assert.NoError(t, err)
+	return token
+}
+
+func TestTokenManager(t *testing.T) {
+	user := &domain.User{ID: primitive.NewObjectID(), Name: "Test Name"}
+	tm := tokenutil.NewTokenManager("issuer", "audience", time.Minute, nil)
+
+	t.Run("access token", func(t *testing.T) {
+		token, err := tm.CreateAccessToken(user, "sid", secret, 1)
+		assert.NoError(t, err)
+
+		claims, err := tm.ValidateAccessToken(token, secret)
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID.Hex(), claims.ID)
+		assert.Equal(t, "sid", claims.SessionID)
+		assert.NotEmpty(t, claims.Id)
+
+		_, err = tm.ValidateRefreshToken(token, secret)
+		assert.ErrorIs(t, err, tokenutil.ErrInvalidToken)
+	})
+
+	t.Run("refresh token", func(t *testing.T) {
+		token, err := tm.CreateRefreshToken(user, "token-id", "family-id", secret, 1)
+		assert.NoError(t, err)
+
+		claims, err := tm.ValidateRefreshToken(token, secret)
+		assert.NoError(t, err)
+		assert.Equal(t, "family-id", claims.FamilyID)
+
+		_, err = tm.ValidateAccessToken(token, secret)
+		assert.ErrorIs(t, err, tokenutil.ErrInvalidToken)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		token, err := tm.CreateAccessToken(user, "sid", secret, 1)
+		assert.NoError(t, err)
+
+		_, err = tm.ValidateAccessToken(token, "other")
+		assert.ErrorIs(t, err, tokenutil.ErrInvalidToken)
+	})
+
+	t.Run("issuer and audience", func(t *testing.T) {
+		token, err := tokenutil.NewTokenManager("other", "audience", 0, nil).CreateAccessToken(user, "sid", secret, 1)
+		assert.NoError(t, err)
+		_, err = tm.ValidateAccessToken(token, secret)
+		assert.ErrorIs(t, err, tokenutil.ErrInvalidToken)
+
+		token, err = tokenutil.NewTokenManager("issuer", "other", 0, nil).CreateAccessToken(user, "sid", secret, 1)
+		assert.NoError(t, err)
+		_, err = tm.ValidateAccessToken(token, secret)
+		assert.ErrorIs(t, err, tokenutil.ErrInvalidToken)
+	})
+
+	t.Run("leeway", func(t *testing.T) {
+		now := time.Now()
+		claims := func(nbf time.Time, exp time.Time) *domain.JwtCustomClaims {
+			return &domain.JwtCustomClaims{
+				ID:   user.ID.Hex(),
+				Type: tokenutil.TokenTypeAccess,
+				StandardClaims: jwt.StandardClaims{
+					Id:        "token-id",
+					Issuer:    "issuer",
+					Audience:  "audience",
+					NotBefore: nbf.Unix(),
+					ExpiresAt: exp.Unix(),
+				},
+			}
+		}
+
+		_, err := tm.ValidateAccessToken(sign(t, claims(now.Add(-time.Hour), now.Add(-30*time.Second))), secret)
+		assert.NoError(t, err)
+
+		_, err = tm.ValidateAccessToken(sign(t, claims(now.Add(-time.Hour), now.Add(-2*time.Minute))), secret)
+		assert.ErrorIs(t, err, tokenutil.ErrTokenExpired)
+
+		_, err = tm.ValidateAccessToken(sign(t, claims(now.Add(30*time.Second), now.Add(time.Hour))), secret)
+		assert.NoError(t, err)
+
+		_, err = tm.ValidateAccessToken(sign(t, claims(now.Add(2*time.Minute), now.Add(time.Hour))), secret)
+		assert.ErrorIs(t, err, tokenutil.ErrInvalidToken)
+	})
+
+	t.Run("signing method", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS512, &domain.JwtCustomClaims{
+			ID:   user.ID.Hex(),
+			Type: tokenutil.TokenTypeAccess,
+		}).SignedString([]byte(secret))
+		assert.NoError(t, err)
+
+		_, err = tm.ValidateAccessToken(token, secret)
+		assert.ErrorIs(t, err, tokenutil.ErrInvalidToken)
+	})
+}
@@ -0,0 +1,58 @@
+package totputil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/totputil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHOTP(t *testing.T) {
+	// RFC 6238 appendix B, SHA-1.
+	key := []byte("12345678901234567890")
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, v := range vectors {
+		step := totputil.Step(time.Unix(v.unix, 0))
+		assert.Equal(t, v.code, totputil.HOTP(key, uint64(step), 8))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := totputil.GenerateSecret()
+	assert.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	code, err := totputil.Code(secret, now)
+	assert.NoError(t, err)
+
+	step, ok := totputil.Validate(secret, code, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, totputil.Step(now), step)
+
+	_, ok = totputil.Validate(secret, code, now.Add(totputil.Period*time.Second), 1)
+	assert.True(t, ok)
+
+	_, ok = totputil.Validate(secret, code, now.Add(2*totputil.Period*time.Second), 1)
+	assert.False(t, ok)
+
+	_, ok = totputil.Validate(secret, "12345", now, 1)
+	assert.False(t, ok)
+}
+
+func TestURI(t *testing.T) {
+	uri := totputil.URI("Debt Helper", "test@gmail.com", "JBSWY3DPEHPK3PXP")
+
+	assert.Equal(t, "otpauth://totp/Debt%20Helper:test@gmail.com?algorithm=SHA1&digits=6&issuer=Debt+Helper&period=30&secret=JBSWY3DPEHPK3PXP", uri)
+}
@@ -0,0 +1,131 @@
+// Package walletutil splits group wallet spending and leftovers between the
+// members of a group.
+package walletutil
+
+import (
+	"math/bits"
+	"sort"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Split shares amount equally between the users. The units that do not
+// divide evenly go to the first users.
+func Split(amount int64, userIDs []primitive.ObjectID) []domain.WalletShare {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	n := int64(len(userIDs))
+	shares := make([]domain.WalletShare, len(userIDs))
+	for i, userID := range userIDs {
+		shares[i] = domain.WalletShare{UserID: userID, Amount: amount / n}
+		if int64(i) < amount%n {
+			shares[i].Amount++
+		}
+	}
+	return shares
+}
+
+// Refunds splits the leftover of a wallet in proportion to what each member
+// contributed. The units lost to rounding go to the largest remainders, so
+// the refunds add up to the leftover.
+func Refunds(entries []domain.WalletEntry, leftover int64) []domain.WalletShare {
+	if leftover <= 0 {
+		return nil
+	}
+
+	var order []primitive.ObjectID
+	contributed := make(map[primitive.ObjectID]int64)
+	var total int64
+	for _, entry := range entries {
+		if entry.Type != domain.WalletEntryContribution {
+			continue
+		}
+		if _, ok := contributed[entry.UserID]; !ok {
+			order = append(order, entry.UserID)
+		}
+		contributed[entry.UserID] += entry.Amount
+		total += entry.Amount
+	}
+	if total < leftover {
+		return nil
+	}
+
+	refunds := make([]domain.WalletShare, len(order))
+	remainders := make([]uint64, len(order))
+	var refunded int64
+	for i, userID := range order {
+		// leftover <= total, so the quotient fits and Div64 does not panic.
+		hi, lo := bits.Mul64(uint64(leftover), uint64(contributed[userID]))
+		quotient, remainder := bits.Div64(hi, lo, uint64(total))
+		refunds[i] = domain.WalletShare{UserID: userID, Amount: int64(quotient)}
+		remainders[i] = remainder
+		refunded += int64(quotient)
+	}
+
+	byRemainder := make([]int, len(order))
+	for i := range byRemainder {
+		byRemainder[i] = i
+	}
+	sort.SliceStable(byRemainder, func(a, b int) bool {
+		return remainders[byRemainder[a]] > remainders[byRemainder[b]]
+	})
+	for i := 0; refunded < leftover; i++ {
+		refunds[byRemainder[i]].Amount++
+		refunded++
+	}
+
+	shares := refunds[:0]
+	for _, refund := range refunds {
+		if refund.Amount > 0 {
+			shares = append(shares, refund)
+		}
+	}
+	return shares
+}
+
+// Summarize totals the entries of a wallet per member, in the order the
+// members first appear in.
+func Summarize(wallet *domain.GroupWallet, entries []domain.WalletEntry) domain.GroupWalletSummary {
+	summary := domain.GroupWalletSummary{
+		Wallet:  *wallet,
+		Members: []domain.WalletMemberBalance{},
+		Entries: entries,
+	}
+
+	index := make(map[primitive.ObjectID]int)
+	member := func(userID primitive.ObjectID) *domain.WalletMemberBalance {
+		i, ok := index[userID]
+		if !ok {
+			i = len(summary.Members)
+			index[userID] = i
+			summary.Members = append(summary.Members, domain.WalletMemberBalance{UserID: userID})
+		}
+		return &summary.Members[i]
+	}
+
+	for _, entry := range entries {
+		switch entry.Type {
+		case domain.WalletEntryContribution:
+			summary.Contributed += entry.Amount
+			member(entry.UserID).Contributed += entry.Amount
+		case domain.WalletEntryExpense:
+			summary.Spent += entry.Amount
+			for _, share := range entry.Shares {
+				member(share.UserID).Share += share.Amount
+			}
+		case domain.WalletEntryRefund:
+			summary.Refunded += entry.Amount
+			member(entry.UserID).Refunded += entry.Amount
+		}
+	}
+
+	for i := range summary.Members {
+		m := &summary.Members[i]
+		m.Net = m.Contributed - m.Share - m.Refunded
+	}
+
+	return summary
+}
@@ -0,0 +1,71 @@
+package walletutil_test
+
+import (
+	"testing"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/walletutil"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSplit(t *testing.T) {
+	a, b, c := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+
+	shares := walletutil.Split(100, []primitive.ObjectID{a, b, c})
+
+	assert.Equal(t, []domain.WalletShare{{UserID: a, Amount: 34}, {UserID: b, Amount: 33}, {UserID: c, Amount: 33}}, shares)
+	assert.Nil(t, walletutil.Split(100, nil))
+}
+
+func TestRefunds(t *testing.T) {
+	a, b, c := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	entries := []domain.WalletEntry{
+		{Type: domain.WalletEntryContribution, UserID: a, Amount: 100},
+		{Type: domain.WalletEntryContribution, UserID: b, Amount: 100},
+		{Type: domain.WalletEntryExpense, UserID: a, Amount: 150},
+		{Type: domain.WalletEntryContribution, UserID: c, Amount: 100},
+		{Type: domain.WalletEntryContribution, UserID: a, Amount: 100},
+	}
+
+	t.Run("pro rata", func(t *testing.T) {
+		refunds := walletutil.Refunds(entries, 250)
+
+		assert.Equal(t, []domain.WalletShare{{UserID: a, Amount: 125}, {UserID: b, Amount: 63}, {UserID: c, Amount: 62}}, refunds)
+	})
+
+	t.Run("nothing left", func(t *testing.T) {
+		assert.Empty(t, walletutil.Refunds(entries, 0))
+	})
+
+	t.Run("large amounts", func(t *testing.T) {
+		large := []domain.WalletEntry{
+			{Type: domain.WalletEntryContribution, UserID: a, Amount: 3_000_000_000_000_000},
+			{Type: domain.WalletEntryContribution, UserID: b, Amount: 1_000_000_000_000_000},
+		}
+
+		refunds := walletutil.Refunds(large, 4_000_000_000_000_000)
+
+		assert.Equal(t, int64(3_000_000_000_000_000), refunds[0].Amount)
+		assert.Equal(t, int64(1_000_000_000_000_000), refunds[1].Amount)
+	})
+}
+
+func TestSummarize(t *testing.T) {
+	a, b := primitive.NewObjectID(), primitive.NewObjectID()
+	wallet := &domain.GroupWallet{Balance: 60}
+	entries := []domain.WalletEntry{
+		{Type: domain.WalletEntryContribution, UserID: a, Amount: 100},
+		{Type: domain.WalletEntryExpense, UserID: a, Amount: 90, Shares: walletutil.Split(90, []primitive.ObjectID{a, b})},
+		{Type: domain.WalletEntryContribution, UserID: b, Amount: 50},
+	}
+
+	summary := walletutil.Summarize(wallet, entries)
+
+	assert.Equal(t, int64(150), summary.Contributed)
+	assert.Equal(t, int64(90), summary.Spent)
+	assert.Equal(t, []domain.WalletMemberBalance{
+		{UserID: a, Contributed: 100, Share: 45, Net: 55},
+		{UserID: b, Contributed: 50, Share: 45, Net: 5},
+	}, summary.Members)
+}
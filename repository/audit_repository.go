@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type auditRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+// NewAuditRepository only inserts and reads, except for removing the email of
+// an erased user.
+func NewAuditRepository(db mongo.Database, collection string) domain.AuditRepository {
+	return &auditRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (ar *auditRepository) Insert(c context.Context, events []domain.AuditEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	collection := ar.database.Collection(ar.collection)
+
+	documents := make([]interface{}, len(events))
+	for i := range events {
+		documents[i] = events[i]
+	}
+
+	_, err := collection.InsertMany(c, documents)
+
+	return err
+}
+
+func (ar *auditRepository) Fetch(c context.Context, query *domain.AuditQuery, limit int64) ([]domain.AuditEvent, error) {
+	collection := ar.database.Collection(ar.collection)
+
+	filter := bson.M{}
+	if query.UserID != "" {
+		filter["$or"] = []bson.M{{"userID": query.UserID}, {"actorID": query.UserID}}
+	}
+	if query.Action != "" {
+		filter["action"] = query.Action
+	}
+	if query.Before != "" {
+		before, err := primitive.ObjectIDFromHex(query.Before)
+		if err != nil {
+			return nil, err
+		}
+		filter["_id"] = bson.M{"$lt": before}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetLimit(limit)
+	cursor, err := collection.Find(c, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []domain.AuditEvent
+
+	err = cursor.All(c, &events)
+	if events == nil {
+		return []domain.AuditEvent{}, err
+	}
+
+	return events, err
+}
+
+func (ar *auditRepository) AnonymizeUser(c context.Context, userID string) error {
+	collection := ar.database.Collection(ar.collection)
+
+	filter := bson.M{"$or": []bson.M{{"userID": userID}, {"actorID": userID}}}
+	_, err := collection.UpdateMany(c, filter, bson.M{"$unset": bson.M{"details.email": ""}})
+
+	return err
+}
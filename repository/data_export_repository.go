@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type dataExportRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewDataExportRepository(db mongo.Database, collection string) domain.DataExportRepository {
+	return &dataExportRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (dr *dataExportRepository) Create(c context.Context, export *domain.DataExport) error {
+	collection := dr.database.Collection(dr.collection)
+
+	_, err := collection.InsertOne(c, export)
+
+	return err
+}
+
+func (dr *dataExportRepository) GetByID(c context.Context, id string, userID string) (domain.DataExport, error) {
+	collection := dr.database.Collection(dr.collection)
+
+	var export domain.DataExport
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return export, err
+	}
+	userIDHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return export, err
+	}
+
+	err = collection.FindOne(c, bson.M{"_id": idHex, "userID": userIDHex}).Decode(&export)
+	return export, err
+}
+
+func (dr *dataExportRepository) GetUnfinishedByUserID(c context.Context, userID string) (domain.DataExport, error) {
+	collection := dr.database.Collection(dr.collection)
+
+	var export domain.DataExport
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return export, err
+	}
+
+	filter := bson.M{
+		"userID": idHex,
+		"status": bson.M{"$in": []string{domain.DataExportStatusPending, domain.DataExportStatusRunning}},
+	}
+	err = collection.FindOne(c, filter).Decode(&export)
+	return export, err
+}
+
+func (dr *dataExportRepository) FetchByUserID(c context.Context, userID string) ([]domain.DataExport, error) {
+	collection := dr.database.Collection(dr.collection)
+
+	var exports []domain.DataExport
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return exports, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := collection.Find(c, bson.M{"userID": idHex}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(c, &exports)
+	if exports == nil {
+		return []domain.DataExport{}, err
+	}
+
+	return exports, err
+}
+
+// Claim updates the export in the same step as it is found, so that two
+// workers never build the same archive.
+func (dr *dataExportRepository) Claim(c context.Context, now time.Time, staleBefore time.Time) (domain.DataExport, error) {
+	collection := dr.database.Collection(dr.collection)
+
+	filter := bson.M{"$or": []bson.M{
+		{"status": domain.DataExportStatusPending},
+		{"status": domain.DataExportStatusRunning, "claimedAt": bson.M{"$lt": staleBefore}},
+	}}
+	update := bson.M{"$set": bson.M{"status": domain.DataExportStatusRunning, "claimedAt": now}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "createdAt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var export domain.DataExport
+	err := collection.FindOneAndUpdate(c, filter, update, opts).Decode(&export)
+	return export, err
+}
+
+func (dr *dataExportRepository) Complete(c context.Context, id primitive.ObjectID, status string, fileKey string, completedAt time.Time, expiresAt time.Time) error {
+	collection := dr.database.Collection(dr.collection)
+
+	set := bson.M{"status": status, "completedAt": completedAt, "expiresAt": expiresAt}
+	if fileKey != "" {
+		set["fileKey"] = fileKey
+	}
+	result, err := collection.UpdateOne(c, bson.M{"_id": id}, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongodriver.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (dr *dataExportRepository) FetchExpired(c context.Context, now time.Time) ([]domain.DataExport, error) {
+	collection := dr.database.Collection(dr.collection)
+
+	cursor, err := collection.Find(c, bson.M{"expiresAt": bson.M{"$lte": now}})
+	if err != nil {
+		return nil, err
+	}
+
+	var exports []domain.DataExport
+
+	err = cursor.All(c, &exports)
+	if exports == nil {
+		return []domain.DataExport{}, err
+	}
+
+	return exports, err
+}
+
+func (dr *dataExportRepository) Delete(c context.Context, id primitive.ObjectID) error {
+	collection := dr.database.Collection(dr.collection)
+
+	_, err := collection.DeleteOne(c, bson.M{"_id": id})
+
+	return err
+}
+
+func (dr *dataExportRepository) DeleteAllForUser(c context.Context, userID string) error {
+	collection := dr.database.Collection(dr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.DeleteMany(c, bson.M{"userID": idHex})
+
+	return err
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type groupDebtRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewGroupDebtRepository(db mongo.Database, collection string) domain.GroupDebtRepository {
+	return &groupDebtRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (dr *groupDebtRepository) Create(c context.Context, debt *domain.GroupDebt) error {
+	collection := dr.database.Collection(dr.collection)
+
+	_, err := collection.InsertOne(c, debt)
+
+	return err
+}
+
+func (dr *groupDebtRepository) GetByID(c context.Context, groupID string, id string) (domain.GroupDebt, error) {
+	collection := dr.database.Collection(dr.collection)
+
+	var debt domain.GroupDebt
+
+	groupIDHex, err := primitive.ObjectIDFromHex(groupID)
+	if err != nil {
+		return debt, err
+	}
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return debt, err
+	}
+
+	err = collection.FindOne(c, bson.M{"_id": idHex, "groupID": groupIDHex}).Decode(&debt)
+	return debt, err
+}
+
+func (dr *groupDebtRepository) FetchByGroupID(c context.Context, groupID string) ([]domain.GroupDebt, error) {
+	idHex, err := primitive.ObjectIDFromHex(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dr.fetch(c, bson.M{"groupID": idHex})
+}
+
+func (dr *groupDebtRepository) FetchByUserID(c context.Context, userID string) ([]domain.GroupDebt, error) {
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dr.fetch(c, bson.M{"$or": []bson.M{{"debtorID": idHex}, {"creditorID": idHex}}})
+}
+
+func (dr *groupDebtRepository) FetchOverdue(c context.Context, groupID primitive.ObjectID, dueBefore time.Time) ([]domain.GroupDebt, error) {
+	return dr.fetch(c, bson.M{"groupID": groupID, "balance": bson.M{"$gt": 0}, "dueDate": bson.M{"$lt": dueBefore}})
+}
+
+func (dr *groupDebtRepository) Update(c context.Context, debt *domain.GroupDebt) error {
+	collection := dr.database.Collection(dr.collection)
+
+	filter := bson.M{"_id": debt.ID, "revision": debt.Revision}
+	update := bson.M{
+		"$set": bson.M{
+			"paid":           debt.Paid,
+			"charges":        debt.Charges,
+			"balance":        debt.Balance,
+			"chargedThrough": debt.ChargedThrough,
+			"settledAt":      debt.SettledAt,
+		},
+		"$inc": bson.M{"revision": 1},
+	}
+
+	result, err := collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrGroupDebtChanged
+	}
+
+	debt.Revision++
+	return nil
+}
+
+func (dr *groupDebtRepository) fetch(c context.Context, filter bson.M) ([]domain.GroupDebt, error) {
+	collection := dr.database.Collection(dr.collection)
+
+	var debts []domain.GroupDebt
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := collection.Find(c, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(c, &debts)
+	if debts == nil {
+		return []domain.GroupDebt{}, err
+	}
+
+	return debts, err
+}
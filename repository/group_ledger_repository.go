@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type groupLedgerRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewGroupLedgerRepository(db mongo.Database, collection string) domain.GroupLedgerRepository {
+	return &groupLedgerRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (lr *groupLedgerRepository) Create(c context.Context, entries ...domain.GroupLedgerEntry) error {
+	collection := lr.database.Collection(lr.collection)
+
+	documents := make([]interface{}, len(entries))
+	for i := range entries {
+		documents[i] = entries[i]
+	}
+
+	_, err := collection.InsertMany(c, documents)
+
+	return err
+}
+
+func (lr *groupLedgerRepository) FetchByGroupID(c context.Context, groupID string) ([]domain.GroupLedgerEntry, error) {
+	idHex, err := primitive.ObjectIDFromHex(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return lr.fetch(c, bson.M{"groupID": idHex})
+}
+
+func (lr *groupLedgerRepository) FetchByDebtID(c context.Context, debtID primitive.ObjectID) ([]domain.GroupLedgerEntry, error) {
+	return lr.fetch(c, bson.M{"debtID": debtID})
+}
+
+func (lr *groupLedgerRepository) fetch(c context.Context, filter bson.M) ([]domain.GroupLedgerEntry, error) {
+	collection := lr.database.Collection(lr.collection)
+
+	var entries []domain.GroupLedgerEntry
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := collection.Find(c, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(c, &entries)
+	if entries == nil {
+		return []domain.GroupLedgerEntry{}, err
+	}
+
+	return entries, err
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type groupMemberRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewGroupMemberRepository(db mongo.Database, collection string) domain.GroupMemberRepository {
+	return &groupMemberRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (gr *groupMemberRepository) Create(c context.Context, member *domain.GroupMember) error {
+	collection := gr.database.Collection(gr.collection)
+
+	_, err := collection.InsertOne(c, member)
+
+	return err
+}
+
+func (gr *groupMemberRepository) GetByGroupAndUser(c context.Context, groupID string, userID string) (domain.GroupMember, error) {
+	collection := gr.database.Collection(gr.collection)
+
+	var member domain.GroupMember
+
+	filter, err := groupMemberFilter(groupID, userID)
+	if err != nil {
+		return member, err
+	}
+
+	err = collection.FindOne(c, filter).Decode(&member)
+	return member, err
+}
+
+func (gr *groupMemberRepository) FetchByGroupID(c context.Context, groupID string) ([]domain.GroupMember, error) {
+	collection := gr.database.Collection(gr.collection)
+
+	var members []domain.GroupMember
+
+	idHex, err := primitive.ObjectIDFromHex(groupID)
+	if err != nil {
+		return members, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := collection.Find(c, bson.M{"groupID": idHex}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(c, &members)
+	if members == nil {
+		return []domain.GroupMember{}, err
+	}
+
+	return members, err
+}
+
+func (gr *groupMemberRepository) FetchByUserID(c context.Context, userID string) ([]domain.GroupMember, error) {
+	collection := gr.database.Collection(gr.collection)
+
+	var members []domain.GroupMember
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return members, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := collection.Find(c, bson.M{"userID": idHex}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(c, &members)
+	if members == nil {
+		return []domain.GroupMember{}, err
+	}
+
+	return members, err
+}
+
+func (gr *groupMemberRepository) UpdateRole(c context.Context, groupID string, userID string, role string) error {
+	collection := gr.database.Collection(gr.collection)
+
+	filter, err := groupMemberFilter(groupID, userID)
+	if err != nil {
+		return err
+	}
+
+	result, err := collection.UpdateOne(c, filter, bson.M{"$set": bson.M{"role": role}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongodriver.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (gr *groupMemberRepository) Delete(c context.Context, groupID string, userID string) error {
+	collection := gr.database.Collection(gr.collection)
+
+	filter, err := groupMemberFilter(groupID, userID)
+	if err != nil {
+		return err
+	}
+
+	count, err := collection.DeleteOne(c, filter)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return mongodriver.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func groupMemberFilter(groupID string, userID string) (bson.M, error) {
+	groupIDHex, err := primitive.ObjectIDFromHex(groupID)
+	if err != nil {
+		return nil, err
+	}
+	userIDHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	return bson.M{"groupID": groupIDHex, "userID": userIDHex}, nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+)
+
+type groupRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewGroupRepository(db mongo.Database, collection string) domain.GroupRepository {
+	return &groupRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (gr *groupRepository) Create(c context.Context, group *domain.Group) error {
+	collection := gr.database.Collection(gr.collection)
+
+	_, err := collection.InsertOne(c, group)
+
+	return err
+}
+
+func (gr *groupRepository) GetByID(c context.Context, id string) (domain.Group, error) {
+	collection := gr.database.Collection(gr.collection)
+
+	var group domain.Group
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return group, err
+	}
+
+	err = collection.FindOne(c, bson.M{"_id": idHex}).Decode(&group)
+	return group, err
+}
+
+func (gr *groupRepository) SetLateFee(c context.Context, id string, policy *domain.LateFeePolicy) error {
+	collection := gr.database.Collection(gr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"lateFee": policy}}
+	if policy == nil {
+		update = bson.M{"$unset": bson.M{"lateFee": ""}}
+	}
+
+	result, err := collection.UpdateOne(c, bson.M{"_id": idHex}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongodriver.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (gr *groupRepository) FetchWithLateFee(c context.Context) ([]domain.Group, error) {
+	collection := gr.database.Collection(gr.collection)
+
+	cursor, err := collection.Find(c, bson.M{"lateFee": bson.M{"$exists": true}, "closedAt": bson.M{"$exists": false}})
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []domain.Group
+
+	err = cursor.All(c, &groups)
+	if groups == nil {
+		return []domain.Group{}, err
+	}
+
+	return groups, err
+}
+
+func (gr *groupRepository) Close(c context.Context, id string) error {
+	collection := gr.database.Collection(gr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": idHex, "closedAt": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"closedAt": time.Now()}}
+
+	result, err := collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrGroupClosed
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type groupWalletRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewGroupWalletRepository(db mongo.Database, collection string) domain.GroupWalletRepository {
+	return &groupWalletRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (wr *groupWalletRepository) Create(c context.Context, wallet *domain.GroupWallet) error {
+	collection := wr.database.Collection(wr.collection)
+
+	_, err := collection.InsertOne(c, wallet)
+
+	return err
+}
+
+func (wr *groupWalletRepository) GetByGroupID(c context.Context, groupID string) (domain.GroupWallet, error) {
+	collection := wr.database.Collection(wr.collection)
+
+	var wallet domain.GroupWallet
+
+	idHex, err := primitive.ObjectIDFromHex(groupID)
+	if err != nil {
+		return wallet, err
+	}
+
+	err = collection.FindOne(c, bson.M{"groupID": idHex}).Decode(&wallet)
+	return wallet, err
+}
+
+func (wr *groupWalletRepository) AddToBalance(c context.Context, wallet *domain.GroupWallet, amount int64) error {
+	collection := wr.database.Collection(wr.collection)
+
+	// Matching on the balance the caller read keeps it from going negative
+	// and makes concurrent writes in a transaction conflict.
+	filter := bson.M{"_id": wallet.ID, "status": domain.GroupWalletStatusOpen, "balance": wallet.Balance}
+	update := bson.M{"$inc": bson.M{"balance": amount}}
+
+	result, err := collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrGroupWalletChanged
+	}
+
+	return nil
+}
+
+func (wr *groupWalletRepository) Close(c context.Context, wallet *domain.GroupWallet) error {
+	collection := wr.database.Collection(wr.collection)
+
+	filter := bson.M{"_id": wallet.ID, "status": domain.GroupWalletStatusOpen, "balance": wallet.Balance}
+	update := bson.M{"$set": bson.M{
+		"status":   domain.GroupWalletStatusClosed,
+		"balance":  0,
+		"closedAt": time.Now(),
+	}}
+
+	result, err := collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrGroupWalletChanged
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type loanRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewLoanRepository(db mongo.Database, collection string) domain.LoanRepository {
+	return &loanRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (lr *loanRepository) Create(c context.Context, loan *domain.Loan) error {
+	collection := lr.database.Collection(lr.collection)
+
+	_, err := collection.InsertOne(c, loan)
+
+	return err
+}
+
+func (lr *loanRepository) GetByID(c context.Context, id string) (domain.Loan, error) {
+	collection := lr.database.Collection(lr.collection)
+
+	var loan domain.Loan
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return loan, err
+	}
+
+	err = collection.FindOne(c, bson.M{"_id": idHex}).Decode(&loan)
+	return loan, err
+}
+
+func (lr *loanRepository) FetchByUserID(c context.Context, userID string) ([]domain.Loan, error) {
+	collection := lr.database.Collection(lr.collection)
+
+	var loans []domain.Loan
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return loans, err
+	}
+
+	filter := bson.M{"$or": []bson.M{{"lenderID": idHex}, {"borrowerID": idHex}}}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := collection.Find(c, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(c, &loans)
+	if loans == nil {
+		return []domain.Loan{}, err
+	}
+
+	return loans, err
+}
+
+func (lr *loanRepository) UpdateConfirmation(c context.Context, loan *domain.Loan) error {
+	collection := lr.database.Collection(lr.collection)
+
+	filter := bson.M{"_id": loan.ID, "status": domain.LoanStatusPending}
+	set := bson.M{"status": loan.Status}
+	if loan.LenderConfirmedAt != nil {
+		set["lenderConfirmedAt"] = loan.LenderConfirmedAt
+	}
+	if loan.BorrowerConfirmedAt != nil {
+		set["borrowerConfirmedAt"] = loan.BorrowerConfirmedAt
+	}
+	update := bson.M{"$set": set}
+
+	result, err := collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrLoanNotPending
+	}
+
+	return nil
+}
+
+func (lr *loanRepository) AddRepayment(c context.Context, loan *domain.Loan, repayment domain.Repayment, status string) error {
+	collection := lr.database.Collection(lr.collection)
+
+	// Matching on the number of repayments rejects concurrent writes that
+	// the remaining balance check of the caller did not see.
+	filter := bson.M{
+		"_id":        loan.ID,
+		"status":     domain.LoanStatusActive,
+		"repayments": bson.M{"$size": len(loan.Repayments)},
+	}
+	update := bson.M{
+		"$push": bson.M{"repayments": repayment},
+		"$set":  bson.M{"status": status},
+	}
+
+	result, err := collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrLoanChanged
+	}
+
+	return nil
+}
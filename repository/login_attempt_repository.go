@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type loginAttemptRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+// NewLoginAttemptRepository shares failure counters between instances.
+// Stale counters are removed by the TTL index on expiresAt.
+func NewLoginAttemptRepository(db mongo.Database, collection string) domain.LoginAttemptStore {
+	return &loginAttemptRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (lr *loginAttemptRepository) Get(c context.Context, key string) (domain.LoginAttempts, error) {
+	collection := lr.database.Collection(lr.collection)
+
+	var attempts domain.LoginAttempts
+	filter := bson.M{"_id": key, "expiresAt": bson.M{"$gt": time.Now()}}
+	err := collection.FindOne(c, filter).Decode(&attempts)
+	if err == mongodriver.ErrNoDocuments {
+		return domain.LoginAttempts{}, nil
+	}
+
+	return attempts, err
+}
+
+// RecordFailure increments the counter in a single pipeline update, so
+// concurrent failures are all counted.
+func (lr *loginAttemptRepository) RecordFailure(c context.Context, key string, now time.Time, resetAfter time.Duration) (domain.LoginAttempts, error) {
+	collection := lr.database.Collection(lr.collection)
+
+	update := bson.A{bson.M{"$set": bson.M{
+		"failures": bson.M{"$cond": bson.A{
+			bson.M{"$gte": bson.A{"$lastFailureAt", now.Add(-resetAfter)}},
+			bson.M{"$add": bson.A{"$failures", 1}},
+			1,
+		}},
+		"lastFailureAt": now,
+		"expiresAt":     now.Add(resetAfter),
+	}}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var attempts domain.LoginAttempts
+	err := collection.FindOneAndUpdate(c, bson.M{"_id": key}, update, opts).Decode(&attempts)
+
+	return attempts, err
+}
+
+func (lr *loginAttemptRepository) Reset(c context.Context, key string) error {
+	collection := lr.database.Collection(lr.collection)
+
+	_, err := collection.DeleteOne(c, bson.M{"_id": key})
+
+	return err
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type oidcStateRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewOIDCStateRepository(db mongo.Database, collection string) domain.OIDCStateRepository {
+	return &oidcStateRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (or *oidcStateRepository) Create(c context.Context, state *domain.OIDCState) error {
+	collection := or.database.Collection(or.collection)
+
+	_, err := collection.InsertOne(c, state)
+
+	return err
+}
+
+func (or *oidcStateRepository) Consume(c context.Context, provider string, stateHash string) (domain.OIDCState, error) {
+	collection := or.database.Collection(or.collection)
+
+	now := time.Now()
+	filter := bson.M{
+		"provider":  provider,
+		"stateHash": stateHash,
+		"usedAt":    bson.M{"$exists": false},
+		"expiresAt": bson.M{"$gt": now},
+	}
+	update := bson.M{"$set": bson.M{"usedAt": now}}
+
+	var state domain.OIDCState
+	err := collection.FindOneAndUpdate(c, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&state)
+	return state, err
+}
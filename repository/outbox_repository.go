@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type outboxRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewOutboxRepository(db mongo.Database, collection string) domain.OutboxRepository {
+	return &outboxRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (or *outboxRepository) Add(c context.Context, events ...domain.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	collection := or.database.Collection(or.collection)
+
+	documents := make([]interface{}, len(events))
+	for i := range events {
+		documents[i] = events[i]
+	}
+
+	_, err := collection.InsertMany(c, documents)
+
+	return err
+}
+
+func (or *outboxRepository) FetchPending(c context.Context, limit int64) ([]domain.DomainEvent, error) {
+	collection := or.database.Collection(or.collection)
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit)
+	filter := bson.M{
+		"dispatchedAt": bson.M{"$exists": false},
+		"attempts":     bson.M{"$lt": domain.OutboxMaxAttempts},
+	}
+	cursor, err := collection.Find(c, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []domain.DomainEvent
+
+	err = cursor.All(c, &events)
+	if events == nil {
+		return []domain.DomainEvent{}, err
+	}
+
+	return events, err
+}
+
+func (or *outboxRepository) MarkDispatched(c context.Context, id primitive.ObjectID) error {
+	collection := or.database.Collection(or.collection)
+
+	_, err := collection.UpdateOne(c, bson.M{"_id": id}, bson.M{"$set": bson.M{"dispatchedAt": time.Now()}})
+
+	return err
+}
+
+func (or *outboxRepository) MarkFailed(c context.Context, id primitive.ObjectID) error {
+	collection := or.database.Collection(or.collection)
+
+	_, err := collection.UpdateOne(c, bson.M{"_id": id}, bson.M{"$inc": bson.M{"attempts": 1}})
+
+	return err
+}
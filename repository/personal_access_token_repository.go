@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type personalAccessTokenRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewPersonalAccessTokenRepository(db mongo.Database, collection string) domain.PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (pr *personalAccessTokenRepository) Create(c context.Context, token *domain.PersonalAccessToken) error {
+	collection := pr.database.Collection(pr.collection)
+
+	_, err := collection.InsertOne(c, token)
+
+	return err
+}
+
+func (pr *personalAccessTokenRepository) FetchByUserID(c context.Context, userID string) ([]domain.PersonalAccessToken, error) {
+	collection := pr.database.Collection(pr.collection)
+
+	var tokens []domain.PersonalAccessToken
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return tokens, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := collection.Find(c, bson.M{"userID": idHex}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(c, &tokens)
+	if tokens == nil {
+		return []domain.PersonalAccessToken{}, err
+	}
+
+	return tokens, err
+}
+
+func (pr *personalAccessTokenRepository) GetByHash(c context.Context, tokenHash string) (domain.PersonalAccessToken, error) {
+	collection := pr.database.Collection(pr.collection)
+	var token domain.PersonalAccessToken
+	err := collection.FindOne(c, bson.M{"tokenHash": tokenHash}).Decode(&token)
+	return token, err
+}
+
+func (pr *personalAccessTokenRepository) Revoke(c context.Context, id string, userID string) error {
+	collection := pr.database.Collection(pr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	userIDHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": idHex, "userID": userIDHex}
+	result, err := collection.UpdateOne(c, filter, bson.M{"$set": bson.M{"revokedAt": time.Now()}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongodriver.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (pr *personalAccessTokenRepository) RevokeAllForUser(c context.Context, userID string) error {
+	collection := pr.database.Collection(pr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"userID": idHex, "revokedAt": bson.M{"$exists": false}}
+	_, err = collection.UpdateMany(c, filter, bson.M{"$set": bson.M{"revokedAt": time.Now()}})
+
+	return err
+}
+
+func (pr *personalAccessTokenRepository) UpdateLastUsed(c context.Context, id primitive.ObjectID, lastUsedAt time.Time) error {
+	collection := pr.database.Collection(pr.collection)
+
+	_, err := collection.UpdateOne(c, bson.M{"_id": id}, bson.M{"$set": bson.M{"lastUsedAt": lastUsedAt}})
+
+	return err
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type refreshTokenRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewRefreshTokenRepository(db mongo.Database, collection string) domain.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (rr *refreshTokenRepository) Create(c context.Context, token *domain.RefreshToken) error {
+	collection := rr.database.Collection(rr.collection)
+
+	_, err := collection.InsertOne(c, token)
+
+	return err
+}
+
+func (rr *refreshTokenRepository) GetByID(c context.Context, id string) (domain.RefreshToken, error) {
+	collection := rr.database.Collection(rr.collection)
+	var token domain.RefreshToken
+	err := collection.FindOne(c, bson.M{"_id": id}).Decode(&token)
+	return token, err
+}
+
+// MarkUsed reports whether the token was still unused, so that only one of
+// several concurrent refreshes with the same token succeeds.
+func (rr *refreshTokenRepository) MarkUsed(c context.Context, id string) (bool, error) {
+	collection := rr.database.Collection(rr.collection)
+
+	filter := bson.M{
+		"_id":       id,
+		"usedAt":    bson.M{"$exists": false},
+		"revokedAt": bson.M{"$exists": false},
+	}
+	result, err := collection.UpdateOne(c, filter, bson.M{"$set": bson.M{"usedAt": time.Now()}})
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+func (rr *refreshTokenRepository) RevokeFamily(c context.Context, familyID string) error {
+	collection := rr.database.Collection(rr.collection)
+
+	filter := bson.M{"familyID": familyID, "revokedAt": bson.M{"$exists": false}}
+	_, err := collection.UpdateMany(c, filter, bson.M{"$set": bson.M{"revokedAt": time.Now()}})
+
+	return err
+}
+
+func (rr *refreshTokenRepository) RevokeAllForUser(c context.Context, userID string) error {
+	collection := rr.database.Collection(rr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"userID": idHex, "revokedAt": bson.M{"$exists": false}}
+	_, err = collection.UpdateMany(c, filter, bson.M{"$set": bson.M{"revokedAt": time.Now()}})
+
+	return err
+}
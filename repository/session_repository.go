@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type sessionRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewSessionRepository(db mongo.Database, collection string) domain.SessionRepository {
+	return &sessionRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (sr *sessionRepository) Create(c context.Context, session *domain.Session) error {
+	collection := sr.database.Collection(sr.collection)
+
+	_, err := collection.InsertOne(c, session)
+
+	return err
+}
+
+func (sr *sessionRepository) Touch(c context.Context, id string, client domain.SessionClient, lastSeenAt time.Time, expiresAt time.Time) error {
+	collection := sr.database.Collection(sr.collection)
+
+	update := bson.M{"$set": bson.M{
+		"userAgent":  client.UserAgent,
+		"ip":         client.IP,
+		"lastSeenAt": lastSeenAt,
+		"expiresAt":  expiresAt,
+	}}
+	_, err := collection.UpdateOne(c, bson.M{"_id": id}, update)
+
+	return err
+}
+
+func (sr *sessionRepository) FetchActiveByUserID(c context.Context, userID string) ([]domain.Session, error) {
+	collection := sr.database.Collection(sr.collection)
+
+	var sessions []domain.Session
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return sessions, err
+	}
+
+	filter := bson.M{
+		"userID":    idHex,
+		"revokedAt": bson.M{"$exists": false},
+		"expiresAt": bson.M{"$gt": time.Now()},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "lastSeenAt", Value: -1}})
+	cursor, err := collection.Find(c, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(c, &sessions)
+	if sessions == nil {
+		return []domain.Session{}, err
+	}
+
+	return sessions, err
+}
+
+func (sr *sessionRepository) Revoke(c context.Context, id string, userID string) error {
+	collection := sr.database.Collection(sr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": id, "userID": idHex, "revokedAt": bson.M{"$exists": false}}
+	result, err := collection.UpdateOne(c, filter, bson.M{"$set": bson.M{"revokedAt": time.Now()}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongodriver.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (sr *sessionRepository) RevokeAllForUser(c context.Context, userID string) error {
+	collection := sr.database.Collection(sr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"userID": idHex, "revokedAt": bson.M{"$exists": false}}
+	_, err = collection.UpdateMany(c, filter, bson.M{"$set": bson.M{"revokedAt": time.Now()}})
+
+	return err
+}
+
+func (sr *sessionRepository) FetchByUserID(c context.Context, userID string) ([]domain.Session, error) {
+	collection := sr.database.Collection(sr.collection)
+
+	var sessions []domain.Session
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return sessions, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := collection.Find(c, bson.M{"userID": idHex}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(c, &sessions)
+	if sessions == nil {
+		return []domain.Session{}, err
+	}
+
+	return sessions, err
+}
+
+func (sr *sessionRepository) DeleteAllForUser(c context.Context, userID string) error {
+	collection := sr.database.Collection(sr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.DeleteMany(c, bson.M{"userID": idHex})
+
+	return err
+}
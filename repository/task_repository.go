@@ -51,3 +51,16 @@ func (tr *taskRepository) FetchByUserID(c context.Context, userID string) ([]dom
 
 	return tasks, err
 }
+
+func (tr *taskRepository) DeleteAllForUser(c context.Context, userID string) error {
+	collection := tr.database.Collection(tr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.DeleteMany(c, bson.M{"userID": idHex})
+
+	return err
+}
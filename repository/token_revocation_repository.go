@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type tokenRevocation struct {
+	ID           string    `bson:"_id"`
+	IssuedBefore time.Time `bson:"issuedBefore,omitempty"`
+	ExpiresAt    time.Time `bson:"expiresAt"`
+}
+
+type tokenRevocationRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+// NewTokenRevocationRepository stores revocations in Mongo. Expired entries
+// are removed by the TTL index on expiresAt.
+func NewTokenRevocationRepository(db mongo.Database, collection string) domain.TokenRevocationStore {
+	return &tokenRevocationRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (tr *tokenRevocationRepository) RevokeToken(c context.Context, tokenID string, expiresAt time.Time) error {
+	collection := tr.database.Collection(tr.collection)
+
+	update := bson.M{"$set": bson.M{"expiresAt": expiresAt}}
+	_, err := collection.UpdateOne(c, bson.M{"_id": "jti:" + tokenID}, update, options.Update().SetUpsert(true))
+
+	return err
+}
+
+func (tr *tokenRevocationRepository) RevokeSessionTokens(c context.Context, sessionID string, expiresAt time.Time) error {
+	collection := tr.database.Collection(tr.collection)
+
+	update := bson.M{"$set": bson.M{"expiresAt": expiresAt}}
+	_, err := collection.UpdateOne(c, bson.M{"_id": "sid:" + sessionID}, update, options.Update().SetUpsert(true))
+
+	return err
+}
+
+func (tr *tokenRevocationRepository) RevokeUserTokens(c context.Context, userID string, issuedBefore time.Time, expiresAt time.Time) error {
+	collection := tr.database.Collection(tr.collection)
+
+	update := bson.M{"$set": bson.M{"issuedBefore": issuedBefore, "expiresAt": expiresAt}}
+	_, err := collection.UpdateOne(c, bson.M{"_id": "user:" + userID}, update, options.Update().SetUpsert(true))
+
+	return err
+}
+
+func (tr *tokenRevocationRepository) IsRevoked(c context.Context, tokenID string, sessionID string, userID string, issuedAt time.Time) (bool, error) {
+	collection := tr.database.Collection(tr.collection)
+
+	ids := []string{"jti:" + tokenID, "user:" + userID}
+	if sessionID != "" {
+		ids = append(ids, "sid:"+sessionID)
+	}
+	filter := bson.M{"_id": bson.M{"$in": ids}}
+	cursor, err := collection.Find(c, filter)
+	if err != nil {
+		return false, err
+	}
+
+	var revocations []tokenRevocation
+	err = cursor.All(c, &revocations)
+	if err != nil {
+		return false, err
+	}
+
+	for _, revocation := range revocations {
+		if revocation.IssuedBefore.IsZero() || issuedAt.Before(revocation.IssuedBefore) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
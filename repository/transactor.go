@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+)
+
+type transactor struct {
+	client mongo.Client
+}
+
+// NewTransactor needs Mongo to run as a replica set, which transactions
+// require even with a single node.
+func NewTransactor(client mongo.Client) domain.Transactor {
+	return &transactor{
+		client: client,
+	}
+}
+
+func (t *transactor) WithTransaction(c context.Context, fn func(ctx context.Context) error) error {
+	return t.client.UseSession(c, func(sc mongodriver.SessionContext) error {
+		_, err := sc.WithTransaction(sc, func(txc mongodriver.SessionContext) (interface{}, error) {
+			return nil, fn(txc)
+		})
+		return err
+	})
+}
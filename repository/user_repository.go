@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"regexp"
+	"time"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -30,11 +33,31 @@ func (ur *userRepository) Create(c context.Context, user *domain.User) error {
 	return err
 }
 
-func (ur *userRepository) Fetch(c context.Context) ([]domain.User, error) {
+func (ur *userRepository) Fetch(c context.Context, query *domain.UserQuery, limit int64) ([]domain.User, error) {
 	collection := ur.database.Collection(ur.collection)
 
-	opts := options.Find().SetProjection(bson.D{{Key: "password", Value: 0}})
-	cursor, err := collection.Find(c, bson.D{}, opts)
+	filter := bson.M{}
+	if query.Search != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(query.Search), Options: "i"}
+		filter["$or"] = []bson.M{{"name": pattern}, {"email": pattern}}
+	}
+	if query.Before != "" {
+		before, err := primitive.ObjectIDFromHex(query.Before)
+		if err != nil {
+			return nil, err
+		}
+		filter["_id"] = bson.M{"$lt": before}
+	}
+
+	opts := options.Find().
+		SetProjection(bson.D{
+			{Key: "password", Value: 0},
+			{Key: "twoFactor.secret", Value: 0},
+			{Key: "twoFactor.recoveryCodes", Value: 0},
+		}).
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(limit)
+	cursor, err := collection.Find(c, filter, opts)
 
 	if err != nil {
 		return nil, err
@@ -70,3 +93,275 @@ func (ur *userRepository) GetByID(c context.Context, id string) (domain.User, er
 	err = collection.FindOne(c, bson.M{"_id": idHex}).Decode(&user)
 	return user, err
 }
+
+func (ur *userRepository) UpdatePassword(c context.Context, id string, password string) error {
+	collection := ur.database.Collection(ur.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.UpdateOne(c, bson.M{"_id": idHex}, bson.M{"$set": bson.M{"password": password}})
+	return err
+}
+
+// MarkEmailVerified only matches while the user still has the given email, so
+// a link sent to a previous address cannot verify the current one.
+func (ur *userRepository) MarkEmailVerified(c context.Context, id string, email string, verifiedAt time.Time) error {
+	collection := ur.database.Collection(ur.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := collection.UpdateOne(c, bson.M{"_id": idHex, "email": email}, bson.M{"$set": bson.M{"emailVerifiedAt": verifiedAt}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongodriver.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (ur *userRepository) SetTwoFactor(c context.Context, id string, twoFactor *domain.TwoFactor) error {
+	collection := ur.database.Collection(ur.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"twoFactor": twoFactor}}
+	if twoFactor == nil {
+		update = bson.M{"$unset": bson.M{"twoFactor": ""}}
+	}
+
+	_, err = collection.UpdateOne(c, bson.M{"_id": idHex}, update)
+	return err
+}
+
+func (ur *userRepository) UseTwoFactorStep(c context.Context, id string, step int64) (bool, error) {
+	collection := ur.database.Collection(ur.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{"_id": idHex, "twoFactor.lastStep": bson.M{"$lt": step}}
+	result, err := collection.UpdateOne(c, filter, bson.M{"$set": bson.M{"twoFactor.lastStep": step}})
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+func (ur *userRepository) UseRecoveryCode(c context.Context, id string, codeHash string) (bool, error) {
+	collection := ur.database.Collection(ur.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{"_id": idHex, "twoFactor.recoveryCodes": codeHash}
+	result, err := collection.UpdateOne(c, filter, bson.M{"$pull": bson.M{"twoFactor.recoveryCodes": codeHash}})
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+func (ur *userRepository) GetByIdentity(c context.Context, provider string, subject string) (domain.User, error) {
+	collection := ur.database.Collection(ur.collection)
+	var user domain.User
+	filter := bson.M{"identities": bson.M{"$elemMatch": bson.M{"provider": provider, "subject": subject}}}
+	err := collection.FindOne(c, filter).Decode(&user)
+	return user, err
+}
+
+func (ur *userRepository) AddIdentity(c context.Context, id string, identity domain.ExternalIdentity) error {
+	collection := ur.database.Collection(ur.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.UpdateOne(c, bson.M{"_id": idHex}, bson.M{"$push": bson.M{"identities": identity}})
+	return err
+}
+
+func (ur *userRepository) SetPendingEmail(c context.Context, id string, email string) error {
+	collection := ur.database.Collection(ur.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := collection.UpdateOne(c, bson.M{"_id": idHex}, bson.M{"$set": bson.M{"pendingEmail": email}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongodriver.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (ur *userRepository) ConfirmEmail(c context.Context, id string, email string, verifiedAt time.Time) error {
+	collection := ur.database.Collection(ur.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"email": email, "emailVerifiedAt": verifiedAt}, "$unset": bson.M{"pendingEmail": ""}}
+	result, err := collection.UpdateOne(c, bson.M{"_id": idHex, "pendingEmail": email}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongodriver.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (ur *userRepository) UpdateProfile(c context.Context, id string, request *domain.UpdateProfileRequest) error {
+	collection := ur.database.Collection(ur.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	set, unset := bson.M{}, bson.M{}
+	for field, value := range map[string]*string{
+		"displayName": request.DisplayName,
+		"currency":    request.Currency,
+		"locale":      request.Locale,
+		"timezone":    request.Timezone,
+	} {
+		switch {
+		case value == nil:
+		case *value == "":
+			unset[field] = ""
+		default:
+			set[field] = *value
+		}
+	}
+
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	if len(update) == 0 {
+		return nil
+	}
+
+	result, err := collection.UpdateOne(c, bson.M{"_id": idHex}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongodriver.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// SetAvatar reads the previous key and writes the new one in one step, so
+// that a concurrent upload cannot leave a file that no user refers to.
+func (ur *userRepository) SetAvatar(c context.Context, id string, key string) (string, error) {
+	collection := ur.database.Collection(ur.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return "", err
+	}
+
+	update := bson.M{"$set": bson.M{"avatarKey": key}}
+	if key == "" {
+		update = bson.M{"$unset": bson.M{"avatarKey": ""}}
+	}
+
+	var previous domain.User
+	opts := options.FindOneAndUpdate().SetProjection(bson.M{"avatarKey": 1})
+	err = collection.FindOneAndUpdate(c, bson.M{"_id": idHex}, update, opts).Decode(&previous)
+	return previous.AvatarKey, err
+}
+
+func (ur *userRepository) Anonymize(c context.Context, id string, deletedAt time.Time) error {
+	collection := ur.database.Collection(ur.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":      domain.DeletedUserName,
+			"email":     "",
+			"password":  "",
+			"deletedAt": deletedAt,
+		},
+		"$unset": bson.M{
+			"emailVerifiedAt": "",
+			"pendingEmail":    "",
+			"twoFactor":       "",
+			"identities":      "",
+			"displayName":     "",
+			"avatarKey":       "",
+			"currency":        "",
+			"locale":          "",
+			"timezone":        "",
+		},
+	}
+	result, err := collection.UpdateOne(c, bson.M{"_id": idHex}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongodriver.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (ur *userRepository) SetDisabled(c context.Context, id string, disabledAt *time.Time) error {
+	collection := ur.database.Collection(ur.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"disabledAt": disabledAt}}
+	if disabledAt == nil {
+		update = bson.M{"$unset": bson.M{"disabledAt": ""}}
+	}
+
+	result, err := collection.UpdateOne(c, bson.M{"_id": idHex}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongodriver.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type userTokenRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewUserTokenRepository(db mongo.Database, collection string) domain.UserTokenRepository {
+	return &userTokenRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (ur *userTokenRepository) Create(c context.Context, token *domain.UserToken) error {
+	collection := ur.database.Collection(ur.collection)
+
+	_, err := collection.InsertOne(c, token)
+
+	return err
+}
+
+// Consume matches and marks the token in one update, so a token can be used
+// only once even under concurrent requests.
+func (ur *userTokenRepository) Consume(c context.Context, purpose string, tokenHash string) (domain.UserToken, error) {
+	collection := ur.database.Collection(ur.collection)
+
+	now := time.Now()
+	filter := bson.M{
+		"purpose":   purpose,
+		"tokenHash": tokenHash,
+		"usedAt":    bson.M{"$exists": false},
+		"expiresAt": bson.M{"$gt": now},
+	}
+	update := bson.M{"$set": bson.M{"usedAt": now}}
+
+	var token domain.UserToken
+	err := collection.FindOneAndUpdate(c, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&token)
+	return token, err
+}
+
+func (ur *userTokenRepository) CountSince(c context.Context, userID string, purpose string, since time.Time) (int64, error) {
+	collection := ur.database.Collection(ur.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	filter := bson.M{"userID": idHex, "purpose": purpose, "createdAt": bson.M{"$gte": since}}
+	return collection.CountDocuments(c, filter)
+}
+
+func (ur *userTokenRepository) CountByIPSince(c context.Context, ip string, purpose string, since time.Time) (int64, error) {
+	collection := ur.database.Collection(ur.collection)
+
+	filter := bson.M{"requestIP": ip, "purpose": purpose, "createdAt": bson.M{"$gte": since}}
+	return collection.CountDocuments(c, filter)
+}
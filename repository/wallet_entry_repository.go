@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type walletEntryRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewWalletEntryRepository(db mongo.Database, collection string) domain.WalletEntryRepository {
+	return &walletEntryRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (er *walletEntryRepository) Create(c context.Context, entries ...domain.WalletEntry) error {
+	collection := er.database.Collection(er.collection)
+
+	documents := make([]interface{}, len(entries))
+	for i := range entries {
+		documents[i] = entries[i]
+	}
+
+	_, err := collection.InsertMany(c, documents)
+
+	return err
+}
+
+func (er *walletEntryRepository) FetchByWalletID(c context.Context, walletID primitive.ObjectID) ([]domain.WalletEntry, error) {
+	return er.fetch(c, bson.M{"walletID": walletID})
+}
+
+func (er *walletEntryRepository) FetchByUserID(c context.Context, userID string) ([]domain.WalletEntry, error) {
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return er.fetch(c, bson.M{"$or": []bson.M{{"userID": idHex}, {"shares.userID": idHex}}})
+}
+
+func (er *walletEntryRepository) fetch(c context.Context, filter bson.M) ([]domain.WalletEntry, error) {
+	collection := er.database.Collection(er.collection)
+
+	var entries []domain.WalletEntry
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := collection.Find(c, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(c, &entries)
+	if entries == nil {
+		return []domain.WalletEntry{}, err
+	}
+
+	return entries, err
+}
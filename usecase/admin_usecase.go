@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type adminUsecase struct {
+	userRepository                domain.UserRepository
+	groupMemberRepository         domain.GroupMemberRepository
+	refreshTokenRepository        domain.RefreshTokenRepository
+	sessionRepository             domain.SessionRepository
+	personalAccessTokenRepository domain.PersonalAccessTokenRepository
+	revocationStore               domain.TokenRevocationStore
+	contextTimeout                time.Duration
+}
+
+func NewAdminUsecase(userRepository domain.UserRepository, groupMemberRepository domain.GroupMemberRepository, refreshTokenRepository domain.RefreshTokenRepository, sessionRepository domain.SessionRepository, personalAccessTokenRepository domain.PersonalAccessTokenRepository, revocationStore domain.TokenRevocationStore, timeout time.Duration) domain.AdminUsecase {
+	return &adminUsecase{
+		userRepository:                userRepository,
+		groupMemberRepository:         groupMemberRepository,
+		refreshTokenRepository:        refreshTokenRepository,
+		sessionRepository:             sessionRepository,
+		personalAccessTokenRepository: personalAccessTokenRepository,
+		revocationStore:               revocationStore,
+		contextTimeout:                timeout,
+	}
+}
+
+// FetchUsers reads one user more than the page holds to tell whether there is
+// a next page.
+func (au *adminUsecase) FetchUsers(c context.Context, query *domain.UserQuery) (domain.AdminUserPage, error) {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	limit := query.PageSize()
+	users, err := au.userRepository.Fetch(ctx, query, limit+1)
+	if err == primitive.ErrInvalidHex {
+		return domain.AdminUserPage{}, domain.ErrInvalidUserCursor
+	}
+	if err != nil {
+		return domain.AdminUserPage{}, err
+	}
+
+	page := domain.AdminUserPage{Users: make([]domain.AdminUser, 0, len(users))}
+	if int64(len(users)) > limit {
+		users = users[:limit]
+		page.Next = users[limit-1].ID.Hex()
+	}
+	for i := range users {
+		page.Users = append(page.Users, domain.NewAdminUser(&users[i]))
+	}
+	return page, nil
+}
+
+func (au *adminUsecase) GetUser(c context.Context, userID string) (domain.AdminUserDetail, error) {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	user, err := au.getUser(ctx, userID)
+	if err != nil {
+		return domain.AdminUserDetail{}, err
+	}
+
+	groups, err := au.groupMemberRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return domain.AdminUserDetail{}, err
+	}
+
+	return domain.AdminUserDetail{AdminUser: domain.NewAdminUser(&user), Groups: groups}, nil
+}
+
+func (au *adminUsecase) DisableUser(c context.Context, userID string, accessTokenExpiry int) error {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	err := au.userRepository.SetDisabled(ctx, userID, &now)
+	if err == mongo.ErrNoDocuments || err == primitive.ErrInvalidHex {
+		return domain.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	err = au.personalAccessTokenRepository.RevokeAllForUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return revokeAllSessions(ctx, au.revocationStore, au.refreshTokenRepository, au.sessionRepository, userID, accessTokenExpiry)
+}
+
+func (au *adminUsecase) EnableUser(c context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	err := au.userRepository.SetDisabled(ctx, userID, nil)
+	if err == mongo.ErrNoDocuments || err == primitive.ErrInvalidHex {
+		return domain.ErrUserNotFound
+	}
+	return err
+}
+
+func (au *adminUsecase) ForceLogout(c context.Context, userID string, accessTokenExpiry int) error {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	_, err := au.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return revokeAllSessions(ctx, au.revocationStore, au.refreshTokenRepository, au.sessionRepository, userID, accessTokenExpiry)
+}
+
+func (au *adminUsecase) ResetTwoFactor(c context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	_, err := au.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return au.userRepository.SetTwoFactor(ctx, userID, nil)
+}
+
+func (au *adminUsecase) getUser(ctx context.Context, userID string) (domain.User, error) {
+	user, err := au.userRepository.GetByID(ctx, userID)
+	if err == mongo.ErrNoDocuments || err == primitive.ErrInvalidHex {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	return user, err
+}
@@ -0,0 +1,134 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/memstore"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestAdmin(t *testing.T) {
+	enabledAt := time.Now()
+	user := domain.User{
+		ID:        primitive.NewObjectID(),
+		Name:      "Test Name",
+		Email:     "test@gmail.com",
+		TwoFactor: &domain.TwoFactor{EnabledAt: &enabledAt},
+	}
+	userID := user.ID.Hex()
+
+	type deps struct {
+		users           *mocks.UserRepository
+		members         *mocks.GroupMemberRepository
+		refreshTokens   *mocks.RefreshTokenRepository
+		sessions        *mocks.SessionRepository
+		accessTokens    *mocks.PersonalAccessTokenRepository
+		revocationStore domain.TokenRevocationStore
+	}
+	newUsecase := func() (domain.AdminUsecase, deps) {
+		d := deps{
+			users:           new(mocks.UserRepository),
+			members:         new(mocks.GroupMemberRepository),
+			refreshTokens:   new(mocks.RefreshTokenRepository),
+			sessions:        new(mocks.SessionRepository),
+			accessTokens:    new(mocks.PersonalAccessTokenRepository),
+			revocationStore: memstore.NewTokenRevocationStore(),
+		}
+		u := usecase.NewAdminUsecase(d.users, d.members, d.refreshTokens, d.sessions, d.accessTokens, d.revocationStore, time.Second*2)
+		return u, d
+	}
+
+	t.Run("fetch users", func(t *testing.T) {
+		u, d := newUsecase()
+		users := []domain.User{user, {ID: primitive.NewObjectID()}, {ID: primitive.NewObjectID()}}
+		query := &domain.UserQuery{Search: "test", Limit: 2}
+		d.users.On("Fetch", mock.Anything, query, int64(3)).Return(users, nil).Once()
+
+		page, err := u.FetchUsers(context.Background(), query)
+		assert.NoError(t, err)
+		assert.Len(t, page.Users, 2)
+		assert.Equal(t, users[1].ID.Hex(), page.Next)
+		assert.Equal(t, user.Email, page.Users[0].Email)
+		assert.True(t, page.Users[0].TwoFactorEnabled)
+	})
+
+	t.Run("fetch users with an invalid cursor", func(t *testing.T) {
+		u, d := newUsecase()
+		d.users.On("Fetch", mock.Anything, mock.Anything, int64(51)).Return(nil, primitive.ErrInvalidHex).Once()
+
+		_, err := u.FetchUsers(context.Background(), &domain.UserQuery{Before: "nope"})
+		assert.ErrorIs(t, err, domain.ErrInvalidUserCursor)
+	})
+
+	t.Run("get user with groups", func(t *testing.T) {
+		u, d := newUsecase()
+		groupID := primitive.NewObjectID()
+		d.users.On("GetByID", mock.Anything, userID).Return(user, nil).Once()
+		d.members.On("FetchByUserID", mock.Anything, userID).Return([]domain.GroupMember{{GroupID: groupID, UserID: user.ID, Role: domain.GroupRoleOwner}}, nil).Once()
+
+		detail, err := u.GetUser(context.Background(), userID)
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, detail.ID)
+		assert.Equal(t, groupID, detail.Groups[0].GroupID)
+	})
+
+	t.Run("get unknown user", func(t *testing.T) {
+		u, d := newUsecase()
+		d.users.On("GetByID", mock.Anything, userID).Return(domain.User{}, mongo.ErrNoDocuments).Once()
+
+		_, err := u.GetUser(context.Background(), userID)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("disable ends sessions and access tokens", func(t *testing.T) {
+		u, d := newUsecase()
+		d.users.On("SetDisabled", mock.Anything, userID, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+		d.accessTokens.On("RevokeAllForUser", mock.Anything, userID).Return(nil).Once()
+		d.refreshTokens.On("RevokeAllForUser", mock.Anything, userID).Return(nil).Once()
+		d.sessions.On("RevokeAllForUser", mock.Anything, userID).Return(nil).Once()
+
+		issuedAt := time.Unix(time.Now().Unix(), 0)
+		err := u.DisableUser(context.Background(), userID, 2)
+		assert.NoError(t, err)
+
+		revoked, err := d.revocationStore.IsRevoked(context.Background(), "jti", "sid", userID, issuedAt)
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+
+		// Tokens from the next second on, e.g. of a new login, are not affected.
+		revoked, err = d.revocationStore.IsRevoked(context.Background(), "jti", "sid", userID, time.Unix(time.Now().Unix()+1, 0))
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+
+		d.users.AssertExpectations(t)
+		d.accessTokens.AssertExpectations(t)
+		d.refreshTokens.AssertExpectations(t)
+		d.sessions.AssertExpectations(t)
+	})
+
+	t.Run("enable unknown user", func(t *testing.T) {
+		u, d := newUsecase()
+		d.users.On("SetDisabled", mock.Anything, userID, (*time.Time)(nil)).Return(mongo.ErrNoDocuments).Once()
+
+		err := u.EnableUser(context.Background(), userID)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("reset two-factor", func(t *testing.T) {
+		u, d := newUsecase()
+		d.users.On("GetByID", mock.Anything, userID).Return(user, nil).Once()
+		d.users.On("SetTwoFactor", mock.Anything, userID, (*domain.TwoFactor)(nil)).Return(nil).Once()
+
+		err := u.ResetTwoFactor(context.Background(), userID)
+		assert.NoError(t, err)
+		d.users.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type auditUsecase struct {
+	auditRepository domain.AuditRepository
+	contextTimeout  time.Duration
+}
+
+func NewAuditUsecase(auditRepository domain.AuditRepository, timeout time.Duration) domain.AuditUsecase {
+	return &auditUsecase{
+		auditRepository: auditRepository,
+		contextTimeout:  timeout,
+	}
+}
+
+func (au *auditUsecase) FetchByUserID(c context.Context, userID string, query *domain.AuditQuery) (domain.AuditEventPage, error) {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	own := *query
+	own.UserID = userID
+	return au.fetch(ctx, &own)
+}
+
+func (au *auditUsecase) Fetch(c context.Context, query *domain.AuditQuery) (domain.AuditEventPage, error) {
+	ctx, cancel := context.WithTimeout(c, au.contextTimeout)
+	defer cancel()
+
+	return au.fetch(ctx, query)
+}
+
+// fetch reads one event more than the page holds to tell whether there is a
+// next page.
+func (au *auditUsecase) fetch(ctx context.Context, query *domain.AuditQuery) (domain.AuditEventPage, error) {
+	limit := query.PageSize()
+	events, err := au.auditRepository.Fetch(ctx, query, limit+1)
+	if err == primitive.ErrInvalidHex {
+		return domain.AuditEventPage{}, domain.ErrInvalidAuditCursor
+	}
+	if err != nil {
+		return domain.AuditEventPage{}, err
+	}
+
+	page := domain.AuditEventPage{Events: events}
+	if int64(len(events)) > limit {
+		page.Events = events[:limit]
+		page.Next = page.Events[limit-1].ID.Hex()
+	}
+	return page, nil
+}
@@ -0,0 +1,71 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAudit(t *testing.T) {
+	userID := primitive.NewObjectID().Hex()
+	events := []domain.AuditEvent{
+		{ID: primitive.NewObjectID(), Action: domain.AuditActionLogin, UserID: userID},
+		{ID: primitive.NewObjectID(), Action: domain.AuditActionLogin, UserID: userID},
+		{ID: primitive.NewObjectID(), Action: domain.AuditActionSignup, UserID: userID},
+	}
+
+	t.Run("users only see their own events", func(t *testing.T) {
+		mockAuditRepository := new(mocks.AuditRepository)
+		u := usecase.NewAuditUsecase(mockAuditRepository, time.Second*2)
+
+		mockAuditRepository.On("Fetch", mock.Anything, &domain.AuditQuery{UserID: userID, Limit: 2}, int64(3)).Return(events, nil).Once()
+
+		page, err := u.FetchByUserID(context.Background(), userID, &domain.AuditQuery{UserID: "someone else", Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, page.Events, 2)
+		assert.Equal(t, events[1].ID.Hex(), page.Next)
+
+		mockAuditRepository.AssertExpectations(t)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		mockAuditRepository := new(mocks.AuditRepository)
+		u := usecase.NewAuditUsecase(mockAuditRepository, time.Second*2)
+
+		mockAuditRepository.On("Fetch", mock.Anything, mock.Anything, int64(51)).Return(events, nil).Once()
+
+		page, err := u.FetchByUserID(context.Background(), userID, &domain.AuditQuery{})
+		assert.NoError(t, err)
+		assert.Len(t, page.Events, 3)
+		assert.Empty(t, page.Next)
+	})
+
+	t.Run("all events keep the user filter", func(t *testing.T) {
+		mockAuditRepository := new(mocks.AuditRepository)
+		u := usecase.NewAuditUsecase(mockAuditRepository, time.Second*2)
+
+		mockAuditRepository.On("Fetch", mock.Anything, &domain.AuditQuery{UserID: userID}, int64(51)).Return(events, nil).Once()
+
+		page, err := u.Fetch(context.Background(), &domain.AuditQuery{UserID: userID})
+		assert.NoError(t, err)
+		assert.Len(t, page.Events, 3)
+		mockAuditRepository.AssertExpectations(t)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		mockAuditRepository := new(mocks.AuditRepository)
+		u := usecase.NewAuditUsecase(mockAuditRepository, time.Second*2)
+
+		mockAuditRepository.On("Fetch", mock.Anything, mock.Anything, int64(51)).Return(nil, primitive.ErrInvalidHex).Once()
+
+		_, err := u.Fetch(context.Background(), &domain.AuditQuery{Before: "nope"})
+		assert.ErrorIs(t, err, domain.ErrInvalidAuditCursor)
+	})
+}
@@ -0,0 +1,383 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// dataExportClaimTimeout is how long an export may be running before another
+// worker takes it over.
+const dataExportClaimTimeout = 10 * time.Minute
+
+type dataExportUsecase struct {
+	dataExportRepository          domain.DataExportRepository
+	userRepository                domain.UserRepository
+	groupRepository               domain.GroupRepository
+	groupMemberRepository         domain.GroupMemberRepository
+	loanRepository                domain.LoanRepository
+	walletEntryRepository         domain.WalletEntryRepository
+	groupDebtRepository           domain.GroupDebtRepository
+	taskRepository                domain.TaskRepository
+	sessionRepository             domain.SessionRepository
+	personalAccessTokenRepository domain.PersonalAccessTokenRepository
+	auditRepository               domain.AuditRepository
+	fileStorage                   domain.FileStorage
+	mailer                        domain.Mailer
+	contextTimeout                time.Duration
+}
+
+func NewDataExportUsecase(dataExportRepository domain.DataExportRepository, userRepository domain.UserRepository, groupRepository domain.GroupRepository, groupMemberRepository domain.GroupMemberRepository, loanRepository domain.LoanRepository, walletEntryRepository domain.WalletEntryRepository, groupDebtRepository domain.GroupDebtRepository, taskRepository domain.TaskRepository, sessionRepository domain.SessionRepository, personalAccessTokenRepository domain.PersonalAccessTokenRepository, auditRepository domain.AuditRepository, fileStorage domain.FileStorage, mailer domain.Mailer, timeout time.Duration) domain.DataExportUsecase {
+	return &dataExportUsecase{
+		dataExportRepository:          dataExportRepository,
+		userRepository:                userRepository,
+		groupRepository:               groupRepository,
+		groupMemberRepository:         groupMemberRepository,
+		loanRepository:                loanRepository,
+		walletEntryRepository:         walletEntryRepository,
+		groupDebtRepository:           groupDebtRepository,
+		taskRepository:                taskRepository,
+		sessionRepository:             sessionRepository,
+		personalAccessTokenRepository: personalAccessTokenRepository,
+		auditRepository:               auditRepository,
+		fileStorage:                   fileStorage,
+		mailer:                        mailer,
+		contextTimeout:                timeout,
+	}
+}
+
+func (du *dataExportUsecase) RequestExport(c context.Context, userID string) (domain.DataExport, error) {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	defer cancel()
+
+	export, err := du.dataExportRepository.GetUnfinishedByUserID(ctx, userID)
+	if err == nil {
+		return export, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return domain.DataExport{}, err
+	}
+
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return domain.DataExport{}, err
+	}
+
+	export = domain.DataExport{
+		ID:        primitive.NewObjectID(),
+		UserID:    userObjectID,
+		Status:    domain.DataExportStatusPending,
+		CreatedAt: time.Now(),
+	}
+	err = du.dataExportRepository.Create(ctx, &export)
+	return export, err
+}
+
+func (du *dataExportUsecase) GetExport(c context.Context, userID string, exportID string) (domain.DataExport, error) {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	defer cancel()
+
+	return du.getExport(ctx, userID, exportID)
+}
+
+func (du *dataExportUsecase) OpenExport(c context.Context, userID string, exportID string) (io.ReadCloser, error) {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	defer cancel()
+
+	export, err := du.getExport(ctx, userID, exportID)
+	if err != nil {
+		return nil, err
+	}
+	if export.Status != domain.DataExportStatusReady {
+		return nil, domain.ErrDataExportNotReady
+	}
+
+	return du.fileStorage.Open(ctx, export.FileKey)
+}
+
+// getExport hides exports that expired but were not purged yet.
+func (du *dataExportUsecase) getExport(ctx context.Context, userID string, exportID string) (domain.DataExport, error) {
+	export, err := du.dataExportRepository.GetByID(ctx, exportID, userID)
+	if err == mongo.ErrNoDocuments || err == primitive.ErrInvalidHex {
+		return domain.DataExport{}, domain.ErrDataExportNotFound
+	}
+	if err != nil {
+		return domain.DataExport{}, err
+	}
+	if export.ExpiresAt != nil && !export.ExpiresAt.After(time.Now()) {
+		return domain.DataExport{}, domain.ErrDataExportNotFound
+	}
+
+	return export, nil
+}
+
+func (du *dataExportUsecase) ProcessPending(c context.Context, expiryHour int) error {
+	for {
+		processed, err := du.processNext(c, expiryHour)
+		if err != nil {
+			return err
+		}
+		if !processed {
+			break
+		}
+	}
+
+	return du.purgeExpired(c)
+}
+
+// processNext builds the archive of the next pending export. A failed build
+// is recorded on the export rather than returned, so that one broken export
+// does not hold up the others.
+func (du *dataExportUsecase) processNext(c context.Context, expiryHour int) (bool, error) {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	export, err := du.dataExportRepository.Claim(ctx, now, now.Add(-dataExportClaimTimeout))
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	userID := export.UserID.Hex()
+	status := domain.DataExportStatusReady
+	key, err := du.buildArchive(ctx, userID)
+	if err != nil {
+		log.Println("Data export failed: ", err)
+		status = domain.DataExportStatusFailed
+	}
+
+	completedAt := time.Now()
+	expiresAt := completedAt.Add(time.Duration(expiryHour) * time.Hour)
+	err = du.dataExportRepository.Complete(ctx, export.ID, status, key, completedAt, expiresAt)
+	if err != nil {
+		if key != "" {
+			_ = du.fileStorage.Delete(ctx, key)
+		}
+		return false, err
+	}
+
+	if status == domain.DataExportStatusReady {
+		du.notify(ctx, userID, expiresAt)
+	}
+	return true, nil
+}
+
+// notify does not report a failed send; the user can still check the status
+// of the export.
+func (du *dataExportUsecase) notify(ctx context.Context, userID string, expiresAt time.Time) {
+	user, err := du.userRepository.GetByID(ctx, userID)
+	if err != nil || user.Email == "" {
+		return
+	}
+
+	_ = du.mailer.Send(ctx, domain.MailMessage{
+		To:      user.Email,
+		Subject: "Your data export is ready",
+		Body:    fmt.Sprintf("Hi %s,\n\nThe export of your data is ready to download until %s.\n", user.Name, expiresAt.UTC().Format(time.RFC1123)),
+	})
+}
+
+func (du *dataExportUsecase) purgeExpired(c context.Context) error {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	defer cancel()
+
+	exports, err := du.dataExportRepository.FetchExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, export := range exports {
+		if export.FileKey != "" {
+			err = du.fileStorage.Delete(ctx, export.FileKey)
+			if err != nil {
+				return err
+			}
+		}
+		err = du.dataExportRepository.Delete(ctx, export.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type exportedProfile struct {
+	ID               string                    `json:"id"`
+	Name             string                    `json:"name"`
+	Email            string                    `json:"email"`
+	EmailVerifiedAt  *time.Time                `json:"emailVerifiedAt,omitempty"`
+	DisplayName      string                    `json:"displayName,omitempty"`
+	AvatarURL        string                    `json:"avatarURL,omitempty"`
+	Currency         string                    `json:"currency,omitempty"`
+	Locale           string                    `json:"locale,omitempty"`
+	Timezone         string                    `json:"timezone,omitempty"`
+	TwoFactorEnabled bool                      `json:"twoFactorEnabled"`
+	Identities       []domain.ExternalIdentity `json:"identities,omitempty"`
+}
+
+type exportedGroup struct {
+	domain.Group
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+// buildArchive writes a ZIP with one JSON file per kind of record and returns
+// its key in the file storage. Password hashes, 2FA secrets and token hashes
+// are left out.
+func (du *dataExportUsecase) buildArchive(ctx context.Context, userID string) (string, error) {
+	files, err := du.collect(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	for _, file := range files {
+		content, err := json.MarshalIndent(file.data, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		w, err := writer.Create(file.name)
+		if err != nil {
+			return "", err
+		}
+		_, err = w.Write(content)
+		if err != nil {
+			return "", err
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return "", err
+	}
+
+	suffix := make([]byte, 8)
+	_, err = rand.Read(suffix)
+	if err != nil {
+		return "", err
+	}
+	key := "exports/" + userID + "-" + hex.EncodeToString(suffix) + ".zip"
+
+	err = du.fileStorage.Save(ctx, key, "application/zip", &archive)
+	if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+type exportFile struct {
+	name string
+	data interface{}
+}
+
+func (du *dataExportUsecase) collect(ctx context.Context, userID string) ([]exportFile, error) {
+	user, err := du.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	profile := exportedProfile{
+		ID:               user.ID.Hex(),
+		Name:             user.Name,
+		Email:            user.Email,
+		EmailVerifiedAt:  user.EmailVerifiedAt,
+		DisplayName:      user.DisplayName,
+		Currency:         user.Currency,
+		Locale:           user.Locale,
+		Timezone:         user.Timezone,
+		TwoFactorEnabled: user.TwoFactor != nil && user.TwoFactor.EnabledAt != nil,
+		Identities:       user.Identities,
+	}
+	if user.AvatarKey != "" {
+		profile.AvatarURL = du.fileStorage.URL(user.AvatarKey)
+	}
+
+	members, err := du.groupMemberRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]exportedGroup, 0, len(members))
+	for _, member := range members {
+		group, err := du.groupRepository.GetByID(ctx, member.GroupID.Hex())
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, exportedGroup{Group: group, Role: member.Role, JoinedAt: member.CreatedAt})
+	}
+
+	// Expenses are paid from group wallets, payments are the contributions to
+	// and refunds from them.
+	entries, err := du.walletEntryRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	expenses := []domain.WalletEntry{}
+	payments := []domain.WalletEntry{}
+	for _, entry := range entries {
+		if entry.Type == domain.WalletEntryExpense {
+			expenses = append(expenses, entry)
+		} else {
+			payments = append(payments, entry)
+		}
+	}
+
+	debts, err := du.groupDebtRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loans, err := du.loanRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := du.taskRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := du.sessionRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := du.personalAccessTokenRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	auditEvents, err := du.auditRepository.Fetch(ctx, &domain.AuditQuery{UserID: userID}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return []exportFile{
+		{name: "profile.json", data: profile},
+		{name: "groups.json", data: groups},
+		{name: "expenses.json", data: expenses},
+		{name: "payments.json", data: payments},
+		{name: "debts.json", data: debts},
+		{name: "loans.json", data: loans},
+		{name: "tasks.json", data: tasks},
+		{name: "sessions.json", data: sessions},
+		{name: "personal_access_tokens.json", data: tokens},
+		{name: "audit_events.json", data: auditEvents},
+	}, nil
+}
@@ -0,0 +1,163 @@
+package usecase_test
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/fakeutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/storageutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestDataExport(t *testing.T) {
+	user := domain.User{
+		ID:       primitive.NewObjectID(),
+		Name:     "Test Name",
+		Email:    "test@gmail.com",
+		Password: "hash",
+	}
+	userID := user.ID.Hex()
+
+	type deps struct {
+		exports      *mocks.DataExportRepository
+		users        *mocks.UserRepository
+		groups       *mocks.GroupRepository
+		members      *mocks.GroupMemberRepository
+		loans        *mocks.LoanRepository
+		wallet       *mocks.WalletEntryRepository
+		debts        *mocks.GroupDebtRepository
+		tasks        *mocks.TaskRepository
+		sessions     *mocks.SessionRepository
+		accessTokens *mocks.PersonalAccessTokenRepository
+		audit        *mocks.AuditRepository
+		mailer       *fakeutil.Mailer
+		storageDir   string
+	}
+	newUsecase := func() (domain.DataExportUsecase, deps) {
+		d := deps{
+			exports:      new(mocks.DataExportRepository),
+			users:        new(mocks.UserRepository),
+			groups:       new(mocks.GroupRepository),
+			members:      new(mocks.GroupMemberRepository),
+			loans:        new(mocks.LoanRepository),
+			wallet:       new(mocks.WalletEntryRepository),
+			debts:        new(mocks.GroupDebtRepository),
+			tasks:        new(mocks.TaskRepository),
+			sessions:     new(mocks.SessionRepository),
+			accessTokens: new(mocks.PersonalAccessTokenRepository),
+			audit:        new(mocks.AuditRepository),
+			mailer:       fakeutil.NewMailer(),
+			storageDir:   t.TempDir(),
+		}
+		u := usecase.NewDataExportUsecase(d.exports, d.users, d.groups, d.members, d.loans, d.wallet, d.debts, d.tasks, d.sessions, d.accessTokens, d.audit, storageutil.NewLocalStorage(d.storageDir, "/uploads"), d.mailer, time.Second*2)
+		return u, d
+	}
+
+	t.Run("request export while one is pending", func(t *testing.T) {
+		u, d := newUsecase()
+		pending := domain.DataExport{ID: primitive.NewObjectID(), UserID: user.ID, Status: domain.DataExportStatusPending}
+		d.exports.On("GetUnfinishedByUserID", mock.Anything, userID).Return(pending, nil).Once()
+
+		export, err := u.RequestExport(context.Background(), userID)
+		assert.NoError(t, err)
+		assert.Equal(t, pending.ID, export.ID)
+
+		d.exports.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("process pending", func(t *testing.T) {
+		u, d := newUsecase()
+		export := domain.DataExport{ID: primitive.NewObjectID(), UserID: user.ID, Status: domain.DataExportStatusRunning}
+		group := domain.Group{ID: primitive.NewObjectID(), Name: "Trip"}
+		expense := domain.WalletEntry{ID: primitive.NewObjectID(), GroupID: group.ID, Type: domain.WalletEntryExpense, UserID: user.ID, Amount: 1200}
+
+		d.exports.On("Claim", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(export, nil).Once()
+		d.exports.On("Claim", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(domain.DataExport{}, mongo.ErrNoDocuments).Once()
+		d.users.On("GetByID", mock.Anything, userID).Return(user, nil)
+		d.members.On("FetchByUserID", mock.Anything, userID).Return([]domain.GroupMember{{GroupID: group.ID, UserID: user.ID, Role: domain.GroupRoleOwner}}, nil).Once()
+		d.groups.On("GetByID", mock.Anything, group.ID.Hex()).Return(group, nil).Once()
+		d.wallet.On("FetchByUserID", mock.Anything, userID).Return([]domain.WalletEntry{expense}, nil).Once()
+		d.debts.On("FetchByUserID", mock.Anything, userID).Return([]domain.GroupDebt{{GroupID: group.ID, DebtorID: user.ID, Amount: 700, Description: "Club fee"}}, nil).Once()
+		d.loans.On("FetchByUserID", mock.Anything, userID).Return([]domain.Loan{}, nil).Once()
+		d.tasks.On("FetchByUserID", mock.Anything, userID).Return([]domain.Task{}, nil).Once()
+		d.sessions.On("FetchByUserID", mock.Anything, userID).Return([]domain.Session{{ID: "sid", IP: "203.0.113.1"}}, nil).Once()
+		d.accessTokens.On("FetchByUserID", mock.Anything, userID).Return([]domain.PersonalAccessToken{}, nil).Once()
+		d.audit.On("Fetch", mock.Anything, &domain.AuditQuery{UserID: userID}, int64(0)).Return([]domain.AuditEvent{{Action: domain.AuditActionLogin, UserID: userID}}, nil).Once()
+
+		var key string
+		d.exports.On("Complete", mock.Anything, export.ID, domain.DataExportStatusReady, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Run(func(args mock.Arguments) {
+			key = args.String(3)
+		}).Return(nil).Once()
+
+		expired := domain.DataExport{ID: primitive.NewObjectID(), UserID: user.ID, FileKey: "exports/old.zip"}
+		old := filepath.Join(d.storageDir, "exports", "old.zip")
+		assert.NoError(t, os.MkdirAll(filepath.Dir(old), 0o755))
+		assert.NoError(t, os.WriteFile(old, []byte("zip"), 0o600))
+		d.exports.On("FetchExpired", mock.Anything, mock.AnythingOfType("time.Time")).Return([]domain.DataExport{expired}, nil).Once()
+		d.exports.On("Delete", mock.Anything, expired.ID).Return(nil).Once()
+
+		err := u.ProcessPending(context.Background(), 72)
+		assert.NoError(t, err)
+
+		archive, err := zip.OpenReader(filepath.Join(d.storageDir, filepath.FromSlash(key)))
+		assert.NoError(t, err)
+		defer archive.Close()
+		contents := map[string]string{}
+		for _, file := range archive.File {
+			r, err := file.Open()
+			assert.NoError(t, err)
+			content, err := io.ReadAll(r)
+			assert.NoError(t, err)
+			r.Close()
+			contents[file.Name] = string(content)
+		}
+		assert.Contains(t, contents["profile.json"], user.Email)
+		assert.NotContains(t, contents["profile.json"], user.Password)
+		assert.Contains(t, contents["groups.json"], "Trip")
+		assert.Contains(t, contents["expenses.json"], `"amount": 1200`)
+		assert.Equal(t, "[]", strings.TrimSpace(contents["payments.json"]))
+		assert.Contains(t, contents["debts.json"], "Club fee")
+		assert.Contains(t, contents["sessions.json"], "203.0.113.1")
+		assert.Contains(t, contents["audit_events.json"], domain.AuditActionLogin)
+
+		_, err = os.Stat(old)
+		assert.True(t, os.IsNotExist(err))
+
+		messages := d.mailer.Messages()
+		assert.Len(t, messages, 1)
+		assert.Equal(t, user.Email, messages[0].To)
+
+		d.exports.AssertExpectations(t)
+	})
+
+	t.Run("open an export that is not ready", func(t *testing.T) {
+		u, d := newUsecase()
+		export := domain.DataExport{ID: primitive.NewObjectID(), UserID: user.ID, Status: domain.DataExportStatusPending}
+		d.exports.On("GetByID", mock.Anything, export.ID.Hex(), userID).Return(export, nil).Once()
+
+		_, err := u.OpenExport(context.Background(), userID, export.ID.Hex())
+		assert.ErrorIs(t, err, domain.ErrDataExportNotReady)
+	})
+
+	t.Run("get an expired export", func(t *testing.T) {
+		u, d := newUsecase()
+		expiresAt := time.Now().Add(-time.Minute)
+		export := domain.DataExport{ID: primitive.NewObjectID(), UserID: user.ID, Status: domain.DataExportStatusReady, ExpiresAt: &expiresAt}
+		d.exports.On("GetByID", mock.Anything, export.ID.Hex(), userID).Return(export, nil).Once()
+
+		_, err := u.GetExport(context.Background(), userID, export.ID.Hex())
+		assert.ErrorIs(t, err, domain.ErrDataExportNotFound)
+	})
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type emailVerificationUsecase struct {
+	userRepository      domain.UserRepository
+	userTokenRepository domain.UserTokenRepository
+	mailer              domain.Mailer
+	contextTimeout      time.Duration
+}
+
+func NewEmailVerificationUsecase(userRepository domain.UserRepository, userTokenRepository domain.UserTokenRepository, mailer domain.Mailer, timeout time.Duration) domain.EmailVerificationUsecase {
+	return &emailVerificationUsecase{
+		userRepository:      userRepository,
+		userTokenRepository: userTokenRepository,
+		mailer:              mailer,
+		contextTimeout:      timeout,
+	}
+}
+
+func (eu *emailVerificationUsecase) SendVerification(c context.Context, user *domain.User, verifyURL string, expiryMinute int) error {
+	ctx, cancel := context.WithTimeout(c, eu.contextTimeout)
+	defer cancel()
+	return eu.sendVerification(ctx, user, verifyURL, expiryMinute)
+}
+
+// Resend allows limitPerHour links per user and hour, counting the one sent
+// at signup.
+func (eu *emailVerificationUsecase) Resend(c context.Context, userID string, verifyURL string, expiryMinute int, limitPerHour int) error {
+	ctx, cancel := context.WithTimeout(c, eu.contextTimeout)
+	defer cancel()
+
+	user, err := eu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.EmailVerifiedAt != nil && user.PendingEmail == "" {
+		return domain.ErrEmailAlreadyVerified
+	}
+
+	count, err := eu.userTokenRepository.CountSince(ctx, userID, domain.UserTokenPurposeEmailVerification, time.Now().Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	if count >= int64(limitPerHour) {
+		return domain.ErrTooManyRequests
+	}
+
+	return eu.sendVerification(ctx, &user, verifyURL, expiryMinute)
+}
+
+func (eu *emailVerificationUsecase) Verify(c context.Context, token string) error {
+	ctx, cancel := context.WithTimeout(c, eu.contextTimeout)
+	defer cancel()
+
+	userToken, err := eu.userTokenRepository.Consume(ctx, domain.UserTokenPurposeEmailVerification, tokenutil.HashOpaqueToken(token))
+	if err == mongo.ErrNoDocuments {
+		return domain.ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = eu.userRepository.MarkEmailVerified(ctx, userToken.UserID.Hex(), userToken.Email, now)
+	if err == mongo.ErrNoDocuments {
+		err = eu.userRepository.ConfirmEmail(ctx, userToken.UserID.Hex(), userToken.Email, now)
+	}
+	if err == mongo.ErrNoDocuments {
+		return domain.ErrInvalidVerificationToken
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrEmailTaken
+	}
+
+	return err
+}
+
+// sendVerification sends the link to the pending email of the user if they
+// have one, to their email otherwise.
+func (eu *emailVerificationUsecase) sendVerification(ctx context.Context, user *domain.User, verifyURL string, expiryMinute int) error {
+	email := user.Email
+	if user.PendingEmail != "" {
+		email = user.PendingEmail
+	}
+
+	token, hash, err := tokenutil.NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	userToken := domain.UserToken{
+		ID:        primitive.NewObjectID(),
+		UserID:    user.ID,
+		Purpose:   domain.UserTokenPurposeEmailVerification,
+		Email:     email,
+		TokenHash: hash,
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Minute * time.Duration(expiryMinute)),
+	}
+	err = eu.userTokenRepository.Create(ctx, &userToken)
+	if err != nil {
+		return err
+	}
+
+	link := verifyURL + "?token=" + url.QueryEscape(token)
+	return eu.mailer.Send(ctx, domain.MailMessage{
+		To:      email,
+		Subject: "Verify your email",
+		Body:    fmt.Sprintf("Hi %s,\n\nPlease confirm your email address with the link below.\n\n%s\n", user.Name, link),
+	})
+}
@@ -0,0 +1,154 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/fakeutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestEmailVerification(t *testing.T) {
+	user := domain.User{
+		ID:    primitive.NewObjectID(),
+		Name:  "Test Name",
+		Email: "test@gmail.com",
+	}
+	userID := user.ID.Hex()
+
+	t.Run("resend", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mailer := fakeutil.NewMailer()
+
+		mockUserRepository.On("GetByID", mock.Anything, userID).Return(user, nil).Once()
+		mockUserTokenRepository.On("CountSince", mock.Anything, userID, domain.UserTokenPurposeEmailVerification, mock.AnythingOfType("time.Time")).Return(int64(1), nil).Once()
+		mockUserTokenRepository.On("Create", mock.Anything, mock.MatchedBy(func(token *domain.UserToken) bool {
+			return token.Purpose == domain.UserTokenPurposeEmailVerification && token.Email == user.Email
+		})).Return(nil).Once()
+
+		u := usecase.NewEmailVerificationUsecase(mockUserRepository, mockUserTokenRepository, mailer, time.Second*2)
+
+		err := u.Resend(context.Background(), userID, "http://localhost/verify", 60, 3)
+
+		assert.NoError(t, err)
+		assert.Len(t, mailer.Messages(), 1)
+
+		mockUserRepository.AssertExpectations(t)
+		mockUserTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("resend limit", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mailer := fakeutil.NewMailer()
+
+		mockUserRepository.On("GetByID", mock.Anything, userID).Return(user, nil).Once()
+		mockUserTokenRepository.On("CountSince", mock.Anything, userID, domain.UserTokenPurposeEmailVerification, mock.AnythingOfType("time.Time")).Return(int64(3), nil).Once()
+
+		u := usecase.NewEmailVerificationUsecase(mockUserRepository, mockUserTokenRepository, mailer, time.Second*2)
+
+		err := u.Resend(context.Background(), userID, "http://localhost/verify", 60, 3)
+
+		assert.ErrorIs(t, err, domain.ErrTooManyRequests)
+		assert.Empty(t, mailer.Messages())
+	})
+
+	t.Run("already verified", func(t *testing.T) {
+		verifiedAt := time.Now()
+		verified := user
+		verified.EmailVerifiedAt = &verifiedAt
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByID", mock.Anything, userID).Return(verified, nil).Once()
+
+		u := usecase.NewEmailVerificationUsecase(mockUserRepository, new(mocks.UserTokenRepository), fakeutil.NewMailer(), time.Second*2)
+
+		err := u.Resend(context.Background(), userID, "http://localhost/verify", 60, 3)
+
+		assert.ErrorIs(t, err, domain.ErrEmailAlreadyVerified)
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+
+		userToken := domain.UserToken{UserID: user.ID, Email: user.Email}
+		mockUserTokenRepository.On("Consume", mock.Anything, domain.UserTokenPurposeEmailVerification, tokenutil.HashOpaqueToken("token")).Return(userToken, nil).Once()
+		mockUserRepository.On("MarkEmailVerified", mock.Anything, userID, user.Email, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		u := usecase.NewEmailVerificationUsecase(mockUserRepository, mockUserTokenRepository, fakeutil.NewMailer(), time.Second*2)
+
+		err := u.Verify(context.Background(), "token")
+
+		assert.NoError(t, err)
+
+		mockUserRepository.AssertExpectations(t)
+		mockUserTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("verify changed email", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+
+		userToken := domain.UserToken{UserID: user.ID, Email: "old@gmail.com"}
+		mockUserTokenRepository.On("Consume", mock.Anything, domain.UserTokenPurposeEmailVerification, tokenutil.HashOpaqueToken("token")).Return(userToken, nil).Once()
+		mockUserRepository.On("MarkEmailVerified", mock.Anything, userID, "old@gmail.com", mock.AnythingOfType("time.Time")).Return(mongo.ErrNoDocuments).Once()
+		mockUserRepository.On("ConfirmEmail", mock.Anything, userID, "old@gmail.com", mock.AnythingOfType("time.Time")).Return(mongo.ErrNoDocuments).Once()
+
+		u := usecase.NewEmailVerificationUsecase(mockUserRepository, mockUserTokenRepository, fakeutil.NewMailer(), time.Second*2)
+
+		err := u.Verify(context.Background(), "token")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidVerificationToken)
+	})
+
+	t.Run("verify pending email", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+
+		userToken := domain.UserToken{UserID: user.ID, Email: "new@gmail.com"}
+		mockUserTokenRepository.On("Consume", mock.Anything, domain.UserTokenPurposeEmailVerification, tokenutil.HashOpaqueToken("token")).Return(userToken, nil).Once()
+		mockUserRepository.On("MarkEmailVerified", mock.Anything, userID, "new@gmail.com", mock.AnythingOfType("time.Time")).Return(mongo.ErrNoDocuments).Once()
+		mockUserRepository.On("ConfirmEmail", mock.Anything, userID, "new@gmail.com", mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		u := usecase.NewEmailVerificationUsecase(mockUserRepository, mockUserTokenRepository, fakeutil.NewMailer(), time.Second*2)
+
+		err := u.Verify(context.Background(), "token")
+
+		assert.NoError(t, err)
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("resend to pending email", func(t *testing.T) {
+		verifiedAt := time.Now()
+		changing := user
+		changing.EmailVerifiedAt = &verifiedAt
+		changing.PendingEmail = "new@gmail.com"
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByID", mock.Anything, userID).Return(changing, nil).Once()
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockUserTokenRepository.On("CountSince", mock.Anything, userID, domain.UserTokenPurposeEmailVerification, mock.AnythingOfType("time.Time")).Return(int64(0), nil).Once()
+		mockUserTokenRepository.On("Create", mock.Anything, mock.MatchedBy(func(token *domain.UserToken) bool {
+			return token.Email == "new@gmail.com"
+		})).Return(nil).Once()
+		mailer := fakeutil.NewMailer()
+
+		u := usecase.NewEmailVerificationUsecase(mockUserRepository, mockUserTokenRepository, mailer, time.Second*2)
+
+		err := u.Resend(context.Background(), userID, "http://localhost/verify", 60, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new@gmail.com", mailer.Messages()[0].To)
+		mockUserTokenRepository.AssertExpectations(t)
+	})
+}
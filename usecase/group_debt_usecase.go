@@ -0,0 +1,340 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/debtutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type groupDebtUsecase struct {
+	groupDebtRepository   domain.GroupDebtRepository
+	groupLedgerRepository domain.GroupLedgerRepository
+	groupRepository       domain.GroupRepository
+	groupMemberRepository domain.GroupMemberRepository
+	outboxRepository      domain.OutboxRepository
+	transactor            domain.Transactor
+	contextTimeout        time.Duration
+}
+
+func NewGroupDebtUsecase(groupDebtRepository domain.GroupDebtRepository, groupLedgerRepository domain.GroupLedgerRepository, groupRepository domain.GroupRepository, groupMemberRepository domain.GroupMemberRepository, outboxRepository domain.OutboxRepository, transactor domain.Transactor, timeout time.Duration) domain.GroupDebtUsecase {
+	return &groupDebtUsecase{
+		groupDebtRepository:   groupDebtRepository,
+		groupLedgerRepository: groupLedgerRepository,
+		groupRepository:       groupRepository,
+		groupMemberRepository: groupMemberRepository,
+		outboxRepository:      outboxRepository,
+		transactor:            transactor,
+		contextTimeout:        timeout,
+	}
+}
+
+// Create records a debt between two members of the group, as an expense the
+// creditor paid for the debtor.
+func (du *groupDebtUsecase) Create(c context.Context, actor *domain.GroupMember, request *domain.GroupDebtRequest) (domain.GroupDebt, error) {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	defer cancel()
+
+	group, err := du.groupRepository.GetByID(ctx, actor.GroupID.Hex())
+	if err == mongo.ErrNoDocuments {
+		return domain.GroupDebt{}, domain.ErrGroupNotFound
+	}
+	if err != nil {
+		return domain.GroupDebt{}, err
+	}
+	if group.ClosedAt != nil {
+		return domain.GroupDebt{}, domain.ErrGroupClosed
+	}
+
+	if request.DebtorID == request.CreditorID {
+		return domain.GroupDebt{}, domain.ErrGroupDebtSelf
+	}
+	debtor, err := du.getParticipant(ctx, actor.GroupID.Hex(), request.DebtorID)
+	if err != nil {
+		return domain.GroupDebt{}, err
+	}
+	creditor, err := du.getParticipant(ctx, actor.GroupID.Hex(), request.CreditorID)
+	if err != nil {
+		return domain.GroupDebt{}, err
+	}
+
+	debt := domain.GroupDebt{
+		ID:          primitive.NewObjectID(),
+		GroupID:     actor.GroupID,
+		DebtorID:    debtor.UserID,
+		CreditorID:  creditor.UserID,
+		Amount:      request.Amount,
+		Currency:    request.Currency,
+		Description: request.Description,
+		Balance:     request.Amount,
+		CreatedBy:   actor.UserID,
+		CreatedAt:   time.Now(),
+	}
+	if !request.DueDate.IsZero() {
+		dueDate := request.DueDate.UTC()
+		debt.DueDate = &dueDate
+	}
+	entry := domain.GroupLedgerEntry{
+		ID:        primitive.NewObjectID(),
+		GroupID:   debt.GroupID,
+		DebtID:    debt.ID,
+		Type:      domain.GroupLedgerDebt,
+		Amount:    debt.Amount,
+		CreatedBy: &actor.UserID,
+		CreatedAt: debt.CreatedAt,
+	}
+	event, err := domain.NewDomainEvent(domain.ExpenseAdded{
+		GroupID:   debt.GroupID.Hex(),
+		ExpenseID: debt.ID.Hex(),
+		PaidBy:    debt.CreditorID.Hex(),
+		Amount:    debt.Amount,
+		Currency:  debt.Currency,
+	})
+	if err != nil {
+		return domain.GroupDebt{}, err
+	}
+
+	err = du.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := du.groupDebtRepository.Create(ctx, &debt)
+		if err != nil {
+			return err
+		}
+		err = du.groupLedgerRepository.Create(ctx, entry)
+		if err != nil {
+			return err
+		}
+		return du.outboxRepository.Add(ctx, event)
+	})
+	if err != nil {
+		return domain.GroupDebt{}, err
+	}
+
+	return debt, nil
+}
+
+func (du *groupDebtUsecase) FetchByGroupID(c context.Context, groupID string) ([]domain.GroupDebt, error) {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	defer cancel()
+	return du.groupDebtRepository.FetchByGroupID(ctx, groupID)
+}
+
+func (du *groupDebtUsecase) FetchLedger(c context.Context, groupID string) ([]domain.GroupLedgerEntry, error) {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	defer cancel()
+	return du.groupLedgerRepository.FetchByGroupID(ctx, groupID)
+}
+
+func (du *groupDebtUsecase) RecordPayment(c context.Context, actor *domain.GroupMember, debtID string, amount int64) (domain.GroupDebt, error) {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	defer cancel()
+
+	debt, err := du.getDebt(ctx, actor.GroupID.Hex(), debtID)
+	if err != nil {
+		return domain.GroupDebt{}, err
+	}
+	if actor.UserID != debt.CreditorID {
+		return domain.GroupDebt{}, domain.ErrGroupDebtNotCreditor
+	}
+	if amount > debt.Balance {
+		return domain.GroupDebt{}, domain.ErrGroupPaymentExceedsBalance
+	}
+
+	now := time.Now()
+	debt.Paid += amount
+	debt.Balance -= amount
+	if debt.Balance == 0 {
+		debt.SettledAt = &now
+	}
+	entry := domain.GroupLedgerEntry{
+		ID:        primitive.NewObjectID(),
+		GroupID:   debt.GroupID,
+		DebtID:    debt.ID,
+		Type:      domain.GroupLedgerPayment,
+		Amount:    -amount,
+		CreatedBy: &actor.UserID,
+		CreatedAt: now,
+	}
+	event, err := domain.NewDomainEvent(domain.PaymentRecorded{
+		GroupID:    debt.GroupID.Hex(),
+		PaymentID:  entry.ID.Hex(),
+		FromUserID: debt.DebtorID.Hex(),
+		ToUserID:   debt.CreditorID.Hex(),
+		Amount:     amount,
+		Currency:   debt.Currency,
+	})
+	if err != nil {
+		return domain.GroupDebt{}, err
+	}
+
+	err = du.updateDebt(ctx, &debt, entry, event)
+	if err != nil {
+		return domain.GroupDebt{}, err
+	}
+
+	return debt, nil
+}
+
+func (du *groupDebtUsecase) WaiveCharge(c context.Context, actor *domain.GroupMember, debtID string, entryID string) (domain.GroupDebt, error) {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	defer cancel()
+
+	if !domain.GroupRoleAllows(actor.Role, domain.GroupPermissionManageMembers) {
+		return domain.GroupDebt{}, domain.ErrForbidden
+	}
+
+	debt, err := du.getDebt(ctx, actor.GroupID.Hex(), debtID)
+	if err != nil {
+		return domain.GroupDebt{}, err
+	}
+	chargeID, err := primitive.ObjectIDFromHex(entryID)
+	if err != nil {
+		return domain.GroupDebt{}, domain.ErrGroupChargeNotFound
+	}
+
+	entries, err := du.groupLedgerRepository.FetchByDebtID(ctx, debt.ID)
+	if err != nil {
+		return domain.GroupDebt{}, err
+	}
+	var charge *domain.GroupLedgerEntry
+	for i := range entries {
+		if entries[i].ID == chargeID && entries[i].Type == domain.GroupLedgerLateFee {
+			charge = &entries[i]
+		}
+		if entries[i].Type == domain.GroupLedgerWaiver && entries[i].EntryID != nil && *entries[i].EntryID == chargeID {
+			return domain.GroupDebt{}, domain.ErrGroupChargeWaived
+		}
+	}
+	if charge == nil {
+		return domain.GroupDebt{}, domain.ErrGroupChargeNotFound
+	}
+	// Payments cover the charges too, so a charge larger than what is left
+	// has been paid at least in part.
+	if charge.Amount > debt.Balance {
+		return domain.GroupDebt{}, domain.ErrGroupChargePaid
+	}
+
+	now := time.Now()
+	debt.Charges -= charge.Amount
+	debt.Balance -= charge.Amount
+	if debt.Balance == 0 {
+		debt.SettledAt = &now
+	}
+	waiver := domain.GroupLedgerEntry{
+		ID:        primitive.NewObjectID(),
+		GroupID:   debt.GroupID,
+		DebtID:    debt.ID,
+		Type:      domain.GroupLedgerWaiver,
+		Amount:    -charge.Amount,
+		EntryID:   &charge.ID,
+		CreatedBy: &actor.UserID,
+		CreatedAt: now,
+	}
+
+	err = du.updateDebt(ctx, &debt, waiver)
+	if err != nil {
+		return domain.GroupDebt{}, err
+	}
+
+	return debt, nil
+}
+
+// ApplyLateFees gives every group and every debt its own timeout, so that a
+// long run does not fail the items at its end. A debt that changed since it
+// was read is charged on the next run.
+func (du *groupDebtUsecase) ApplyLateFees(c context.Context, now time.Time) error {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	groups, err := du.groupRepository.FetchWithLateFee(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for i := range groups {
+		err := du.applyGroupLateFees(c, &groups[i], now)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (du *groupDebtUsecase) applyGroupLateFees(c context.Context, group *domain.Group, now time.Time) error {
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	debts, err := du.groupDebtRepository.FetchOverdue(ctx, group.ID, now.AddDate(0, 0, -group.LateFee.GraceDays))
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for i := range debts {
+		err := du.applyLateFee(c, group.LateFee, &debts[i], now)
+		if err != nil && err != domain.ErrGroupDebtChanged && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (du *groupDebtUsecase) applyLateFee(c context.Context, policy *domain.LateFeePolicy, debt *domain.GroupDebt, now time.Time) error {
+	amount, through := debtutil.LateFee(policy, debt, now)
+	if amount == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c, du.contextTimeout)
+	defer cancel()
+
+	debt.Charges += amount
+	debt.Balance += amount
+	debt.ChargedThrough = &through
+	entry := domain.GroupLedgerEntry{
+		ID:        primitive.NewObjectID(),
+		GroupID:   debt.GroupID,
+		DebtID:    debt.ID,
+		Type:      domain.GroupLedgerLateFee,
+		Amount:    amount,
+		Through:   &through,
+		CreatedAt: now,
+	}
+
+	return du.updateDebt(ctx, debt, entry)
+}
+
+// updateDebt stores the debt, the ledger entry and the events in one
+// transaction.
+func (du *groupDebtUsecase) updateDebt(ctx context.Context, debt *domain.GroupDebt, entry domain.GroupLedgerEntry, events ...domain.DomainEvent) error {
+	return du.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := du.groupDebtRepository.Update(ctx, debt)
+		if err != nil {
+			return err
+		}
+		err = du.groupLedgerRepository.Create(ctx, entry)
+		if err != nil || len(events) == 0 {
+			return err
+		}
+		return du.outboxRepository.Add(ctx, events...)
+	})
+}
+
+func (du *groupDebtUsecase) getDebt(ctx context.Context, groupID string, debtID string) (domain.GroupDebt, error) {
+	debt, err := du.groupDebtRepository.GetByID(ctx, groupID, debtID)
+	if err == mongo.ErrNoDocuments || err == primitive.ErrInvalidHex {
+		return debt, domain.ErrGroupDebtNotFound
+	}
+	return debt, err
+}
+
+func (du *groupDebtUsecase) getParticipant(ctx context.Context, groupID string, userID string) (domain.GroupMember, error) {
+	member, err := du.groupMemberRepository.GetByGroupAndUser(ctx, groupID, userID)
+	if err == mongo.ErrNoDocuments || err == primitive.ErrInvalidHex {
+		return member, domain.ErrGroupDebtParticipant
+	}
+	return member, err
+}
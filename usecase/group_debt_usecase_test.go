@@ -0,0 +1,204 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/fakeutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestGroupDebt(t *testing.T) {
+	groupID := primitive.NewObjectID()
+	admin := domain.GroupMember{GroupID: groupID, UserID: primitive.NewObjectID(), Role: domain.GroupRoleAdmin}
+	debtor := domain.GroupMember{GroupID: groupID, UserID: primitive.NewObjectID(), Role: domain.GroupRoleMember}
+	creditor := domain.GroupMember{GroupID: groupID, UserID: primitive.NewObjectID(), Role: domain.GroupRoleMember}
+	dueDate := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+	newDebt := func() domain.GroupDebt {
+		return domain.GroupDebt{ID: primitive.NewObjectID(), GroupID: groupID, DebtorID: debtor.UserID, CreditorID: creditor.UserID, Amount: 100, Currency: "EUR", Balance: 100, DueDate: &dueDate}
+	}
+
+	memberRepository := new(mocks.GroupMemberRepository)
+	for _, m := range []domain.GroupMember{admin, debtor, creditor} {
+		memberRepository.On("GetByGroupAndUser", mock.Anything, groupID.Hex(), m.UserID.Hex()).Return(m, nil).Maybe()
+	}
+	memberRepository.On("GetByGroupAndUser", mock.Anything, groupID.Hex(), mock.Anything).Return(domain.GroupMember{}, mongo.ErrNoDocuments).Maybe()
+
+	groupRepository := new(mocks.GroupRepository)
+	groupRepository.On("GetByID", mock.Anything, groupID.Hex()).Return(domain.Group{ID: groupID}, nil).Maybe()
+
+	newUsecase := func(debtRepository *mocks.GroupDebtRepository, ledgerRepository *mocks.GroupLedgerRepository, outboxRepository *mocks.OutboxRepository) domain.GroupDebtUsecase {
+		return usecase.NewGroupDebtUsecase(debtRepository, ledgerRepository, groupRepository, memberRepository, outboxRepository, fakeutil.NewTransactor(), time.Second*2)
+	}
+
+	t.Run("create", func(t *testing.T) {
+		debtRepository := new(mocks.GroupDebtRepository)
+		debtRepository.On("Create", mock.Anything, mock.AnythingOfType("*domain.GroupDebt")).Return(nil).Once()
+		ledgerRepository := new(mocks.GroupLedgerRepository)
+		ledgerRepository.On("Create", mock.Anything, mock.MatchedBy(func(entry domain.GroupLedgerEntry) bool {
+			return entry.Type == domain.GroupLedgerDebt && entry.Amount == 100
+		})).Return(nil).Once()
+		outboxRepository := new(mocks.OutboxRepository)
+		outboxRepository.On("Add", mock.Anything, mock.MatchedBy(func(event domain.DomainEvent) bool {
+			var expense domain.ExpenseAdded
+			return event.DecodePayload(&expense) == nil && expense.PaidBy == creditor.UserID.Hex()
+		})).Return(nil).Once()
+
+		u := newUsecase(debtRepository, ledgerRepository, outboxRepository)
+
+		debt, err := u.Create(context.Background(), &admin, &domain.GroupDebtRequest{DebtorID: debtor.UserID.Hex(), CreditorID: creditor.UserID.Hex(), Amount: 100, Currency: "EUR", Description: "Rent", DueDate: dueDate})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), debt.Balance)
+		assert.Equal(t, dueDate, *debt.DueDate)
+		debtRepository.AssertExpectations(t)
+		ledgerRepository.AssertExpectations(t)
+		outboxRepository.AssertExpectations(t)
+	})
+
+	t.Run("create in closed group", func(t *testing.T) {
+		closedAt := time.Now()
+		closedGroupRepository := new(mocks.GroupRepository)
+		closedGroupRepository.On("GetByID", mock.Anything, groupID.Hex()).Return(domain.Group{ID: groupID, ClosedAt: &closedAt}, nil).Once()
+
+		u := usecase.NewGroupDebtUsecase(new(mocks.GroupDebtRepository), new(mocks.GroupLedgerRepository), closedGroupRepository, memberRepository, new(mocks.OutboxRepository), fakeutil.NewTransactor(), time.Second*2)
+
+		_, err := u.Create(context.Background(), &admin, &domain.GroupDebtRequest{DebtorID: debtor.UserID.Hex(), CreditorID: creditor.UserID.Hex(), Amount: 100, Currency: "EUR", Description: "Rent"})
+		assert.ErrorIs(t, err, domain.ErrGroupClosed)
+	})
+
+	t.Run("create with non-member", func(t *testing.T) {
+		u := newUsecase(new(mocks.GroupDebtRepository), new(mocks.GroupLedgerRepository), new(mocks.OutboxRepository))
+
+		_, err := u.Create(context.Background(), &admin, &domain.GroupDebtRequest{DebtorID: primitive.NewObjectID().Hex(), CreditorID: creditor.UserID.Hex(), Amount: 100, Currency: "EUR", Description: "Rent"})
+		assert.ErrorIs(t, err, domain.ErrGroupDebtParticipant)
+
+		_, err = u.Create(context.Background(), &admin, &domain.GroupDebtRequest{DebtorID: debtor.UserID.Hex(), CreditorID: debtor.UserID.Hex(), Amount: 100, Currency: "EUR", Description: "Rent"})
+		assert.ErrorIs(t, err, domain.ErrGroupDebtSelf)
+	})
+
+	t.Run("record payment", func(t *testing.T) {
+		debt := newDebt()
+		debtRepository := new(mocks.GroupDebtRepository)
+		debtRepository.On("GetByID", mock.Anything, groupID.Hex(), debt.ID.Hex()).Return(debt, nil)
+		debtRepository.On("Update", mock.Anything, mock.MatchedBy(func(debt *domain.GroupDebt) bool {
+			return debt.Paid == 100 && debt.Balance == 0 && debt.SettledAt != nil
+		})).Return(nil).Once()
+		ledgerRepository := new(mocks.GroupLedgerRepository)
+		ledgerRepository.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+		outboxRepository := new(mocks.OutboxRepository)
+		outboxRepository.On("Add", mock.Anything, mock.MatchedBy(func(event domain.DomainEvent) bool {
+			var payment domain.PaymentRecorded
+			return event.DecodePayload(&payment) == nil && payment.FromUserID == debtor.UserID.Hex() && payment.ToUserID == creditor.UserID.Hex() && payment.Amount == 100
+		})).Return(nil).Once()
+
+		u := newUsecase(debtRepository, ledgerRepository, outboxRepository)
+
+		_, err := u.RecordPayment(context.Background(), &debtor, debt.ID.Hex(), 100)
+		assert.ErrorIs(t, err, domain.ErrGroupDebtNotCreditor)
+
+		_, err = u.RecordPayment(context.Background(), &creditor, debt.ID.Hex(), 101)
+		assert.ErrorIs(t, err, domain.ErrGroupPaymentExceedsBalance)
+
+		_, err = u.RecordPayment(context.Background(), &creditor, debt.ID.Hex(), 100)
+		assert.NoError(t, err)
+		debtRepository.AssertExpectations(t)
+		outboxRepository.AssertExpectations(t)
+	})
+
+	t.Run("waive charge", func(t *testing.T) {
+		debt := newDebt()
+		debt.Charges = 25
+		debt.Balance = 125
+		charge := domain.GroupLedgerEntry{ID: primitive.NewObjectID(), DebtID: debt.ID, Type: domain.GroupLedgerLateFee, Amount: 25}
+		debtRepository := new(mocks.GroupDebtRepository)
+		debtRepository.On("GetByID", mock.Anything, groupID.Hex(), debt.ID.Hex()).Return(debt, nil)
+		debtRepository.On("Update", mock.Anything, mock.MatchedBy(func(debt *domain.GroupDebt) bool {
+			return debt.Charges == 0 && debt.Balance == 100
+		})).Return(nil).Once()
+		ledgerRepository := new(mocks.GroupLedgerRepository)
+		ledgerRepository.On("FetchByDebtID", mock.Anything, debt.ID).Return([]domain.GroupLedgerEntry{charge}, nil).Once()
+		ledgerRepository.On("Create", mock.Anything, mock.MatchedBy(func(entry domain.GroupLedgerEntry) bool {
+			return entry.Type == domain.GroupLedgerWaiver && entry.Amount == -25 && *entry.EntryID == charge.ID && *entry.CreatedBy == admin.UserID
+		})).Return(nil).Once()
+
+		u := newUsecase(debtRepository, ledgerRepository, new(mocks.OutboxRepository))
+
+		_, err := u.WaiveCharge(context.Background(), &creditor, debt.ID.Hex(), charge.ID.Hex())
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+
+		waived, err := u.WaiveCharge(context.Background(), &admin, debt.ID.Hex(), charge.ID.Hex())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), waived.Balance)
+		debtRepository.AssertExpectations(t)
+		ledgerRepository.AssertExpectations(t)
+	})
+
+	t.Run("waive charge twice", func(t *testing.T) {
+		debt := newDebt()
+		charge := domain.GroupLedgerEntry{ID: primitive.NewObjectID(), DebtID: debt.ID, Type: domain.GroupLedgerLateFee, Amount: 25}
+		waiver := domain.GroupLedgerEntry{ID: primitive.NewObjectID(), DebtID: debt.ID, Type: domain.GroupLedgerWaiver, Amount: -25, EntryID: &charge.ID}
+		debtRepository := new(mocks.GroupDebtRepository)
+		debtRepository.On("GetByID", mock.Anything, groupID.Hex(), debt.ID.Hex()).Return(debt, nil)
+		ledgerRepository := new(mocks.GroupLedgerRepository)
+		ledgerRepository.On("FetchByDebtID", mock.Anything, debt.ID).Return([]domain.GroupLedgerEntry{charge, waiver}, nil).Once()
+
+		u := newUsecase(debtRepository, ledgerRepository, new(mocks.OutboxRepository))
+
+		_, err := u.WaiveCharge(context.Background(), &admin, debt.ID.Hex(), charge.ID.Hex())
+		assert.ErrorIs(t, err, domain.ErrGroupChargeWaived)
+	})
+}
+
+func TestGroupDebtApplyLateFees(t *testing.T) {
+	now := time.Date(2026, time.February, 10, 0, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+	policy := domain.LateFeePolicy{Type: domain.LateFeeFlat, Amount: 25, GraceDays: 3}
+	failing := domain.Group{ID: primitive.NewObjectID(), LateFee: &policy}
+	group := domain.Group{ID: primitive.NewObjectID(), LateFee: &policy}
+	changed := domain.GroupDebt{ID: primitive.NewObjectID(), GroupID: group.ID, Amount: 100, Balance: 100, DueDate: &dueDate}
+	overdue := domain.GroupDebt{ID: primitive.NewObjectID(), GroupID: group.ID, Amount: 100, Balance: 100, DueDate: &dueDate}
+	fetchErr := errors.New("fetch failed")
+
+	groupRepository := new(mocks.GroupRepository)
+	groupRepository.On("FetchWithLateFee", mock.Anything).Return([]domain.Group{failing, group}, nil).Once()
+
+	// Every debt is charged with a deadline of its own.
+	hasDeadline := mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := ctx.Deadline()
+		return ok
+	})
+
+	dueBefore := now.AddDate(0, 0, -3)
+	debtRepository := new(mocks.GroupDebtRepository)
+	debtRepository.On("FetchOverdue", mock.Anything, failing.ID, dueBefore).Return(nil, fetchErr).Once()
+	debtRepository.On("FetchOverdue", mock.Anything, group.ID, dueBefore).Return([]domain.GroupDebt{changed, overdue}, nil).Once()
+	debtRepository.On("Update", hasDeadline, mock.MatchedBy(func(debt *domain.GroupDebt) bool {
+		return debt.ID == changed.ID
+	})).Return(domain.ErrGroupDebtChanged).Once()
+	debtRepository.On("Update", hasDeadline, mock.MatchedBy(func(debt *domain.GroupDebt) bool {
+		return debt.ID == overdue.ID && debt.Charges == 25 && debt.Balance == 125 && debt.ChargedThrough != nil
+	})).Return(nil).Once()
+	ledgerRepository := new(mocks.GroupLedgerRepository)
+	ledgerRepository.On("Create", mock.Anything, mock.MatchedBy(func(entry domain.GroupLedgerEntry) bool {
+		return entry.Type == domain.GroupLedgerLateFee && entry.DebtID == overdue.ID && entry.Amount == 25 && entry.CreatedBy == nil
+	})).Return(nil).Once()
+
+	u := usecase.NewGroupDebtUsecase(debtRepository, ledgerRepository, groupRepository, new(mocks.GroupMemberRepository), new(mocks.OutboxRepository), fakeutil.NewTransactor(), time.Second*2)
+
+	err := u.ApplyLateFees(context.Background(), now)
+
+	// The failing group does not stop the others from being charged.
+	assert.ErrorIs(t, err, fetchErr)
+	groupRepository.AssertExpectations(t)
+	debtRepository.AssertExpectations(t)
+	ledgerRepository.AssertExpectations(t)
+}
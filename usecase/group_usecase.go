@@ -0,0 +1,358 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/walletutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type groupUsecase struct {
+	groupRepository       domain.GroupRepository
+	groupMemberRepository domain.GroupMemberRepository
+	groupWalletRepository domain.GroupWalletRepository
+	walletEntryRepository domain.WalletEntryRepository
+	userRepository        domain.UserRepository
+	outboxRepository      domain.OutboxRepository
+	transactor            domain.Transactor
+	contextTimeout        time.Duration
+}
+
+func NewGroupUsecase(groupRepository domain.GroupRepository, groupMemberRepository domain.GroupMemberRepository, groupWalletRepository domain.GroupWalletRepository, walletEntryRepository domain.WalletEntryRepository, userRepository domain.UserRepository, outboxRepository domain.OutboxRepository, transactor domain.Transactor, timeout time.Duration) domain.GroupUsecase {
+	return &groupUsecase{
+		groupRepository:       groupRepository,
+		groupMemberRepository: groupMemberRepository,
+		groupWalletRepository: groupWalletRepository,
+		walletEntryRepository: walletEntryRepository,
+		userRepository:        userRepository,
+		outboxRepository:      outboxRepository,
+		transactor:            transactor,
+		contextTimeout:        timeout,
+	}
+}
+
+// Create stores the group and makes its creator the owner.
+func (gu *groupUsecase) Create(c context.Context, group *domain.Group) error {
+	ctx, cancel := context.WithTimeout(c, gu.contextTimeout)
+	defer cancel()
+
+	group.CreatedAt = time.Now()
+	owner := domain.GroupMember{
+		ID:        primitive.NewObjectID(),
+		GroupID:   group.ID,
+		UserID:    group.CreatedBy,
+		Role:      domain.GroupRoleOwner,
+		CreatedAt: group.CreatedAt,
+	}
+	event, err := domain.NewDomainEvent(domain.MemberJoined{GroupID: group.ID.Hex(), UserID: owner.UserID.Hex(), Role: owner.Role})
+	if err != nil {
+		return err
+	}
+
+	return gu.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := gu.groupRepository.Create(ctx, group)
+		if err != nil {
+			return err
+		}
+		err = gu.groupMemberRepository.Create(ctx, &owner)
+		if err != nil {
+			return err
+		}
+		return gu.outboxRepository.Add(ctx, event)
+	})
+}
+
+// Authorize does not distinguish unknown groups from groups the user is not a
+// member of, so group IDs cannot be probed.
+func (gu *groupUsecase) Authorize(c context.Context, groupID string, userID string, permission string) (domain.GroupMember, error) {
+	ctx, cancel := context.WithTimeout(c, gu.contextTimeout)
+	defer cancel()
+
+	member, err := gu.groupMemberRepository.GetByGroupAndUser(ctx, groupID, userID)
+	if err == mongo.ErrNoDocuments || err == primitive.ErrInvalidHex {
+		return domain.GroupMember{}, domain.ErrForbidden
+	}
+	if err != nil {
+		return domain.GroupMember{}, err
+	}
+
+	if !domain.GroupRoleAllows(member.Role, permission) {
+		return domain.GroupMember{}, domain.ErrForbidden
+	}
+
+	return member, nil
+}
+
+func (gu *groupUsecase) FetchMembers(c context.Context, groupID string) ([]domain.GroupMember, error) {
+	ctx, cancel := context.WithTimeout(c, gu.contextTimeout)
+	defer cancel()
+	return gu.groupMemberRepository.FetchByGroupID(ctx, groupID)
+}
+
+func (gu *groupUsecase) AddMember(c context.Context, actor *domain.GroupMember, userID string, role string) (domain.GroupMember, error) {
+	ctx, cancel := context.WithTimeout(c, gu.contextTimeout)
+	defer cancel()
+
+	err := checkAssignableRole(actor, role)
+	if err != nil {
+		return domain.GroupMember{}, err
+	}
+
+	user, err := gu.userRepository.GetByID(ctx, userID)
+	if err == mongo.ErrNoDocuments || err == primitive.ErrInvalidHex {
+		return domain.GroupMember{}, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return domain.GroupMember{}, err
+	}
+	if user.DeletedAt != nil {
+		return domain.GroupMember{}, domain.ErrUserNotFound
+	}
+
+	member := domain.GroupMember{
+		ID:        primitive.NewObjectID(),
+		GroupID:   actor.GroupID,
+		UserID:    user.ID,
+		Role:      role,
+		CreatedAt: time.Now(),
+	}
+	event, err := domain.NewDomainEvent(domain.MemberJoined{GroupID: member.GroupID.Hex(), UserID: userID, Role: role})
+	if err != nil {
+		return domain.GroupMember{}, err
+	}
+
+	err = gu.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := gu.groupMemberRepository.Create(ctx, &member)
+		if err != nil {
+			return err
+		}
+		return gu.outboxRepository.Add(ctx, event)
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.GroupMember{}, domain.ErrGroupMemberExists
+	}
+	if err != nil {
+		return domain.GroupMember{}, err
+	}
+
+	return member, nil
+}
+
+func (gu *groupUsecase) UpdateMemberRole(c context.Context, actor *domain.GroupMember, userID string, role string) error {
+	ctx, cancel := context.WithTimeout(c, gu.contextTimeout)
+	defer cancel()
+
+	err := checkAssignableRole(actor, role)
+	if err != nil {
+		return err
+	}
+
+	_, err = gu.getManageableMember(ctx, actor, userID)
+	if err != nil {
+		return err
+	}
+
+	err = gu.groupMemberRepository.UpdateRole(ctx, actor.GroupID.Hex(), userID, role)
+	if err == mongo.ErrNoDocuments {
+		return domain.ErrGroupMemberNotFound
+	}
+
+	return err
+}
+
+func (gu *groupUsecase) RemoveMember(c context.Context, actor *domain.GroupMember, userID string) error {
+	ctx, cancel := context.WithTimeout(c, gu.contextTimeout)
+	defer cancel()
+
+	// Anyone but the owner may leave a group.
+	if actor.UserID.Hex() == userID {
+		if actor.Role == domain.GroupRoleOwner {
+			return domain.ErrGroupOwnerImmutable
+		}
+	} else {
+		_, err := gu.getManageableMember(ctx, actor, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	event, err := domain.NewDomainEvent(domain.MemberLeft{GroupID: actor.GroupID.Hex(), UserID: userID})
+	if err != nil {
+		return err
+	}
+
+	err = gu.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := gu.groupMemberRepository.Delete(ctx, actor.GroupID.Hex(), userID)
+		if err != nil {
+			return err
+		}
+		return gu.outboxRepository.Add(ctx, event)
+	})
+	if err == mongo.ErrNoDocuments {
+		return domain.ErrGroupMemberNotFound
+	}
+
+	return err
+}
+
+func (gu *groupUsecase) SetLateFee(c context.Context, actor *domain.GroupMember, policy *domain.LateFeePolicy) (domain.Group, error) {
+	ctx, cancel := context.WithTimeout(c, gu.contextTimeout)
+	defer cancel()
+
+	if !domain.GroupRoleAllows(actor.Role, domain.GroupPermissionManageMembers) {
+		return domain.Group{}, domain.ErrForbidden
+	}
+	if policy != nil && !policy.Valid() {
+		return domain.Group{}, domain.ErrInvalidLateFee
+	}
+
+	err := gu.groupRepository.SetLateFee(ctx, actor.GroupID.Hex(), policy)
+	if err == mongo.ErrNoDocuments {
+		return domain.Group{}, domain.ErrGroupNotFound
+	}
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	group, err := gu.groupRepository.GetByID(ctx, actor.GroupID.Hex())
+	if err == mongo.ErrNoDocuments {
+		return domain.Group{}, domain.ErrGroupNotFound
+	}
+	return group, err
+}
+
+func (gu *groupUsecase) Close(c context.Context, actor *domain.GroupMember) (domain.Group, error) {
+	ctx, cancel := context.WithTimeout(c, gu.contextTimeout)
+	defer cancel()
+
+	if !domain.GroupRoleAllows(actor.Role, domain.GroupPermissionDelete) {
+		return domain.Group{}, domain.ErrForbidden
+	}
+
+	group, err := gu.groupRepository.GetByID(ctx, actor.GroupID.Hex())
+	if err == mongo.ErrNoDocuments {
+		return domain.Group{}, domain.ErrGroupNotFound
+	}
+	if err != nil {
+		return domain.Group{}, err
+	}
+	if group.ClosedAt != nil {
+		return domain.Group{}, domain.ErrGroupClosed
+	}
+
+	var wallet *domain.GroupWallet
+	found, err := gu.groupWalletRepository.GetByGroupID(ctx, group.ID.Hex())
+	if err != nil && err != mongo.ErrNoDocuments {
+		return domain.Group{}, err
+	}
+	if err == nil && found.Status == domain.GroupWalletStatusOpen {
+		wallet = &found
+	}
+
+	var refunds []domain.WalletEntry
+	var events []domain.DomainEvent
+	if wallet != nil {
+		refunds, events, err = gu.walletRefunds(ctx, wallet)
+		if err != nil {
+			return domain.Group{}, err
+		}
+	}
+
+	err = gu.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := gu.groupRepository.Close(ctx, group.ID.Hex())
+		if err != nil || wallet == nil {
+			return err
+		}
+		err = gu.groupWalletRepository.Close(ctx, wallet)
+		if err != nil || len(refunds) == 0 {
+			return err
+		}
+		err = gu.walletEntryRepository.Create(ctx, refunds...)
+		if err != nil {
+			return err
+		}
+		return gu.outboxRepository.Add(ctx, events...)
+	})
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	now := time.Now()
+	group.ClosedAt = &now
+	return group, nil
+}
+
+// walletRefunds returns the entries and events that refund what is left in
+// the wallet to the contributors.
+func (gu *groupUsecase) walletRefunds(ctx context.Context, wallet *domain.GroupWallet) ([]domain.WalletEntry, []domain.DomainEvent, error) {
+	entries, err := gu.walletEntryRepository.FetchByWalletID(ctx, wallet.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	var refunds []domain.WalletEntry
+	var events []domain.DomainEvent
+	for _, share := range walletutil.Refunds(entries, wallet.Balance) {
+		refund := domain.WalletEntry{
+			ID:        primitive.NewObjectID(),
+			WalletID:  wallet.ID,
+			GroupID:   wallet.GroupID,
+			Type:      domain.WalletEntryRefund,
+			UserID:    share.UserID,
+			Amount:    share.Amount,
+			CreatedAt: now,
+		}
+		event, err := domain.NewDomainEvent(domain.PaymentRecorded{
+			GroupID:    wallet.GroupID.Hex(),
+			PaymentID:  refund.ID.Hex(),
+			FromUserID: domain.WalletPartyID,
+			ToUserID:   share.UserID.Hex(),
+			Amount:     share.Amount,
+			Currency:   wallet.Currency,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		refunds = append(refunds, refund)
+		events = append(events, event)
+	}
+
+	return refunds, events, nil
+}
+
+// getManageableMember returns the member if the actor ranks above them.
+func (gu *groupUsecase) getManageableMember(ctx context.Context, actor *domain.GroupMember, userID string) (domain.GroupMember, error) {
+	member, err := gu.groupMemberRepository.GetByGroupAndUser(ctx, actor.GroupID.Hex(), userID)
+	if err == mongo.ErrNoDocuments || err == primitive.ErrInvalidHex {
+		return member, domain.ErrGroupMemberNotFound
+	}
+	if err != nil {
+		return member, err
+	}
+
+	if member.Role == domain.GroupRoleOwner {
+		return member, domain.ErrGroupOwnerImmutable
+	}
+	if !domain.GroupRoleOutranks(actor.Role, member.Role) {
+		return member, domain.ErrForbidden
+	}
+
+	return member, nil
+}
+
+func checkAssignableRole(actor *domain.GroupMember, role string) error {
+	if !domain.IsGroupRole(role) {
+		return domain.ErrUnknownGroupRole
+	}
+	if role == domain.GroupRoleOwner {
+		return domain.ErrGroupOwnerImmutable
+	}
+	if !domain.GroupRoleAllows(actor.Role, domain.GroupPermissionManageMembers) || !domain.GroupRoleOutranks(actor.Role, role) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/fakeutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestGroupAuthorize(t *testing.T) {
+	groupID := primitive.NewObjectID()
+	viewerID := primitive.NewObjectID()
+	viewer := domain.GroupMember{GroupID: groupID, UserID: viewerID, Role: domain.GroupRoleViewer}
+
+	mockMemberRepository := new(mocks.GroupMemberRepository)
+	mockMemberRepository.On("GetByGroupAndUser", mock.Anything, groupID.Hex(), viewerID.Hex()).Return(viewer, nil)
+	mockMemberRepository.On("GetByGroupAndUser", mock.Anything, groupID.Hex(), mock.Anything).Return(domain.GroupMember{}, mongo.ErrNoDocuments)
+
+	u := usecase.NewGroupUsecase(new(mocks.GroupRepository), mockMemberRepository, new(mocks.GroupWalletRepository), new(mocks.WalletEntryRepository), new(mocks.UserRepository), new(mocks.OutboxRepository), fakeutil.NewTransactor(), time.Second*2)
+
+	t.Run("allowed", func(t *testing.T) {
+		member, err := u.Authorize(context.Background(), groupID.Hex(), viewerID.Hex(), domain.GroupPermissionView)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.GroupRoleViewer, member.Role)
+	})
+
+	t.Run("role lacks permission", func(t *testing.T) {
+		_, err := u.Authorize(context.Background(), groupID.Hex(), viewerID.Hex(), domain.GroupPermissionWriteExpenses)
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		_, err := u.Authorize(context.Background(), groupID.Hex(), primitive.NewObjectID().Hex(), domain.GroupPermissionView)
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+}
+
+func TestGroupCreate(t *testing.T) {
+	group := domain.Group{ID: primitive.NewObjectID(), Name: "Flat", CreatedBy: primitive.NewObjectID()}
+
+	mockGroupRepository := new(mocks.GroupRepository)
+	mockGroupRepository.On("Create", mock.Anything, &group).Return(nil).Once()
+	mockMemberRepository := new(mocks.GroupMemberRepository)
+	mockMemberRepository.On("Create", mock.Anything, mock.MatchedBy(func(member *domain.GroupMember) bool {
+		return member.GroupID == group.ID && member.UserID == group.CreatedBy && member.Role == domain.GroupRoleOwner
+	})).Return(nil).Once()
+	mockOutboxRepository := new(mocks.OutboxRepository)
+	mockOutboxRepository.On("Add", mock.Anything, mock.MatchedBy(func(event domain.DomainEvent) bool {
+		var joined domain.MemberJoined
+		return event.DecodePayload(&joined) == nil && joined.UserID == group.CreatedBy.Hex() && joined.Role == domain.GroupRoleOwner
+	})).Return(nil).Once()
+
+	u := usecase.NewGroupUsecase(mockGroupRepository, mockMemberRepository, new(mocks.GroupWalletRepository), new(mocks.WalletEntryRepository), new(mocks.UserRepository), mockOutboxRepository, fakeutil.NewTransactor(), time.Second*2)
+
+	err := u.Create(context.Background(), &group)
+
+	assert.NoError(t, err)
+	assert.False(t, group.CreatedAt.IsZero())
+	mockGroupRepository.AssertExpectations(t)
+	mockMemberRepository.AssertExpectations(t)
+	mockOutboxRepository.AssertExpectations(t)
+}
+
+func TestGroupManageMembers(t *testing.T) {
+	groupID := primitive.NewObjectID()
+	owner := domain.GroupMember{GroupID: groupID, UserID: primitive.NewObjectID(), Role: domain.GroupRoleOwner}
+	admin := domain.GroupMember{GroupID: groupID, UserID: primitive.NewObjectID(), Role: domain.GroupRoleAdmin}
+	otherAdmin := domain.GroupMember{GroupID: groupID, UserID: primitive.NewObjectID(), Role: domain.GroupRoleAdmin}
+	member := domain.GroupMember{GroupID: groupID, UserID: primitive.NewObjectID(), Role: domain.GroupRoleMember}
+
+	mockMemberRepository := new(mocks.GroupMemberRepository)
+	for _, m := range []domain.GroupMember{owner, admin, otherAdmin, member} {
+		mockMemberRepository.On("GetByGroupAndUser", mock.Anything, groupID.Hex(), m.UserID.Hex()).Return(m, nil).Maybe()
+	}
+
+	mockUserRepository := new(mocks.UserRepository)
+	mockOutboxRepository := new(mocks.OutboxRepository)
+
+	u := usecase.NewGroupUsecase(new(mocks.GroupRepository), mockMemberRepository, new(mocks.GroupWalletRepository), new(mocks.WalletEntryRepository), mockUserRepository, mockOutboxRepository, fakeutil.NewTransactor(), time.Second*2)
+
+	t.Run("admin cannot grant admin", func(t *testing.T) {
+		err := u.UpdateMemberRole(context.Background(), &admin, member.UserID.Hex(), domain.GroupRoleAdmin)
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("admin cannot demote another admin", func(t *testing.T) {
+		err := u.UpdateMemberRole(context.Background(), &admin, otherAdmin.UserID.Hex(), domain.GroupRoleViewer)
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("owner role cannot be assigned", func(t *testing.T) {
+		err := u.UpdateMemberRole(context.Background(), &owner, member.UserID.Hex(), domain.GroupRoleOwner)
+		assert.ErrorIs(t, err, domain.ErrGroupOwnerImmutable)
+	})
+
+	t.Run("admin cannot remove owner", func(t *testing.T) {
+		err := u.RemoveMember(context.Background(), &admin, owner.UserID.Hex())
+		assert.ErrorIs(t, err, domain.ErrGroupOwnerImmutable)
+	})
+
+	t.Run("member cannot remove others", func(t *testing.T) {
+		err := u.RemoveMember(context.Background(), &member, otherAdmin.UserID.Hex())
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("owner promotes member", func(t *testing.T) {
+		mockMemberRepository.On("UpdateRole", mock.Anything, groupID.Hex(), member.UserID.Hex(), domain.GroupRoleAdmin).Return(nil).Once()
+
+		err := u.UpdateMemberRole(context.Background(), &owner, member.UserID.Hex(), domain.GroupRoleAdmin)
+		assert.NoError(t, err)
+	})
+
+	t.Run("admin adds member", func(t *testing.T) {
+		user := domain.User{ID: primitive.NewObjectID()}
+		mockUserRepository.On("GetByID", mock.Anything, user.ID.Hex()).Return(user, nil).Once()
+		mockMemberRepository.On("Create", mock.Anything, mock.AnythingOfType("*domain.GroupMember")).Return(nil).Once()
+		mockOutboxRepository.On("Add", mock.Anything, mock.MatchedBy(func(event domain.DomainEvent) bool {
+			var joined domain.MemberJoined
+			return event.Type == domain.DomainEventMemberJoined && event.DecodePayload(&joined) == nil &&
+				joined.GroupID == groupID.Hex() && joined.UserID == user.ID.Hex() && joined.Role == domain.GroupRoleMember
+		})).Return(nil).Once()
+
+		added, err := u.AddMember(context.Background(), &admin, user.ID.Hex(), domain.GroupRoleMember)
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, added.UserID)
+		mockOutboxRepository.AssertExpectations(t)
+	})
+
+	t.Run("member leaves", func(t *testing.T) {
+		mockMemberRepository.On("Delete", mock.Anything, groupID.Hex(), member.UserID.Hex()).Return(nil).Once()
+		mockOutboxRepository.On("Add", mock.Anything, mock.MatchedBy(func(event domain.DomainEvent) bool {
+			var left domain.MemberLeft
+			return event.Type == domain.DomainEventMemberLeft && event.DecodePayload(&left) == nil &&
+				left.GroupID == groupID.Hex() && left.UserID == member.UserID.Hex()
+		})).Return(nil).Once()
+
+		err := u.RemoveMember(context.Background(), &member, member.UserID.Hex())
+		assert.NoError(t, err)
+		mockOutboxRepository.AssertExpectations(t)
+	})
+
+	mockMemberRepository.AssertExpectations(t)
+}
+
+func TestGroupSetLateFee(t *testing.T) {
+	groupID := primitive.NewObjectID()
+	admin := domain.GroupMember{GroupID: groupID, UserID: primitive.NewObjectID(), Role: domain.GroupRoleAdmin}
+	member := domain.GroupMember{GroupID: groupID, UserID: primitive.NewObjectID(), Role: domain.GroupRoleMember}
+	policy := domain.LateFeePolicy{Type: domain.LateFeeFlat, Amount: 25, GraceDays: 3}
+
+	mockGroupRepository := new(mocks.GroupRepository)
+
+	u := usecase.NewGroupUsecase(mockGroupRepository, new(mocks.GroupMemberRepository), new(mocks.GroupWalletRepository), new(mocks.WalletEntryRepository), new(mocks.UserRepository), new(mocks.OutboxRepository), fakeutil.NewTransactor(), time.Second*2)
+
+	t.Run("member cannot set", func(t *testing.T) {
+		_, err := u.SetLateFee(context.Background(), &member, &policy)
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		_, err := u.SetLateFee(context.Background(), &admin, &domain.LateFeePolicy{Type: domain.LateFeeDailyInterest})
+		assert.ErrorIs(t, err, domain.ErrInvalidLateFee)
+	})
+
+	t.Run("admin sets", func(t *testing.T) {
+		mockGroupRepository.On("SetLateFee", mock.Anything, groupID.Hex(), &policy).Return(nil).Once()
+		mockGroupRepository.On("GetByID", mock.Anything, groupID.Hex()).Return(domain.Group{ID: groupID, LateFee: &policy}, nil).Once()
+
+		group, err := u.SetLateFee(context.Background(), &admin, &policy)
+		assert.NoError(t, err)
+		assert.Equal(t, &policy, group.LateFee)
+	})
+
+	mockGroupRepository.AssertExpectations(t)
+}
+
+func TestGroupClose(t *testing.T) {
+	groupID := primitive.NewObjectID()
+	owner := domain.GroupMember{GroupID: groupID, UserID: primitive.NewObjectID(), Role: domain.GroupRoleOwner}
+	member := domain.GroupMember{GroupID: groupID, UserID: primitive.NewObjectID(), Role: domain.GroupRoleMember}
+	admin := domain.GroupMember{GroupID: groupID, UserID: primitive.NewObjectID(), Role: domain.GroupRoleAdmin}
+	group := domain.Group{ID: groupID, Name: "Trip"}
+	wallet := domain.GroupWallet{ID: primitive.NewObjectID(), GroupID: groupID, Currency: "EUR", Balance: 100, Status: domain.GroupWalletStatusOpen}
+
+	t.Run("admin cannot close", func(t *testing.T) {
+		u := usecase.NewGroupUsecase(new(mocks.GroupRepository), new(mocks.GroupMemberRepository), new(mocks.GroupWalletRepository), new(mocks.WalletEntryRepository), new(mocks.UserRepository), new(mocks.OutboxRepository), fakeutil.NewTransactor(), time.Second*2)
+
+		_, err := u.Close(context.Background(), &admin)
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("close refunds the wallet leftover", func(t *testing.T) {
+		entries := []domain.WalletEntry{
+			{Type: domain.WalletEntryContribution, UserID: owner.UserID, Amount: 150},
+			{Type: domain.WalletEntryContribution, UserID: member.UserID, Amount: 50},
+			{Type: domain.WalletEntryExpense, UserID: owner.UserID, Amount: 100},
+		}
+
+		groupRepository := new(mocks.GroupRepository)
+		groupRepository.On("GetByID", mock.Anything, groupID.Hex()).Return(group, nil).Once()
+		groupRepository.On("Close", mock.Anything, groupID.Hex()).Return(nil).Once()
+		walletRepository := new(mocks.GroupWalletRepository)
+		walletRepository.On("GetByGroupID", mock.Anything, groupID.Hex()).Return(wallet, nil).Once()
+		walletRepository.On("Close", mock.Anything, mock.Anything).Return(nil).Once()
+		entryRepository := new(mocks.WalletEntryRepository)
+		entryRepository.On("FetchByWalletID", mock.Anything, wallet.ID).Return(entries, nil).Once()
+		entryRepository.On("Create", mock.Anything,
+			mock.MatchedBy(func(refund domain.WalletEntry) bool {
+				return refund.Type == domain.WalletEntryRefund && refund.UserID == owner.UserID && refund.Amount == 75
+			}),
+			mock.MatchedBy(func(refund domain.WalletEntry) bool {
+				return refund.Type == domain.WalletEntryRefund && refund.UserID == member.UserID && refund.Amount == 25
+			}),
+		).Return(nil).Once()
+		outboxRepository := new(mocks.OutboxRepository)
+		outboxRepository.On("Add", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		u := usecase.NewGroupUsecase(groupRepository, new(mocks.GroupMemberRepository), walletRepository, entryRepository, new(mocks.UserRepository), outboxRepository, fakeutil.NewTransactor(), time.Second*2)
+
+		closed, err := u.Close(context.Background(), &owner)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, closed.ClosedAt)
+		groupRepository.AssertExpectations(t)
+		walletRepository.AssertExpectations(t)
+		entryRepository.AssertExpectations(t)
+		outboxRepository.AssertExpectations(t)
+	})
+
+	t.Run("close without wallet", func(t *testing.T) {
+		groupRepository := new(mocks.GroupRepository)
+		groupRepository.On("GetByID", mock.Anything, groupID.Hex()).Return(group, nil).Once()
+		groupRepository.On("Close", mock.Anything, groupID.Hex()).Return(nil).Once()
+		walletRepository := new(mocks.GroupWalletRepository)
+		walletRepository.On("GetByGroupID", mock.Anything, groupID.Hex()).Return(domain.GroupWallet{}, mongo.ErrNoDocuments).Once()
+
+		u := usecase.NewGroupUsecase(groupRepository, new(mocks.GroupMemberRepository), walletRepository, new(mocks.WalletEntryRepository), new(mocks.UserRepository), new(mocks.OutboxRepository), fakeutil.NewTransactor(), time.Second*2)
+
+		_, err := u.Close(context.Background(), &owner)
+
+		assert.NoError(t, err)
+		groupRepository.AssertExpectations(t)
+	})
+
+	t.Run("already closed", func(t *testing.T) {
+		closedAt := time.Now()
+		closedGroup := group
+		closedGroup.ClosedAt = &closedAt
+		groupRepository := new(mocks.GroupRepository)
+		groupRepository.On("GetByID", mock.Anything, groupID.Hex()).Return(closedGroup, nil).Once()
+
+		u := usecase.NewGroupUsecase(groupRepository, new(mocks.GroupMemberRepository), new(mocks.GroupWalletRepository), new(mocks.WalletEntryRepository), new(mocks.UserRepository), new(mocks.OutboxRepository), fakeutil.NewTransactor(), time.Second*2)
+
+		_, err := u.Close(context.Background(), &owner)
+		assert.ErrorIs(t, err, domain.ErrGroupClosed)
+	})
+}
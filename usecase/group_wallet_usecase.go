@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/walletutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type groupWalletUsecase struct {
+	groupWalletRepository domain.GroupWalletRepository
+	walletEntryRepository domain.WalletEntryRepository
+	groupRepository       domain.GroupRepository
+	groupMemberRepository domain.GroupMemberRepository
+	outboxRepository      domain.OutboxRepository
+	transactor            domain.Transactor
+	contextTimeout        time.Duration
+}
+
+func NewGroupWalletUsecase(groupWalletRepository domain.GroupWalletRepository, walletEntryRepository domain.WalletEntryRepository, groupRepository domain.GroupRepository, groupMemberRepository domain.GroupMemberRepository, outboxRepository domain.OutboxRepository, transactor domain.Transactor, timeout time.Duration) domain.GroupWalletUsecase {
+	return &groupWalletUsecase{
+		groupWalletRepository: groupWalletRepository,
+		walletEntryRepository: walletEntryRepository,
+		groupRepository:       groupRepository,
+		groupMemberRepository: groupMemberRepository,
+		outboxRepository:      outboxRepository,
+		transactor:            transactor,
+		contextTimeout:        timeout,
+	}
+}
+
+func (wu *groupWalletUsecase) Open(c context.Context, actor *domain.GroupMember, currency string) (domain.GroupWallet, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	group, err := wu.groupRepository.GetByID(ctx, actor.GroupID.Hex())
+	if err == mongo.ErrNoDocuments {
+		return domain.GroupWallet{}, domain.ErrGroupNotFound
+	}
+	if err != nil {
+		return domain.GroupWallet{}, err
+	}
+	if group.ClosedAt != nil {
+		return domain.GroupWallet{}, domain.ErrGroupClosed
+	}
+
+	wallet := domain.GroupWallet{
+		ID:        primitive.NewObjectID(),
+		GroupID:   actor.GroupID,
+		Currency:  currency,
+		Status:    domain.GroupWalletStatusOpen,
+		CreatedBy: actor.UserID,
+		CreatedAt: time.Now(),
+	}
+
+	err = wu.groupWalletRepository.Create(ctx, &wallet)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.GroupWallet{}, domain.ErrGroupWalletExists
+	}
+	if err != nil {
+		return domain.GroupWallet{}, err
+	}
+
+	return wallet, nil
+}
+
+func (wu *groupWalletUsecase) GetSummary(c context.Context, groupID string) (domain.GroupWalletSummary, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	wallet, err := wu.getWallet(ctx, groupID)
+	if err != nil {
+		return domain.GroupWalletSummary{}, err
+	}
+
+	entries, err := wu.walletEntryRepository.FetchByWalletID(ctx, wallet.ID)
+	if err != nil {
+		return domain.GroupWalletSummary{}, err
+	}
+
+	return walletutil.Summarize(&wallet, entries), nil
+}
+
+func (wu *groupWalletUsecase) Contribute(c context.Context, actor *domain.GroupMember, amount int64) (domain.WalletEntry, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	wallet, err := wu.getOpenWallet(ctx, actor.GroupID.Hex())
+	if err != nil {
+		return domain.WalletEntry{}, err
+	}
+
+	entry := domain.WalletEntry{
+		ID:        primitive.NewObjectID(),
+		WalletID:  wallet.ID,
+		GroupID:   wallet.GroupID,
+		Type:      domain.WalletEntryContribution,
+		UserID:    actor.UserID,
+		Amount:    amount,
+		CreatedAt: time.Now(),
+	}
+	event, err := domain.NewDomainEvent(domain.PaymentRecorded{
+		GroupID:    wallet.GroupID.Hex(),
+		PaymentID:  entry.ID.Hex(),
+		FromUserID: actor.UserID.Hex(),
+		ToUserID:   domain.WalletPartyID,
+		Amount:     amount,
+		Currency:   wallet.Currency,
+	})
+	if err != nil {
+		return domain.WalletEntry{}, err
+	}
+
+	err = wu.addEntry(ctx, &wallet, amount, entry, event)
+	if err != nil {
+		return domain.WalletEntry{}, err
+	}
+
+	return entry, nil
+}
+
+// AddExpense splits the expense equally between the participants, who must
+// all be members of the group.
+func (wu *groupWalletUsecase) AddExpense(c context.Context, actor *domain.GroupMember, request *domain.WalletExpenseRequest) (domain.WalletEntry, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	wallet, err := wu.getOpenWallet(ctx, actor.GroupID.Hex())
+	if err != nil {
+		return domain.WalletEntry{}, err
+	}
+	if request.Amount > wallet.Balance {
+		return domain.WalletEntry{}, domain.ErrGroupWalletInsufficient
+	}
+
+	participants, err := wu.participants(ctx, actor.GroupID.Hex(), request.Participants)
+	if err != nil {
+		return domain.WalletEntry{}, err
+	}
+
+	entry := domain.WalletEntry{
+		ID:          primitive.NewObjectID(),
+		WalletID:    wallet.ID,
+		GroupID:     wallet.GroupID,
+		Type:        domain.WalletEntryExpense,
+		UserID:      actor.UserID,
+		Amount:      request.Amount,
+		Description: request.Description,
+		Shares:      walletutil.Split(request.Amount, participants),
+		CreatedAt:   time.Now(),
+	}
+	event, err := domain.NewDomainEvent(domain.ExpenseAdded{
+		GroupID:   wallet.GroupID.Hex(),
+		ExpenseID: entry.ID.Hex(),
+		PaidBy:    domain.WalletPartyID,
+		Amount:    request.Amount,
+		Currency:  wallet.Currency,
+	})
+	if err != nil {
+		return domain.WalletEntry{}, err
+	}
+
+	err = wu.addEntry(ctx, &wallet, -request.Amount, entry, event)
+	if err != nil {
+		return domain.WalletEntry{}, err
+	}
+
+	return entry, nil
+}
+
+// addEntry changes the balance of the wallet, stores the entry and adds the
+// event to the outbox in one transaction.
+func (wu *groupWalletUsecase) addEntry(ctx context.Context, wallet *domain.GroupWallet, amount int64, entry domain.WalletEntry, event domain.DomainEvent) error {
+	return wu.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := wu.groupWalletRepository.AddToBalance(ctx, wallet, amount)
+		if err != nil {
+			return err
+		}
+		err = wu.walletEntryRepository.Create(ctx, entry)
+		if err != nil {
+			return err
+		}
+		return wu.outboxRepository.Add(ctx, event)
+	})
+}
+
+// participants returns all members of the group if userIDs is empty.
+func (wu *groupWalletUsecase) participants(ctx context.Context, groupID string, userIDs []string) ([]primitive.ObjectID, error) {
+	members, err := wu.groupMemberRepository.FetchByGroupID(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var participants []primitive.ObjectID
+	if len(userIDs) == 0 {
+		for _, member := range members {
+			participants = append(participants, member.UserID)
+		}
+		return participants, nil
+	}
+
+	isMember := make(map[string]bool, len(members))
+	for _, member := range members {
+		isMember[member.UserID.Hex()] = true
+	}
+	seen := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if !isMember[userID] {
+			return nil, domain.ErrWalletParticipant
+		}
+		// Listing a participant twice does not double their share.
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		participant, err := primitive.ObjectIDFromHex(userID)
+		if err != nil {
+			return nil, domain.ErrWalletParticipant
+		}
+		participants = append(participants, participant)
+	}
+
+	return participants, nil
+}
+
+func (wu *groupWalletUsecase) getWallet(ctx context.Context, groupID string) (domain.GroupWallet, error) {
+	wallet, err := wu.groupWalletRepository.GetByGroupID(ctx, groupID)
+	if err == mongo.ErrNoDocuments || err == primitive.ErrInvalidHex {
+		return wallet, domain.ErrGroupWalletNotFound
+	}
+	return wallet, err
+}
+
+func (wu *groupWalletUsecase) getOpenWallet(ctx context.Context, groupID string) (domain.GroupWallet, error) {
+	wallet, err := wu.getWallet(ctx, groupID)
+	if err != nil {
+		return wallet, err
+	}
+	if wallet.Status != domain.GroupWalletStatusOpen {
+		return wallet, domain.ErrGroupWalletClosed
+	}
+	return wallet, nil
+}
@@ -0,0 +1,115 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/fakeutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGroupWallet(t *testing.T) {
+	groupID := primitive.NewObjectID()
+	owner := domain.GroupMember{GroupID: groupID, UserID: primitive.NewObjectID(), Role: domain.GroupRoleOwner}
+	member := domain.GroupMember{GroupID: groupID, UserID: primitive.NewObjectID(), Role: domain.GroupRoleMember}
+	wallet := domain.GroupWallet{ID: primitive.NewObjectID(), GroupID: groupID, Currency: "EUR", Balance: 100, Status: domain.GroupWalletStatusOpen}
+
+	newUsecase := func(walletRepository *mocks.GroupWalletRepository, entryRepository *mocks.WalletEntryRepository, outboxRepository *mocks.OutboxRepository) domain.GroupWalletUsecase {
+		groupRepository := new(mocks.GroupRepository)
+		groupRepository.On("GetByID", mock.Anything, groupID.Hex()).Return(domain.Group{ID: groupID}, nil).Maybe()
+		memberRepository := new(mocks.GroupMemberRepository)
+		memberRepository.On("FetchByGroupID", mock.Anything, groupID.Hex()).Return([]domain.GroupMember{owner, member}, nil).Maybe()
+		return usecase.NewGroupWalletUsecase(walletRepository, entryRepository, groupRepository, memberRepository, outboxRepository, fakeutil.NewTransactor(), time.Second*2)
+	}
+
+	t.Run("contribute", func(t *testing.T) {
+		walletRepository := new(mocks.GroupWalletRepository)
+		walletRepository.On("GetByGroupID", mock.Anything, groupID.Hex()).Return(wallet, nil).Once()
+		walletRepository.On("AddToBalance", mock.Anything, mock.Anything, int64(50)).Return(nil).Once()
+		entryRepository := new(mocks.WalletEntryRepository)
+		entryRepository.On("Create", mock.Anything, mock.MatchedBy(func(entry domain.WalletEntry) bool {
+			return entry.Type == domain.WalletEntryContribution && entry.UserID == member.UserID && entry.Amount == 50
+		})).Return(nil).Once()
+		outboxRepository := new(mocks.OutboxRepository)
+		outboxRepository.On("Add", mock.Anything, mock.MatchedBy(func(event domain.DomainEvent) bool {
+			var payment domain.PaymentRecorded
+			return event.DecodePayload(&payment) == nil && payment.ToUserID == domain.WalletPartyID && payment.Amount == 50
+		})).Return(nil).Once()
+
+		u := newUsecase(walletRepository, entryRepository, outboxRepository)
+
+		_, err := u.Contribute(context.Background(), &member, 50)
+
+		assert.NoError(t, err)
+		walletRepository.AssertExpectations(t)
+		entryRepository.AssertExpectations(t)
+		outboxRepository.AssertExpectations(t)
+	})
+
+	t.Run("expense split between all members", func(t *testing.T) {
+		walletRepository := new(mocks.GroupWalletRepository)
+		walletRepository.On("GetByGroupID", mock.Anything, groupID.Hex()).Return(wallet, nil).Once()
+		walletRepository.On("AddToBalance", mock.Anything, mock.Anything, int64(-75)).Return(nil).Once()
+		entryRepository := new(mocks.WalletEntryRepository)
+		entryRepository.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+		outboxRepository := new(mocks.OutboxRepository)
+		outboxRepository.On("Add", mock.Anything, mock.MatchedBy(func(event domain.DomainEvent) bool {
+			var expense domain.ExpenseAdded
+			return event.DecodePayload(&expense) == nil && expense.PaidBy == domain.WalletPartyID
+		})).Return(nil).Once()
+
+		u := newUsecase(walletRepository, entryRepository, outboxRepository)
+
+		entry, err := u.AddExpense(context.Background(), &member, &domain.WalletExpenseRequest{Amount: 75, Description: "Dinner"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.WalletShare{{UserID: owner.UserID, Amount: 38}, {UserID: member.UserID, Amount: 37}}, entry.Shares)
+		outboxRepository.AssertExpectations(t)
+	})
+
+	t.Run("expense exceeds balance", func(t *testing.T) {
+		walletRepository := new(mocks.GroupWalletRepository)
+		walletRepository.On("GetByGroupID", mock.Anything, groupID.Hex()).Return(wallet, nil).Once()
+
+		u := newUsecase(walletRepository, new(mocks.WalletEntryRepository), new(mocks.OutboxRepository))
+
+		_, err := u.AddExpense(context.Background(), &member, &domain.WalletExpenseRequest{Amount: 101, Description: "Dinner"})
+
+		assert.ErrorIs(t, err, domain.ErrGroupWalletInsufficient)
+	})
+
+	t.Run("participant not a member", func(t *testing.T) {
+		walletRepository := new(mocks.GroupWalletRepository)
+		walletRepository.On("GetByGroupID", mock.Anything, groupID.Hex()).Return(wallet, nil).Once()
+
+		u := newUsecase(walletRepository, new(mocks.WalletEntryRepository), new(mocks.OutboxRepository))
+
+		_, err := u.AddExpense(context.Background(), &member, &domain.WalletExpenseRequest{
+			Amount:       10,
+			Description:  "Dinner",
+			Participants: []string{member.UserID.Hex(), primitive.NewObjectID().Hex()},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrWalletParticipant)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		closed := wallet
+		closed.Status = domain.GroupWalletStatusClosed
+
+		walletRepository := new(mocks.GroupWalletRepository)
+		walletRepository.On("GetByGroupID", mock.Anything, groupID.Hex()).Return(closed, nil).Once()
+
+		u := newUsecase(walletRepository, new(mocks.WalletEntryRepository), new(mocks.OutboxRepository))
+
+		_, err := u.Contribute(context.Background(), &member, 50)
+
+		assert.ErrorIs(t, err, domain.ErrGroupWalletClosed)
+	})
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/loanutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type loanUsecase struct {
+	loanRepository domain.LoanRepository
+	userRepository domain.UserRepository
+	contextTimeout time.Duration
+}
+
+func NewLoanUsecase(loanRepository domain.LoanRepository, userRepository domain.UserRepository, timeout time.Duration) domain.LoanUsecase {
+	return &loanUsecase{
+		loanRepository: loanRepository,
+		userRepository: userRepository,
+		contextTimeout: timeout,
+	}
+}
+
+// Create stores a pending loan. The creator confirms it implicitly, the loan
+// takes effect once the counterparty confirms it too.
+func (lu *loanUsecase) Create(c context.Context, loan *domain.Loan) error {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+
+	if loan.LenderID == loan.BorrowerID {
+		return domain.ErrLoanSelf
+	}
+
+	counterpartyID := loan.LenderID
+	if loan.CreatedBy == loan.LenderID {
+		counterpartyID = loan.BorrowerID
+	}
+	counterparty, err := lu.userRepository.GetByID(ctx, counterpartyID.Hex())
+	if err == mongo.ErrNoDocuments {
+		return domain.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if counterparty.DeletedAt != nil {
+		return domain.ErrUserNotFound
+	}
+
+	now := time.Now()
+	if loan.CreatedBy == loan.LenderID {
+		loan.LenderConfirmedAt = &now
+	} else {
+		loan.BorrowerConfirmedAt = &now
+	}
+	loan.Status = domain.LoanStatusPending
+	loan.Repayments = []domain.Repayment{}
+	loan.CreatedAt = now
+
+	return lu.loanRepository.Create(ctx, loan)
+}
+
+func (lu *loanUsecase) GetByID(c context.Context, loanID string, userID string) (domain.Loan, error) {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+	return lu.getForParty(ctx, loanID, userID)
+}
+
+func (lu *loanUsecase) FetchByUserID(c context.Context, userID string) ([]domain.Loan, error) {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+	return lu.loanRepository.FetchByUserID(ctx, userID)
+}
+
+func (lu *loanUsecase) Confirm(c context.Context, loanID string, userID string) (domain.Loan, error) {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+
+	loan, err := lu.getForParty(ctx, loanID, userID)
+	if err != nil {
+		return loan, err
+	}
+	if loan.Status != domain.LoanStatusPending {
+		return loan, domain.ErrLoanNotPending
+	}
+
+	now := time.Now()
+	if loan.LenderID.Hex() == userID {
+		if loan.LenderConfirmedAt != nil {
+			return loan, domain.ErrLoanAlreadyConfirmed
+		}
+		loan.LenderConfirmedAt = &now
+	} else {
+		if loan.BorrowerConfirmedAt != nil {
+			return loan, domain.ErrLoanAlreadyConfirmed
+		}
+		loan.BorrowerConfirmedAt = &now
+	}
+
+	if loan.LenderConfirmedAt != nil && loan.BorrowerConfirmedAt != nil {
+		loan.Status = domain.LoanStatusActive
+	}
+
+	err = lu.loanRepository.UpdateConfirmation(ctx, &loan)
+	return loan, err
+}
+
+func (lu *loanUsecase) Decline(c context.Context, loanID string, userID string) (domain.Loan, error) {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+
+	loan, err := lu.getForParty(ctx, loanID, userID)
+	if err != nil {
+		return loan, err
+	}
+	if loan.Status != domain.LoanStatusPending {
+		return loan, domain.ErrLoanNotPending
+	}
+
+	loan.Status = domain.LoanStatusDeclined
+
+	err = lu.loanRepository.UpdateConfirmation(ctx, &loan)
+	return loan, err
+}
+
+func (lu *loanUsecase) AddRepayment(c context.Context, loanID string, userID string, repayment domain.Repayment) (domain.Loan, error) {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+
+	loan, err := lu.getForParty(ctx, loanID, userID)
+	if err != nil {
+		return loan, err
+	}
+	if loan.Status != domain.LoanStatusActive {
+		return loan, domain.ErrLoanNotActive
+	}
+	if loan.LenderID.Hex() != userID {
+		return loan, domain.ErrLoanNotLender
+	}
+
+	balance := loanutil.Balance(&loan, time.Now())
+	if repayment.Amount > balance.Remaining {
+		return loan, domain.ErrRepaymentExceedsBalance
+	}
+
+	repayment.ID = primitive.NewObjectID()
+	repayment.RecordedBy = loan.LenderID
+	if repayment.PaidAt.IsZero() {
+		repayment.PaidAt = time.Now()
+	}
+
+	status := domain.LoanStatusActive
+	if repayment.Amount == balance.Remaining {
+		status = domain.LoanStatusRepaid
+	}
+
+	err = lu.loanRepository.AddRepayment(ctx, &loan, repayment, status)
+	if err != nil {
+		return loan, err
+	}
+
+	loan.Repayments = append(loan.Repayments, repayment)
+	loan.Status = status
+	return loan, nil
+}
+
+func (lu *loanUsecase) GetBalance(c context.Context, loanID string, userID string) (*domain.LoanBalance, error) {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+
+	loan, err := lu.getForParty(ctx, loanID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return loanutil.Balance(&loan, time.Now()), nil
+}
+
+// getForParty hides loans from users who are not a party to them.
+func (lu *loanUsecase) getForParty(ctx context.Context, loanID string, userID string) (domain.Loan, error) {
+	loan, err := lu.loanRepository.GetByID(ctx, loanID)
+	if err == mongo.ErrNoDocuments || err == primitive.ErrInvalidHex {
+		return loan, domain.ErrLoanNotFound
+	}
+	if err != nil {
+		return loan, err
+	}
+
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil || !loan.IsParty(userObjectID) {
+		return domain.Loan{}, domain.ErrLoanNotFound
+	}
+
+	return loan, nil
+}
@@ -0,0 +1,316 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func newActiveLoan(lenderID primitive.ObjectID, borrowerID primitive.ObjectID) domain.Loan {
+	return domain.Loan{
+		ID:         primitive.NewObjectID(),
+		LenderID:   lenderID,
+		BorrowerID: borrowerID,
+		Principal:  1000,
+		Currency:   "EUR",
+		Installments: []domain.Installment{
+			{Number: 1, DueDate: time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC), Principal: 1000, Amount: 1000},
+		},
+		Repayments: []domain.Repayment{},
+		Status:     domain.LoanStatusActive,
+		CreatedBy:  lenderID,
+	}
+}
+
+func TestLoanCreate(t *testing.T) {
+	lenderID := primitive.NewObjectID()
+	borrowerID := primitive.NewObjectID()
+
+	newLoan := func() domain.Loan {
+		loan := newActiveLoan(lenderID, borrowerID)
+		loan.Status = ""
+		loan.Repayments = nil
+		return loan
+	}
+
+	t.Run("success", func(t *testing.T) {
+		loan := newLoan()
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByID", mock.Anything, borrowerID.Hex()).Return(domain.User{ID: borrowerID}, nil).Once()
+		mockLoanRepository := new(mocks.LoanRepository)
+		mockLoanRepository.On("Create", mock.Anything, &loan).Return(nil).Once()
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, mockUserRepository, time.Second*2)
+
+		err := u.Create(context.Background(), &loan)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.LoanStatusPending, loan.Status)
+		assert.NotNil(t, loan.LenderConfirmedAt)
+		assert.Nil(t, loan.BorrowerConfirmedAt)
+		mockLoanRepository.AssertExpectations(t)
+	})
+
+	t.Run("self", func(t *testing.T) {
+		loan := newActiveLoan(lenderID, lenderID)
+
+		u := usecase.NewLoanUsecase(new(mocks.LoanRepository), new(mocks.UserRepository), time.Second*2)
+
+		err := u.Create(context.Background(), &loan)
+
+		assert.ErrorIs(t, err, domain.ErrLoanSelf)
+	})
+
+	t.Run("unknown counterparty", func(t *testing.T) {
+		loan := newLoan()
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByID", mock.Anything, borrowerID.Hex()).Return(domain.User{}, mongo.ErrNoDocuments).Once()
+
+		u := usecase.NewLoanUsecase(new(mocks.LoanRepository), mockUserRepository, time.Second*2)
+
+		err := u.Create(context.Background(), &loan)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("deleted counterparty", func(t *testing.T) {
+		loan := newLoan()
+		deletedAt := time.Now()
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByID", mock.Anything, borrowerID.Hex()).Return(domain.User{ID: borrowerID, DeletedAt: &deletedAt}, nil).Once()
+		mockLoanRepository := new(mocks.LoanRepository)
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, mockUserRepository, time.Second*2)
+
+		err := u.Create(context.Background(), &loan)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		mockLoanRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLoanConfirm(t *testing.T) {
+	lenderID := primitive.NewObjectID()
+	borrowerID := primitive.NewObjectID()
+
+	newPendingLoan := func() domain.Loan {
+		loan := newActiveLoan(lenderID, borrowerID)
+		confirmedAt := time.Now()
+		loan.Status = domain.LoanStatusPending
+		loan.LenderConfirmedAt = &confirmedAt
+		return loan
+	}
+
+	t.Run("counterparty activates", func(t *testing.T) {
+		loan := newPendingLoan()
+
+		mockLoanRepository := new(mocks.LoanRepository)
+		mockLoanRepository.On("GetByID", mock.Anything, loan.ID.Hex()).Return(loan, nil).Once()
+		mockLoanRepository.On("UpdateConfirmation", mock.Anything, mock.MatchedBy(func(l *domain.Loan) bool {
+			return l.Status == domain.LoanStatusActive && l.BorrowerConfirmedAt != nil
+		})).Return(nil).Once()
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, new(mocks.UserRepository), time.Second*2)
+
+		confirmed, err := u.Confirm(context.Background(), loan.ID.Hex(), borrowerID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.LoanStatusActive, confirmed.Status)
+		mockLoanRepository.AssertExpectations(t)
+	})
+
+	t.Run("already confirmed", func(t *testing.T) {
+		loan := newPendingLoan()
+
+		mockLoanRepository := new(mocks.LoanRepository)
+		mockLoanRepository.On("GetByID", mock.Anything, loan.ID.Hex()).Return(loan, nil).Once()
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, new(mocks.UserRepository), time.Second*2)
+
+		_, err := u.Confirm(context.Background(), loan.ID.Hex(), lenderID.Hex())
+
+		assert.ErrorIs(t, err, domain.ErrLoanAlreadyConfirmed)
+	})
+
+	t.Run("not a party", func(t *testing.T) {
+		loan := newPendingLoan()
+
+		mockLoanRepository := new(mocks.LoanRepository)
+		mockLoanRepository.On("GetByID", mock.Anything, loan.ID.Hex()).Return(loan, nil).Once()
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, new(mocks.UserRepository), time.Second*2)
+
+		_, err := u.Confirm(context.Background(), loan.ID.Hex(), primitive.NewObjectID().Hex())
+
+		assert.ErrorIs(t, err, domain.ErrLoanNotFound)
+		mockLoanRepository.AssertNotCalled(t, "UpdateConfirmation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not pending", func(t *testing.T) {
+		loan := newActiveLoan(lenderID, borrowerID)
+
+		mockLoanRepository := new(mocks.LoanRepository)
+		mockLoanRepository.On("GetByID", mock.Anything, loan.ID.Hex()).Return(loan, nil).Once()
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, new(mocks.UserRepository), time.Second*2)
+
+		_, err := u.Confirm(context.Background(), loan.ID.Hex(), borrowerID.Hex())
+
+		assert.ErrorIs(t, err, domain.ErrLoanNotPending)
+	})
+}
+
+func TestLoanDecline(t *testing.T) {
+	lenderID := primitive.NewObjectID()
+	borrowerID := primitive.NewObjectID()
+
+	t.Run("success", func(t *testing.T) {
+		loan := newActiveLoan(lenderID, borrowerID)
+		loan.Status = domain.LoanStatusPending
+
+		mockLoanRepository := new(mocks.LoanRepository)
+		mockLoanRepository.On("GetByID", mock.Anything, loan.ID.Hex()).Return(loan, nil).Once()
+		mockLoanRepository.On("UpdateConfirmation", mock.Anything, mock.MatchedBy(func(l *domain.Loan) bool {
+			return l.Status == domain.LoanStatusDeclined
+		})).Return(nil).Once()
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, new(mocks.UserRepository), time.Second*2)
+
+		declined, err := u.Decline(context.Background(), loan.ID.Hex(), borrowerID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.LoanStatusDeclined, declined.Status)
+		mockLoanRepository.AssertExpectations(t)
+	})
+
+	t.Run("not a party", func(t *testing.T) {
+		loan := newActiveLoan(lenderID, borrowerID)
+		loan.Status = domain.LoanStatusPending
+
+		mockLoanRepository := new(mocks.LoanRepository)
+		mockLoanRepository.On("GetByID", mock.Anything, loan.ID.Hex()).Return(loan, nil).Once()
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, new(mocks.UserRepository), time.Second*2)
+
+		_, err := u.Decline(context.Background(), loan.ID.Hex(), primitive.NewObjectID().Hex())
+
+		assert.ErrorIs(t, err, domain.ErrLoanNotFound)
+	})
+
+	t.Run("not pending", func(t *testing.T) {
+		loan := newActiveLoan(lenderID, borrowerID)
+
+		mockLoanRepository := new(mocks.LoanRepository)
+		mockLoanRepository.On("GetByID", mock.Anything, loan.ID.Hex()).Return(loan, nil).Once()
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, new(mocks.UserRepository), time.Second*2)
+
+		_, err := u.Decline(context.Background(), loan.ID.Hex(), borrowerID.Hex())
+
+		assert.ErrorIs(t, err, domain.ErrLoanNotPending)
+	})
+}
+
+func TestLoanAddRepayment(t *testing.T) {
+	lenderID := primitive.NewObjectID()
+	borrowerID := primitive.NewObjectID()
+
+	t.Run("partial", func(t *testing.T) {
+		loan := newActiveLoan(lenderID, borrowerID)
+
+		mockLoanRepository := new(mocks.LoanRepository)
+		mockLoanRepository.On("GetByID", mock.Anything, loan.ID.Hex()).Return(loan, nil).Once()
+		mockLoanRepository.On("AddRepayment", mock.Anything, mock.Anything, mock.MatchedBy(func(r domain.Repayment) bool {
+			return r.Amount == 400 && r.RecordedBy == lenderID && !r.PaidAt.IsZero()
+		}), domain.LoanStatusActive).Return(nil).Once()
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, new(mocks.UserRepository), time.Second*2)
+
+		updated, err := u.AddRepayment(context.Background(), loan.ID.Hex(), lenderID.Hex(), domain.Repayment{Amount: 400})
+
+		assert.NoError(t, err)
+		assert.Len(t, updated.Repayments, 1)
+		mockLoanRepository.AssertExpectations(t)
+	})
+
+	t.Run("final repayment", func(t *testing.T) {
+		loan := newActiveLoan(lenderID, borrowerID)
+
+		mockLoanRepository := new(mocks.LoanRepository)
+		mockLoanRepository.On("GetByID", mock.Anything, loan.ID.Hex()).Return(loan, nil).Once()
+		mockLoanRepository.On("AddRepayment", mock.Anything, mock.Anything, mock.Anything, domain.LoanStatusRepaid).Return(nil).Once()
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, new(mocks.UserRepository), time.Second*2)
+
+		updated, err := u.AddRepayment(context.Background(), loan.ID.Hex(), lenderID.Hex(), domain.Repayment{Amount: 1000})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.LoanStatusRepaid, updated.Status)
+		mockLoanRepository.AssertExpectations(t)
+	})
+
+	t.Run("exceeds balance", func(t *testing.T) {
+		loan := newActiveLoan(lenderID, borrowerID)
+
+		mockLoanRepository := new(mocks.LoanRepository)
+		mockLoanRepository.On("GetByID", mock.Anything, loan.ID.Hex()).Return(loan, nil).Once()
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, new(mocks.UserRepository), time.Second*2)
+
+		_, err := u.AddRepayment(context.Background(), loan.ID.Hex(), lenderID.Hex(), domain.Repayment{Amount: 1001})
+
+		assert.ErrorIs(t, err, domain.ErrRepaymentExceedsBalance)
+		mockLoanRepository.AssertNotCalled(t, "AddRepayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("borrower cannot record", func(t *testing.T) {
+		loan := newActiveLoan(lenderID, borrowerID)
+
+		mockLoanRepository := new(mocks.LoanRepository)
+		mockLoanRepository.On("GetByID", mock.Anything, loan.ID.Hex()).Return(loan, nil).Once()
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, new(mocks.UserRepository), time.Second*2)
+
+		_, err := u.AddRepayment(context.Background(), loan.ID.Hex(), borrowerID.Hex(), domain.Repayment{Amount: 100})
+
+		assert.ErrorIs(t, err, domain.ErrLoanNotLender)
+	})
+
+	t.Run("not a party", func(t *testing.T) {
+		loan := newActiveLoan(lenderID, borrowerID)
+
+		mockLoanRepository := new(mocks.LoanRepository)
+		mockLoanRepository.On("GetByID", mock.Anything, loan.ID.Hex()).Return(loan, nil).Once()
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, new(mocks.UserRepository), time.Second*2)
+
+		_, err := u.AddRepayment(context.Background(), loan.ID.Hex(), primitive.NewObjectID().Hex(), domain.Repayment{Amount: 100})
+
+		assert.ErrorIs(t, err, domain.ErrLoanNotFound)
+	})
+
+	t.Run("not active", func(t *testing.T) {
+		loan := newActiveLoan(lenderID, borrowerID)
+		loan.Status = domain.LoanStatusPending
+
+		mockLoanRepository := new(mocks.LoanRepository)
+		mockLoanRepository.On("GetByID", mock.Anything, loan.ID.Hex()).Return(loan, nil).Once()
+
+		u := usecase.NewLoanUsecase(mockLoanRepository, new(mocks.UserRepository), time.Second*2)
+
+		_, err := u.AddRepayment(context.Background(), loan.ID.Hex(), lenderID.Hex(), domain.Repayment{Amount: 100})
+
+		assert.ErrorIs(t, err, domain.ErrLoanNotActive)
+	})
+}
@@ -2,34 +2,149 @@ package usecase
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type loginUsecase struct {
-	userRepository domain.UserRepository
-	contextTimeout time.Duration
+	userRepository         domain.UserRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	sessionRepository      domain.SessionRepository
+	passwordHasher         domain.PasswordHasher
+	dummyPasswordHash      string
+	loginAttemptStore      domain.LoginAttemptStore
+	throttlePolicy         domain.LoginThrottlePolicy
+	tokenManager           *tokenutil.TokenManager
+	contextTimeout         time.Duration
 }
 
-func NewLoginUsecase(userRepository domain.UserRepository, timeout time.Duration) domain.LoginUsecase {
+func NewLoginUsecase(userRepository domain.UserRepository, refreshTokenRepository domain.RefreshTokenRepository, sessionRepository domain.SessionRepository, passwordHasher domain.PasswordHasher, loginAttemptStore domain.LoginAttemptStore, throttlePolicy domain.LoginThrottlePolicy, tokenManager *tokenutil.TokenManager, timeout time.Duration) domain.LoginUsecase {
+	// dummyPasswordHash is compared against for unknown emails, so that they
+	// take as long to reject as a wrong password.
+	dummyPasswordHash, _ := passwordHasher.Hash("dummy password")
 	return &loginUsecase{
-		userRepository: userRepository,
-		contextTimeout: timeout,
+		userRepository:         userRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		sessionRepository:      sessionRepository,
+		passwordHasher:         passwordHasher,
+		dummyPasswordHash:      dummyPasswordHash,
+		loginAttemptStore:      loginAttemptStore,
+		throttlePolicy:         throttlePolicy,
+		tokenManager:           tokenManager,
+		contextTimeout:         timeout,
 	}
 }
 
-func (lu *loginUsecase) GetUserByEmail(c context.Context, email string) (domain.User, error) {
+func (lu *loginUsecase) Authenticate(c context.Context, email string, password string, clientIP string) (domain.User, error) {
 	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
 	defer cancel()
-	return lu.userRepository.GetByEmail(ctx, email)
+
+	// Counting by email rather than user ID treats unknown emails the same.
+	accountKey := "account:" + strings.ToLower(email)
+	ipKey := "ip:" + clientIP
+	now := time.Now()
+
+	err := lu.checkThrottle(ctx, accountKey, ipKey, now)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	user, err := lu.userRepository.GetByEmail(ctx, email)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return domain.User{}, err
+	}
+
+	hash := user.Password
+	if err == mongo.ErrNoDocuments {
+		hash = lu.dummyPasswordHash
+	}
+	match, needsRehash := lu.passwordHasher.Verify(hash, password)
+	if !match || err == mongo.ErrNoDocuments {
+		failure := &domain.InvalidCredentialsError{}
+		if err == nil {
+			failure.UserID = user.ID.Hex()
+		}
+		for _, key := range []string{accountKey, ipKey} {
+			_, err = lu.loginAttemptStore.RecordFailure(ctx, key, now, lu.throttlePolicy.LockoutDuration)
+			if err != nil {
+				return domain.User{}, err
+			}
+		}
+		return domain.User{}, failure
+	}
+
+	err = lu.loginAttemptStore.Reset(ctx, accountKey)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	// Only told after the password matched, so it does not reveal that the
+	// account exists.
+	if user.DisabledAt != nil {
+		return domain.User{}, domain.ErrAccountDisabled
+	}
+
+	if needsRehash {
+		// The login succeeded either way, the hash is upgraded on a later
+		// login if this fails.
+		rehashed, err := lu.passwordHasher.Hash(password)
+		if err == nil && lu.userRepository.UpdatePassword(ctx, user.ID.Hex(), rehashed) == nil {
+			user.Password = rehashed
+		}
+	}
+
+	return user, nil
+}
+
+// checkThrottle delays attempts on an account progressively after the free
+// attempts and locks it out after the maximum. Addresses are only locked out,
+// as many users may share one.
+func (lu *loginUsecase) checkThrottle(ctx context.Context, accountKey string, ipKey string, now time.Time) error {
+	policy := lu.throttlePolicy
+
+	account, err := lu.loginAttemptStore.Get(ctx, accountKey)
+	if err != nil {
+		return err
+	}
+	ip, err := lu.loginAttemptStore.Get(ctx, ipKey)
+	if err != nil {
+		return err
+	}
+
+	var wait time.Duration
+	if account.Failures >= policy.MaxAccountFailures {
+		wait = policy.LockoutDuration
+	} else if account.Failures > policy.FreeAttempts {
+		wait = policy.BaseDelay << (account.Failures - policy.FreeAttempts - 1)
+		if wait > policy.LockoutDuration || wait <= 0 {
+			wait = policy.LockoutDuration
+		}
+	}
+	retryAfter := account.LastFailureAt.Add(wait).Sub(now)
+
+	if ip.Failures >= policy.MaxIPFailures {
+		ipRetryAfter := ip.LastFailureAt.Add(policy.LockoutDuration).Sub(now)
+		if ipRetryAfter > retryAfter {
+			retryAfter = ipRetryAfter
+		}
+	}
+
+	if retryAfter > 0 {
+		return &domain.LoginLockedError{RetryAfter: retryAfter}
+	}
+	return nil
 }
 
-func (lu *loginUsecase) CreateAccessToken(user *domain.User, secret string, expiry int) (accessToken string, err error) {
-	return tokenutil.CreateAccessToken(user, secret, expiry)
+func (lu *loginUsecase) CreateAccessToken(user *domain.User, sessionID string, secret string, expiry int) (accessToken string, err error) {
+	return lu.tokenManager.CreateAccessToken(user, sessionID, secret, expiry)
 }
 
-func (lu *loginUsecase) CreateRefreshToken(user *domain.User, secret string, expiry int) (refreshToken string, err error) {
-	return tokenutil.CreateRefreshToken(user, secret, expiry)
+func (lu *loginUsecase) CreateRefreshToken(c context.Context, user *domain.User, client domain.SessionClient, secret string, expiry int) (refreshToken string, sessionID string, err error) {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+	return issueRefreshToken(ctx, lu.refreshTokenRepository, lu.sessionRepository, lu.tokenManager, user, "", client, secret, expiry)
 }
@@ -0,0 +1,152 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/memstore"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/passwordutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAuthenticate(t *testing.T) {
+	tokenManager := tokenutil.NewTokenManager("issuer", "audience", 0, nil)
+	policy := domain.LoginThrottlePolicy{
+		FreeAttempts:       3,
+		BaseDelay:          time.Minute,
+		MaxAccountFailures: 5,
+		MaxIPFailures:      20,
+		LockoutDuration:    time.Hour,
+	}
+
+	passwordHasher := passwordutil.NewArgon2idHasher(passwordutil.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1})
+
+	password, err := passwordHasher.Hash("password")
+	assert.NoError(t, err)
+	user := domain.User{
+		ID:       primitive.NewObjectID(),
+		Email:    "test@gmail.com",
+		Password: password,
+	}
+
+	newUsecase := func() domain.LoginUsecase {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+		mockUserRepository.On("GetByEmail", mock.Anything, mock.Anything).Return(domain.User{}, mongo.ErrNoDocuments)
+		return usecase.NewLoginUsecase(mockUserRepository, new(mocks.RefreshTokenRepository), new(mocks.SessionRepository), passwordHasher, memstore.NewLoginAttemptStore(), policy, tokenManager, time.Second*2)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		u := newUsecase()
+
+		result, err := u.Authenticate(context.Background(), user.Email, "password", "127.0.0.1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, result.ID)
+	})
+
+	t.Run("upgrades bcrypt hash", func(t *testing.T) {
+		bcryptPassword, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+		assert.NoError(t, err)
+		legacy := user
+		legacy.Password = string(bcryptPassword)
+
+		var rehashed string
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByEmail", mock.Anything, user.Email).Return(legacy, nil)
+		mockUserRepository.On("UpdatePassword", mock.Anything, user.ID.Hex(), mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+			rehashed = args.String(2)
+		}).Return(nil).Once()
+
+		u := usecase.NewLoginUsecase(mockUserRepository, new(mocks.RefreshTokenRepository), new(mocks.SessionRepository), passwordHasher, memstore.NewLoginAttemptStore(), policy, tokenManager, time.Second*2)
+
+		_, err = u.Authenticate(context.Background(), user.Email, "password", "127.0.0.1")
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(rehashed, "$argon2id$"))
+		match, needsRehash := passwordHasher.Verify(rehashed, "password")
+		assert.True(t, match)
+		assert.False(t, needsRehash)
+
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("unknown email and wrong password", func(t *testing.T) {
+		u := newUsecase()
+
+		_, wrongPassword := u.Authenticate(context.Background(), user.Email, "wrong", "127.0.0.1")
+		_, unknownEmail := u.Authenticate(context.Background(), "other@gmail.com", "password", "127.0.0.1")
+
+		assert.ErrorIs(t, wrongPassword, domain.ErrInvalidCredentials)
+		assert.Equal(t, wrongPassword.Error(), unknownEmail.Error())
+
+		var invalid *domain.InvalidCredentialsError
+		assert.ErrorAs(t, wrongPassword, &invalid)
+		assert.Equal(t, user.ID.Hex(), invalid.UserID)
+		assert.ErrorAs(t, unknownEmail, &invalid)
+		assert.Empty(t, invalid.UserID)
+	})
+
+	t.Run("disabled account", func(t *testing.T) {
+		disabledAt := time.Now()
+		disabled := user
+		disabled.DisabledAt = &disabledAt
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByEmail", mock.Anything, user.Email).Return(disabled, nil)
+
+		u := usecase.NewLoginUsecase(mockUserRepository, new(mocks.RefreshTokenRepository), new(mocks.SessionRepository), passwordHasher, memstore.NewLoginAttemptStore(), policy, tokenManager, time.Second*2)
+
+		_, err := u.Authenticate(context.Background(), user.Email, "wrong", "127.0.0.1")
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+
+		_, err = u.Authenticate(context.Background(), user.Email, "password", "127.0.0.1")
+		assert.ErrorIs(t, err, domain.ErrAccountDisabled)
+	})
+
+	t.Run("progressive delay", func(t *testing.T) {
+		u := newUsecase()
+
+		for i := 0; i < policy.FreeAttempts; i++ {
+			_, err := u.Authenticate(context.Background(), user.Email, "wrong", "127.0.0.1")
+			assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+		}
+
+		// The fourth failure is not delayed yet but the next attempt is, even
+		// with the right password.
+		_, err := u.Authenticate(context.Background(), user.Email, "wrong", "127.0.0.1")
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+
+		_, err = u.Authenticate(context.Background(), user.Email, "password", "127.0.0.1")
+		var locked *domain.LoginLockedError
+		assert.True(t, errors.As(err, &locked))
+		assert.InDelta(t, time.Minute.Seconds(), locked.RetryAfter.Seconds(), 1)
+	})
+
+	t.Run("ip lockout", func(t *testing.T) {
+		u := newUsecase()
+
+		for i := 0; i < policy.MaxIPFailures; i++ {
+			email := fmt.Sprintf("other%d@gmail.com", i)
+			_, err := u.Authenticate(context.Background(), email, "password", "10.0.0.1")
+			assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+		}
+
+		_, err := u.Authenticate(context.Background(), user.Email, "password", "10.0.0.1")
+		assert.ErrorIs(t, err, domain.ErrLoginLocked)
+
+		_, err = u.Authenticate(context.Background(), user.Email, "password", "10.0.0.2")
+		assert.NoError(t, err)
+	})
+}
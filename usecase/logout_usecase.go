@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type logoutUsecase struct {
+	refreshTokenRepository domain.RefreshTokenRepository
+	sessionRepository      domain.SessionRepository
+	revocationStore        domain.TokenRevocationStore
+	tokenManager           *tokenutil.TokenManager
+	contextTimeout         time.Duration
+}
+
+func NewLogoutUsecase(refreshTokenRepository domain.RefreshTokenRepository, sessionRepository domain.SessionRepository, revocationStore domain.TokenRevocationStore, tokenManager *tokenutil.TokenManager, timeout time.Duration) domain.LogoutUsecase {
+	return &logoutUsecase{
+		refreshTokenRepository: refreshTokenRepository,
+		sessionRepository:      sessionRepository,
+		revocationStore:        revocationStore,
+		tokenManager:           tokenManager,
+		contextTimeout:         timeout,
+	}
+}
+
+func (lu *logoutUsecase) ExtractRefreshClaimsFromToken(requestToken string, secret string) (*domain.JwtCustomRefreshClaims, error) {
+	return lu.tokenManager.ValidateRefreshToken(requestToken, secret)
+}
+
+func (lu *logoutUsecase) Logout(c context.Context, userID string, tokenID string, sessionID string, expiresAt time.Time, refreshClaims *domain.JwtCustomRefreshClaims, accessTokenExpiry int) error {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+
+	err := lu.revocationStore.RevokeToken(ctx, tokenID, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	if sessionID == "" && refreshClaims != nil {
+		sessionID = refreshClaims.FamilyID
+	}
+	if sessionID == "" {
+		return nil
+	}
+
+	// Families issued before sessions were recorded have no session record.
+	err = lu.sessionRepository.Revoke(ctx, sessionID, userID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+
+	return revokeSession(ctx, lu.revocationStore, lu.refreshTokenRepository, sessionID, accessTokenExpiry)
+}
+
+// LogoutAll rejects every access token issued to the user so far and revokes
+// all of the user's refresh tokens.
+func (lu *logoutUsecase) LogoutAll(c context.Context, userID string, accessTokenExpiry int) error {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+
+	return revokeAllSessions(ctx, lu.revocationStore, lu.refreshTokenRepository, lu.sessionRepository, userID, accessTokenExpiry)
+}
+
+func revokeAllSessions(ctx context.Context, revocationStore domain.TokenRevocationStore, refreshTokenRepository domain.RefreshTokenRepository, sessionRepository domain.SessionRepository, userID string, accessTokenExpiry int) error {
+	// Tokens issued before the cut-off are rejected. Their issue time has
+	// second precision, so only tokens from later in the current second can
+	// be rejected too, never ones from the next second.
+	now := time.Now()
+	expiresAt := now.Add(time.Hour * time.Duration(accessTokenExpiry))
+	err := revocationStore.RevokeUserTokens(ctx, userID, now, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	err = refreshTokenRepository.RevokeAllForUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return sessionRepository.RevokeAllForUser(ctx, userID)
+}
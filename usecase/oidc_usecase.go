@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/oidcutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type oidcUsecase struct {
+	provider            domain.OIDCProvider
+	oidcStateRepository domain.OIDCStateRepository
+	userRepository      domain.UserRepository
+	contextTimeout      time.Duration
+}
+
+func NewOIDCUsecase(provider domain.OIDCProvider, oidcStateRepository domain.OIDCStateRepository, userRepository domain.UserRepository, timeout time.Duration) domain.OIDCUsecase {
+	return &oidcUsecase{
+		provider:            provider,
+		oidcStateRepository: oidcStateRepository,
+		userRepository:      userRepository,
+		contextTimeout:      timeout,
+	}
+}
+
+func (ou *oidcUsecase) Begin(c context.Context, expiry time.Duration) (string, string, error) {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	state, stateHash, err := tokenutil.NewOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, _, err := tokenutil.NewOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	codeVerifier, _, err := tokenutil.NewOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL, err := ou.provider.AuthCodeURL(ctx, state, nonce, oidcutil.CodeChallenge(codeVerifier))
+	if err != nil {
+		return "", "", err
+	}
+
+	now := time.Now()
+	err = ou.oidcStateRepository.Create(ctx, &domain.OIDCState{
+		ID:           primitive.NewObjectID(),
+		Provider:     ou.provider.Name(),
+		StateHash:    stateHash,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(expiry),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return authURL, state, nil
+}
+
+// Complete signs in the user linked to the provider subject. Otherwise an
+// existing account is only linked if both the provider and we have verified
+// its email, so nobody can take over an account by registering its email
+// first on either side.
+func (ou *oidcUsecase) Complete(c context.Context, state string, code string) (domain.User, error) {
+	ctx, cancel := context.WithTimeout(c, ou.contextTimeout)
+	defer cancel()
+
+	pending, err := ou.oidcStateRepository.Consume(ctx, ou.provider.Name(), tokenutil.HashOpaqueToken(state))
+	if err == mongo.ErrNoDocuments {
+		return domain.User{}, domain.ErrInvalidOIDCState
+	}
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	identity, err := ou.provider.Exchange(ctx, code, pending.CodeVerifier, pending.Nonce)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	user, err := ou.userRepository.GetByIdentity(ctx, ou.provider.Name(), identity.Subject)
+	if err == nil && user.DisabledAt != nil {
+		return domain.User{}, domain.ErrAccountDisabled
+	}
+	if err == nil {
+		return user, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return domain.User{}, err
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return domain.User{}, domain.ErrOIDCEmailNotVerified
+	}
+
+	now := time.Now()
+	link := domain.ExternalIdentity{
+		Provider: ou.provider.Name(),
+		Subject:  identity.Subject,
+		LinkedAt: now,
+	}
+
+	user, err = ou.userRepository.GetByEmail(ctx, identity.Email)
+	if err == nil {
+		if user.EmailVerifiedAt == nil {
+			return domain.User{}, domain.ErrOIDCAccountExists
+		}
+		if user.DisabledAt != nil {
+			return domain.User{}, domain.ErrAccountDisabled
+		}
+		err = ou.userRepository.AddIdentity(ctx, user.ID.Hex(), link)
+		if err != nil {
+			return domain.User{}, err
+		}
+		user.Identities = append(user.Identities, link)
+		return user, nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return domain.User{}, err
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = identity.Email
+	}
+	// Users created here have no password until they reset it.
+	user = domain.User{
+		ID:              primitive.NewObjectID(),
+		Name:            name,
+		Email:           identity.Email,
+		EmailVerifiedAt: &now,
+		Identities:      []domain.ExternalIdentity{link},
+	}
+	err = ou.userRepository.Create(ctx, &user)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,135 @@
+package usecase_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestOIDC(t *testing.T) {
+	identity := domain.OIDCIdentity{Subject: "subject", Email: "test@gmail.com", EmailVerified: true, Name: "Test Name"}
+	pending := domain.OIDCState{Provider: "test", Nonce: "nonce", CodeVerifier: "verifier"}
+
+	newProvider := func(identity domain.OIDCIdentity) *mocks.OIDCProvider {
+		mockProvider := new(mocks.OIDCProvider)
+		mockProvider.On("Name").Return("test")
+		mockProvider.On("Exchange", mock.Anything, "code", "verifier", "nonce").Return(identity, nil)
+		return mockProvider
+	}
+	newStateRepository := func() *mocks.OIDCStateRepository {
+		mockStateRepository := new(mocks.OIDCStateRepository)
+		mockStateRepository.On("Consume", mock.Anything, "test", tokenutil.HashOpaqueToken("state")).Return(pending, nil)
+		return mockStateRepository
+	}
+
+	t.Run("begin", func(t *testing.T) {
+		mockProvider := new(mocks.OIDCProvider)
+		mockProvider.On("Name").Return("test")
+		mockProvider.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(func(c context.Context, state string, nonce string, challenge string) string {
+			return "https://issuer.example.com/authorize?" + url.Values{"state": {state}}.Encode()
+		}, nil)
+
+		var stored *domain.OIDCState
+		mockStateRepository := new(mocks.OIDCStateRepository)
+		mockStateRepository.On("Create", mock.Anything, mock.AnythingOfType("*domain.OIDCState")).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*domain.OIDCState)
+		}).Return(nil).Once()
+
+		u := usecase.NewOIDCUsecase(mockProvider, mockStateRepository, new(mocks.UserRepository), time.Second*2)
+
+		authURL, state, err := u.Begin(context.Background(), 10*time.Minute)
+		assert.NoError(t, err)
+		assert.Contains(t, authURL, "state="+state)
+		assert.Equal(t, tokenutil.HashOpaqueToken(state), stored.StateHash)
+		assert.NotEmpty(t, stored.Nonce)
+		assert.NotEmpty(t, stored.CodeVerifier)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		mockStateRepository := new(mocks.OIDCStateRepository)
+		mockStateRepository.On("Consume", mock.Anything, "test", mock.Anything).Return(domain.OIDCState{}, mongo.ErrNoDocuments)
+
+		u := usecase.NewOIDCUsecase(newProvider(identity), mockStateRepository, new(mocks.UserRepository), time.Second*2)
+
+		_, err := u.Complete(context.Background(), "state", "code")
+		assert.ErrorIs(t, err, domain.ErrInvalidOIDCState)
+	})
+
+	t.Run("linked user", func(t *testing.T) {
+		user := domain.User{ID: primitive.NewObjectID()}
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByIdentity", mock.Anything, "test", "subject").Return(user, nil)
+
+		u := usecase.NewOIDCUsecase(newProvider(identity), newStateRepository(), mockUserRepository, time.Second*2)
+
+		signedIn, err := u.Complete(context.Background(), "state", "code")
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, signedIn.ID)
+	})
+
+	t.Run("links verified account", func(t *testing.T) {
+		verifiedAt := time.Now()
+		user := domain.User{ID: primitive.NewObjectID(), Email: identity.Email, EmailVerifiedAt: &verifiedAt}
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByIdentity", mock.Anything, "test", "subject").Return(domain.User{}, mongo.ErrNoDocuments)
+		mockUserRepository.On("GetByEmail", mock.Anything, identity.Email).Return(user, nil)
+		mockUserRepository.On("AddIdentity", mock.Anything, user.ID.Hex(), mock.AnythingOfType("domain.ExternalIdentity")).Return(nil).Once()
+
+		u := usecase.NewOIDCUsecase(newProvider(identity), newStateRepository(), mockUserRepository, time.Second*2)
+
+		signedIn, err := u.Complete(context.Background(), "state", "code")
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, signedIn.ID)
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("does not link unverified account", func(t *testing.T) {
+		user := domain.User{ID: primitive.NewObjectID(), Email: identity.Email}
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByIdentity", mock.Anything, "test", "subject").Return(domain.User{}, mongo.ErrNoDocuments)
+		mockUserRepository.On("GetByEmail", mock.Anything, identity.Email).Return(user, nil)
+
+		u := usecase.NewOIDCUsecase(newProvider(identity), newStateRepository(), mockUserRepository, time.Second*2)
+
+		_, err := u.Complete(context.Background(), "state", "code")
+		assert.ErrorIs(t, err, domain.ErrOIDCAccountExists)
+	})
+
+	t.Run("unverified provider email", func(t *testing.T) {
+		unverified := identity
+		unverified.EmailVerified = false
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByIdentity", mock.Anything, "test", "subject").Return(domain.User{}, mongo.ErrNoDocuments)
+
+		u := usecase.NewOIDCUsecase(newProvider(unverified), newStateRepository(), mockUserRepository, time.Second*2)
+
+		_, err := u.Complete(context.Background(), "state", "code")
+		assert.ErrorIs(t, err, domain.ErrOIDCEmailNotVerified)
+	})
+
+	t.Run("creates user", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByIdentity", mock.Anything, "test", "subject").Return(domain.User{}, mongo.ErrNoDocuments)
+		mockUserRepository.On("GetByEmail", mock.Anything, identity.Email).Return(domain.User{}, mongo.ErrNoDocuments)
+		mockUserRepository.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil).Once()
+
+		u := usecase.NewOIDCUsecase(newProvider(identity), newStateRepository(), mockUserRepository, time.Second*2)
+
+		user, err := u.Complete(context.Background(), "state", "code")
+		assert.NoError(t, err)
+		assert.Equal(t, identity.Name, user.Name)
+		assert.NotNil(t, user.EmailVerifiedAt)
+		assert.Equal(t, "subject", user.Identities[0].Subject)
+		mockUserRepository.AssertExpectations(t)
+	})
+}
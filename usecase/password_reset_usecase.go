@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type passwordResetUsecase struct {
+	userRepository         domain.UserRepository
+	userTokenRepository    domain.UserTokenRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	sessionRepository      domain.SessionRepository
+	revocationStore        domain.TokenRevocationStore
+	passwordHasher         domain.PasswordHasher
+	passwordPolicy         domain.PasswordPolicy
+	mailer                 domain.Mailer
+	contextTimeout         time.Duration
+}
+
+func NewPasswordResetUsecase(userRepository domain.UserRepository, userTokenRepository domain.UserTokenRepository, refreshTokenRepository domain.RefreshTokenRepository, sessionRepository domain.SessionRepository, revocationStore domain.TokenRevocationStore, passwordHasher domain.PasswordHasher, passwordPolicy domain.PasswordPolicy, mailer domain.Mailer, timeout time.Duration) domain.PasswordResetUsecase {
+	return &passwordResetUsecase{
+		userRepository:         userRepository,
+		userTokenRepository:    userTokenRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		sessionRepository:      sessionRepository,
+		revocationStore:        revocationStore,
+		passwordHasher:         passwordHasher,
+		passwordPolicy:         passwordPolicy,
+		mailer:                 mailer,
+		contextTimeout:         timeout,
+	}
+}
+
+// RequestReset mails a reset link to the user. It allows ipLimitPerHour links
+// per IP address and emailLimitPerHour links per user and hour. Unknown emails
+// and emails over their limit are ignored without an error so that callers
+// cannot probe for registered addresses.
+func (pu *passwordResetUsecase) RequestReset(c context.Context, email string, ip string, resetURL string, expiryMinute int, emailLimitPerHour int, ipLimitPerHour int) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	since := time.Now().Add(-time.Hour)
+	count, err := pu.userTokenRepository.CountByIPSince(ctx, ip, domain.UserTokenPurposePasswordReset, since)
+	if err != nil {
+		return err
+	}
+	if count >= int64(ipLimitPerHour) {
+		return domain.ErrTooManyRequests
+	}
+
+	user, err := pu.userRepository.GetByEmail(ctx, email)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	count, err = pu.userTokenRepository.CountSince(ctx, user.ID.Hex(), domain.UserTokenPurposePasswordReset, since)
+	if err != nil {
+		return err
+	}
+	if count >= int64(emailLimitPerHour) {
+		return nil
+	}
+
+	token, hash, err := tokenutil.NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	userToken := domain.UserToken{
+		ID:        primitive.NewObjectID(),
+		UserID:    user.ID,
+		Purpose:   domain.UserTokenPurposePasswordReset,
+		TokenHash: hash,
+		RequestIP: ip,
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Minute * time.Duration(expiryMinute)),
+	}
+	err = pu.userTokenRepository.Create(ctx, &userToken)
+	if err != nil {
+		return err
+	}
+
+	link := resetURL + "?token=" + url.QueryEscape(token)
+	return pu.mailer.Send(ctx, domain.MailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body:    fmt.Sprintf("Hi %s,\n\nUse the link below to choose a new password. It expires in %d minutes.\n\n%s\n\nIf you did not ask for this, you can ignore this email.\n", user.Name, expiryMinute, link),
+	})
+}
+
+// ResetPassword sets the new password and signs the user out everywhere. The
+// password is checked once before the token is used up, so that most
+// rejections can be retried with the same link, and again with the user's
+// email and name once the token tells who the user is.
+func (pu *passwordResetUsecase) ResetPassword(c context.Context, token string, password string, accessTokenExpiry int) (string, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	err := pu.passwordPolicy.Check(ctx, password, nil)
+	if err != nil {
+		return "", err
+	}
+
+	userToken, err := pu.userTokenRepository.Consume(ctx, domain.UserTokenPurposePasswordReset, tokenutil.HashOpaqueToken(token))
+	if err == mongo.ErrNoDocuments {
+		return "", domain.ErrInvalidResetToken
+	}
+	if err != nil {
+		return "", err
+	}
+
+	userID := userToken.UserID.Hex()
+	user, err := pu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	err = pu.passwordPolicy.Check(ctx, password, &user)
+	if err != nil {
+		return "", err
+	}
+
+	encryptedPassword, err := pu.passwordHasher.Hash(password)
+	if err != nil {
+		return "", err
+	}
+
+	err = pu.userRepository.UpdatePassword(ctx, userID, encryptedPassword)
+	if err != nil {
+		return "", err
+	}
+
+	return userID, revokeAllSessions(ctx, pu.revocationStore, pu.refreshTokenRepository, pu.sessionRepository, userID, accessTokenExpiry)
+}
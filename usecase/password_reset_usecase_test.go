@@ -0,0 +1,163 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/fakeutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/memstore"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/passwordutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestPasswordReset(t *testing.T) {
+	user := domain.User{
+		ID:    primitive.NewObjectID(),
+		Name:  "Test Name",
+		Email: "test@gmail.com",
+	}
+	passwordHasher := passwordutil.NewArgon2idHasher(passwordutil.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1})
+	passwordPolicy := passwordutil.NewPolicy(passwordutil.PolicyParams{MinLength: 8, MaxBytes: passwordutil.BcryptMaxBytes}, nil)
+
+	t.Run("request and reset", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockSessionRepository := new(mocks.SessionRepository)
+		revocationStore := memstore.NewTokenRevocationStore()
+		mailer := fakeutil.NewMailer()
+
+		var stored domain.UserToken
+		mockUserTokenRepository.On("CountByIPSince", mock.Anything, "10.0.0.1", domain.UserTokenPurposePasswordReset, mock.AnythingOfType("time.Time")).Return(int64(0), nil).Once()
+		mockUserRepository.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+		mockUserTokenRepository.On("CountSince", mock.Anything, user.ID.Hex(), domain.UserTokenPurposePasswordReset, mock.AnythingOfType("time.Time")).Return(int64(2), nil).Once()
+		mockUserTokenRepository.On("Create", mock.Anything, mock.AnythingOfType("*domain.UserToken")).Run(func(args mock.Arguments) {
+			stored = *args.Get(1).(*domain.UserToken)
+		}).Return(nil).Once()
+
+		u := usecase.NewPasswordResetUsecase(mockUserRepository, mockUserTokenRepository, mockRefreshTokenRepository, mockSessionRepository, revocationStore, passwordHasher, passwordPolicy, mailer, time.Second*2)
+
+		err := u.RequestReset(context.Background(), user.Email, "10.0.0.1", "http://localhost/reset", 30, 3, 10)
+		assert.NoError(t, err)
+
+		messages := mailer.Messages()
+		assert.Len(t, messages, 1)
+		assert.Equal(t, user.Email, messages[0].To)
+
+		start := strings.Index(messages[0].Body, "?token=") + len("?token=")
+		token := strings.Fields(messages[0].Body[start:])[0]
+		assert.Equal(t, tokenutil.HashOpaqueToken(token), stored.TokenHash)
+		assert.Equal(t, domain.UserTokenPurposePasswordReset, stored.Purpose)
+		assert.Equal(t, "10.0.0.1", stored.RequestIP)
+
+		mockUserTokenRepository.On("Consume", mock.Anything, domain.UserTokenPurposePasswordReset, stored.TokenHash).Return(stored, nil).Once()
+		mockUserRepository.On("GetByID", mock.Anything, user.ID.Hex()).Return(user, nil).Once()
+		mockUserRepository.On("UpdatePassword", mock.Anything, user.ID.Hex(), mock.AnythingOfType("string")).Return(nil).Once()
+		mockRefreshTokenRepository.On("RevokeAllForUser", mock.Anything, user.ID.Hex()).Return(nil).Once()
+		mockSessionRepository.On("RevokeAllForUser", mock.Anything, user.ID.Hex()).Return(nil).Once()
+
+		resetUserID, err := u.ResetPassword(context.Background(), token, "new password", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID.Hex(), resetUserID)
+
+		revoked, err := revocationStore.IsRevoked(context.Background(), "jti", "sid", user.ID.Hex(), time.Now().Add(-time.Minute))
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+
+		mockUserRepository.AssertExpectations(t)
+		mockUserTokenRepository.AssertExpectations(t)
+		mockRefreshTokenRepository.AssertExpectations(t)
+		mockSessionRepository.AssertExpectations(t)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mailer := fakeutil.NewMailer()
+
+		mockUserTokenRepository.On("CountByIPSince", mock.Anything, "10.0.0.1", domain.UserTokenPurposePasswordReset, mock.AnythingOfType("time.Time")).Return(int64(0), nil).Once()
+		mockUserRepository.On("GetByEmail", mock.Anything, "other@gmail.com").Return(domain.User{}, mongo.ErrNoDocuments).Once()
+
+		u := usecase.NewPasswordResetUsecase(mockUserRepository, mockUserTokenRepository, new(mocks.RefreshTokenRepository), new(mocks.SessionRepository), memstore.NewTokenRevocationStore(), passwordHasher, passwordPolicy, mailer, time.Second*2)
+
+		err := u.RequestReset(context.Background(), "other@gmail.com", "10.0.0.1", "http://localhost/reset", 30, 3, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, mailer.Messages())
+
+		mockUserTokenRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("too many requests for the email", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mailer := fakeutil.NewMailer()
+
+		mockUserTokenRepository.On("CountByIPSince", mock.Anything, "10.0.0.1", domain.UserTokenPurposePasswordReset, mock.AnythingOfType("time.Time")).Return(int64(0), nil).Once()
+		mockUserRepository.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+		mockUserTokenRepository.On("CountSince", mock.Anything, user.ID.Hex(), domain.UserTokenPurposePasswordReset, mock.AnythingOfType("time.Time")).Return(int64(3), nil).Once()
+
+		u := usecase.NewPasswordResetUsecase(mockUserRepository, mockUserTokenRepository, new(mocks.RefreshTokenRepository), new(mocks.SessionRepository), memstore.NewTokenRevocationStore(), passwordHasher, passwordPolicy, mailer, time.Second*2)
+
+		// Answered like an unknown email, so that the limit does not tell
+		// which addresses are registered.
+		err := u.RequestReset(context.Background(), user.Email, "10.0.0.1", "http://localhost/reset", 30, 3, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, mailer.Messages())
+
+		mockUserTokenRepository.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("too many requests from the IP", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+		mailer := fakeutil.NewMailer()
+
+		mockUserTokenRepository.On("CountByIPSince", mock.Anything, "10.0.0.1", domain.UserTokenPurposePasswordReset, mock.AnythingOfType("time.Time")).Return(int64(10), nil).Once()
+
+		u := usecase.NewPasswordResetUsecase(mockUserRepository, mockUserTokenRepository, new(mocks.RefreshTokenRepository), new(mocks.SessionRepository), memstore.NewTokenRevocationStore(), passwordHasher, passwordPolicy, mailer, time.Second*2)
+
+		err := u.RequestReset(context.Background(), "other@gmail.com", "10.0.0.1", "http://localhost/reset", 30, 3, 10)
+		assert.ErrorIs(t, err, domain.ErrTooManyRequests)
+		assert.Empty(t, mailer.Messages())
+
+		mockUserRepository.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("used token", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+
+		mockUserTokenRepository.On("Consume", mock.Anything, domain.UserTokenPurposePasswordReset, tokenutil.HashOpaqueToken("token")).Return(domain.UserToken{}, mongo.ErrNoDocuments).Once()
+
+		u := usecase.NewPasswordResetUsecase(mockUserRepository, mockUserTokenRepository, new(mocks.RefreshTokenRepository), new(mocks.SessionRepository), memstore.NewTokenRevocationStore(), passwordHasher, passwordPolicy, fakeutil.NewMailer(), time.Second*2)
+
+		_, err := u.ResetPassword(context.Background(), "token", "new password", 2)
+		assert.ErrorIs(t, err, domain.ErrInvalidResetToken)
+
+		mockUserRepository.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejected password", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserTokenRepository := new(mocks.UserTokenRepository)
+
+		u := usecase.NewPasswordResetUsecase(mockUserRepository, mockUserTokenRepository, new(mocks.RefreshTokenRepository), new(mocks.SessionRepository), memstore.NewTokenRevocationStore(), passwordHasher, passwordPolicy, fakeutil.NewMailer(), time.Second*2)
+
+		_, err := u.ResetPassword(context.Background(), "token", "short", 2)
+		var rejected *domain.PasswordPolicyError
+		assert.True(t, errors.As(err, &rejected))
+		assert.Equal(t, domain.PasswordViolationTooShort, rejected.Violations[0].Code)
+
+		mockUserTokenRepository.AssertNotCalled(t, "Consume", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// lastUsedInterval limits how often use of a token is written back.
+const lastUsedInterval = time.Minute
+
+type personalAccessTokenUsecase struct {
+	personalAccessTokenRepository domain.PersonalAccessTokenRepository
+	contextTimeout                time.Duration
+}
+
+func NewPersonalAccessTokenUsecase(personalAccessTokenRepository domain.PersonalAccessTokenRepository, timeout time.Duration) domain.PersonalAccessTokenUsecase {
+	return &personalAccessTokenUsecase{
+		personalAccessTokenRepository: personalAccessTokenRepository,
+		contextTimeout:                timeout,
+	}
+}
+
+func (pu *personalAccessTokenUsecase) Create(c context.Context, userID string, request *domain.PersonalAccessTokenRequest) (domain.PersonalAccessTokenResponse, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	scopes, err := normalizeScopes(request.Scopes)
+	if err != nil {
+		return domain.PersonalAccessTokenResponse{}, err
+	}
+
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return domain.PersonalAccessTokenResponse{}, err
+	}
+
+	secret, hash, err := tokenutil.NewOpaqueToken()
+	if err != nil {
+		return domain.PersonalAccessTokenResponse{}, err
+	}
+
+	now := time.Now()
+	token := domain.PersonalAccessToken{
+		ID:        primitive.NewObjectID(),
+		UserID:    userObjectID,
+		Name:      request.Name,
+		Scopes:    scopes,
+		TokenHash: hash,
+		CreatedAt: now,
+		ExpiresAt: now.AddDate(0, 0, request.ExpiresInDays),
+	}
+	err = pu.personalAccessTokenRepository.Create(ctx, &token)
+	if err != nil {
+		return domain.PersonalAccessTokenResponse{}, err
+	}
+
+	return domain.PersonalAccessTokenResponse{
+		PersonalAccessToken: token,
+		Token:               domain.PersonalAccessTokenPrefix + secret,
+	}, nil
+}
+
+func (pu *personalAccessTokenUsecase) FetchByUserID(c context.Context, userID string) ([]domain.PersonalAccessToken, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+	return pu.personalAccessTokenRepository.FetchByUserID(ctx, userID)
+}
+
+func (pu *personalAccessTokenUsecase) Revoke(c context.Context, userID string, id string) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	err := pu.personalAccessTokenRepository.Revoke(ctx, id, userID)
+	if err == mongo.ErrNoDocuments || err == primitive.ErrInvalidHex {
+		return domain.ErrPersonalAccessTokenNotFound
+	}
+
+	return err
+}
+
+func (pu *personalAccessTokenUsecase) Authenticate(c context.Context, token string) (domain.PersonalAccessToken, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	secret := strings.TrimPrefix(token, domain.PersonalAccessTokenPrefix)
+	pat, err := pu.personalAccessTokenRepository.GetByHash(ctx, tokenutil.HashOpaqueToken(secret))
+	if err == mongo.ErrNoDocuments {
+		return domain.PersonalAccessToken{}, domain.ErrInvalidPersonalAccessToken
+	}
+	if err != nil {
+		return domain.PersonalAccessToken{}, err
+	}
+
+	now := time.Now()
+	if pat.RevokedAt != nil || now.After(pat.ExpiresAt) {
+		return domain.PersonalAccessToken{}, domain.ErrInvalidPersonalAccessToken
+	}
+
+	if pat.LastUsedAt == nil || now.Sub(*pat.LastUsedAt) > lastUsedInterval {
+		err = pu.personalAccessTokenRepository.UpdateLastUsed(ctx, pat.ID, now)
+		if err != nil {
+			return domain.PersonalAccessToken{}, err
+		}
+	}
+
+	return pat, nil
+}
+
+func normalizeScopes(requested []string) ([]string, error) {
+	var scopes []string
+	seen := make(map[string]bool)
+	for _, scope := range requested {
+		known := false
+		for _, s := range domain.Scopes {
+			known = known || s == scope
+		}
+		if !known {
+			return nil, domain.ErrUnknownScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
@@ -0,0 +1,86 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestPersonalAccessToken(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	t.Run("create", func(t *testing.T) {
+		mockRepository := new(mocks.PersonalAccessTokenRepository)
+
+		var stored *domain.PersonalAccessToken
+		mockRepository.On("Create", mock.Anything, mock.AnythingOfType("*domain.PersonalAccessToken")).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*domain.PersonalAccessToken)
+		}).Return(nil).Once()
+
+		u := usecase.NewPersonalAccessTokenUsecase(mockRepository, time.Second*2)
+
+		response, err := u.Create(context.Background(), userID.Hex(), &domain.PersonalAccessTokenRequest{
+			Name:          "CI",
+			Scopes:        []string{domain.ScopeLoansRead, domain.ScopeLoansRead},
+			ExpiresInDays: 30,
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(response.Token, domain.PersonalAccessTokenPrefix))
+		assert.Equal(t, []string{domain.ScopeLoansRead}, stored.Scopes)
+		assert.Equal(t, tokenutil.HashOpaqueToken(strings.TrimPrefix(response.Token, domain.PersonalAccessTokenPrefix)), stored.TokenHash)
+		assert.Equal(t, userID, stored.UserID)
+
+		mockRepository.AssertExpectations(t)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		u := usecase.NewPersonalAccessTokenUsecase(new(mocks.PersonalAccessTokenRepository), time.Second*2)
+
+		_, err := u.Create(context.Background(), userID.Hex(), &domain.PersonalAccessTokenRequest{
+			Name:          "CI",
+			Scopes:        []string{"admin"},
+			ExpiresInDays: 30,
+		})
+
+		assert.ErrorIs(t, err, domain.ErrUnknownScope)
+	})
+
+	t.Run("authenticate", func(t *testing.T) {
+		now := time.Now()
+		revokedAt := now
+		valid := domain.PersonalAccessToken{ID: primitive.NewObjectID(), UserID: userID, ExpiresAt: now.Add(time.Hour)}
+		expired := domain.PersonalAccessToken{ID: primitive.NewObjectID(), UserID: userID, ExpiresAt: now.Add(-time.Hour)}
+		revoked := domain.PersonalAccessToken{ID: primitive.NewObjectID(), UserID: userID, ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt}
+
+		mockRepository := new(mocks.PersonalAccessTokenRepository)
+		mockRepository.On("GetByHash", mock.Anything, tokenutil.HashOpaqueToken("valid")).Return(valid, nil)
+		mockRepository.On("GetByHash", mock.Anything, tokenutil.HashOpaqueToken("expired")).Return(expired, nil)
+		mockRepository.On("GetByHash", mock.Anything, tokenutil.HashOpaqueToken("revoked")).Return(revoked, nil)
+		mockRepository.On("GetByHash", mock.Anything, mock.Anything).Return(domain.PersonalAccessToken{}, mongo.ErrNoDocuments)
+		mockRepository.On("UpdateLastUsed", mock.Anything, valid.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		u := usecase.NewPersonalAccessTokenUsecase(mockRepository, time.Second*2)
+
+		pat, err := u.Authenticate(context.Background(), "pat_valid")
+		assert.NoError(t, err)
+		assert.Equal(t, valid.ID, pat.ID)
+
+		for _, token := range []string{"pat_expired", "pat_revoked", "pat_unknown"} {
+			_, err = u.Authenticate(context.Background(), token)
+			assert.ErrorIs(t, err, domain.ErrInvalidPersonalAccessToken)
+		}
+
+		mockRepository.AssertExpectations(t)
+	})
+}
@@ -1,21 +1,73 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"time"
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/imageutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/loanutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/walletutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	avatarSize = 256
+	// avatarMaxPixels bounds the memory used to decode an upload.
+	avatarMaxPixels = 40_000_000
 )
 
 type profileUsecase struct {
-	userRepository domain.UserRepository
-	contextTimeout time.Duration
+	userRepository                domain.UserRepository
+	refreshTokenRepository        domain.RefreshTokenRepository
+	sessionRepository             domain.SessionRepository
+	personalAccessTokenRepository domain.PersonalAccessTokenRepository
+	loanRepository                domain.LoanRepository
+	walletEntryRepository         domain.WalletEntryRepository
+	groupDebtRepository           domain.GroupDebtRepository
+	taskRepository                domain.TaskRepository
+	dataExportRepository          domain.DataExportRepository
+	auditRepository               domain.AuditRepository
+	revocationStore               domain.TokenRevocationStore
+	loginAttemptStore             domain.LoginAttemptStore
+	throttlePolicy                domain.LoginThrottlePolicy
+	reauthMaxAge                  time.Duration
+	passwordHasher                domain.PasswordHasher
+	passwordPolicy                domain.PasswordPolicy
+	mailer                        domain.Mailer
+	fileStorage                   domain.FileStorage
+	transactor                    domain.Transactor
+	contextTimeout                time.Duration
 }
 
-func NewProfileUsecase(userRepository domain.UserRepository, timeout time.Duration) domain.ProfileUsecase {
+func NewProfileUsecase(userRepository domain.UserRepository, refreshTokenRepository domain.RefreshTokenRepository, sessionRepository domain.SessionRepository, personalAccessTokenRepository domain.PersonalAccessTokenRepository, loanRepository domain.LoanRepository, walletEntryRepository domain.WalletEntryRepository, groupDebtRepository domain.GroupDebtRepository, taskRepository domain.TaskRepository, dataExportRepository domain.DataExportRepository, auditRepository domain.AuditRepository, revocationStore domain.TokenRevocationStore, loginAttemptStore domain.LoginAttemptStore, throttlePolicy domain.LoginThrottlePolicy, reauthMaxAge time.Duration, passwordHasher domain.PasswordHasher, passwordPolicy domain.PasswordPolicy, mailer domain.Mailer, fileStorage domain.FileStorage, transactor domain.Transactor, timeout time.Duration) domain.ProfileUsecase {
 	return &profileUsecase{
-		userRepository: userRepository,
-		contextTimeout: timeout,
+		userRepository:                userRepository,
+		refreshTokenRepository:        refreshTokenRepository,
+		sessionRepository:             sessionRepository,
+		personalAccessTokenRepository: personalAccessTokenRepository,
+		loanRepository:                loanRepository,
+		walletEntryRepository:         walletEntryRepository,
+		groupDebtRepository:           groupDebtRepository,
+		taskRepository:                taskRepository,
+		dataExportRepository:          dataExportRepository,
+		auditRepository:               auditRepository,
+		revocationStore:               revocationStore,
+		loginAttemptStore:             loginAttemptStore,
+		throttlePolicy:                throttlePolicy,
+		reauthMaxAge:                  reauthMaxAge,
+		passwordHasher:                passwordHasher,
+		passwordPolicy:                passwordPolicy,
+		mailer:                        mailer,
+		fileStorage:                   fileStorage,
+		transactor:                    transactor,
+		contextTimeout:                timeout,
 	}
 }
 
@@ -28,5 +80,384 @@ func (pu *profileUsecase) GetProfileByID(c context.Context, userID string) (*dom
 		return nil, err
 	}
 
-	return &domain.Profile{Name: user.Name, Email: user.Email}, nil
+	return pu.toProfile(&user), nil
+}
+
+func (pu *profileUsecase) UpdateProfile(c context.Context, userID string, request *domain.UpdateProfileRequest) (*domain.Profile, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	err := pu.userRepository.UpdateProfile(ctx, userID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := pu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pu.toProfile(&user), nil
+}
+
+func (pu *profileUsecase) UpdateAvatar(c context.Context, userID string, image io.Reader) (*domain.Profile, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	thumbnail, err := imageutil.Thumbnail(image, avatarSize, avatarMaxPixels)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every upload gets a new key, so that clients and caches never show a
+	// previous avatar under the URL of the new one.
+	suffix := make([]byte, 8)
+	_, err = rand.Read(suffix)
+	if err != nil {
+		return nil, err
+	}
+	key := "avatars/" + userID + "-" + hex.EncodeToString(suffix) + ".jpg"
+
+	err = pu.fileStorage.Save(ctx, key, "image/jpeg", bytes.NewReader(thumbnail))
+	if err != nil {
+		return nil, err
+	}
+
+	return pu.setAvatar(ctx, userID, key)
+}
+
+func (pu *profileUsecase) DeleteAvatar(c context.Context, userID string) (*domain.Profile, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	return pu.setAvatar(ctx, userID, "")
+}
+
+// setAvatar deletes the file of the replaced avatar. A file left behind by a
+// failed delete is only wasted space, so the error is not reported.
+func (pu *profileUsecase) setAvatar(ctx context.Context, userID string, key string) (*domain.Profile, error) {
+	previousKey, err := pu.userRepository.SetAvatar(ctx, userID, key)
+	if err != nil {
+		if key != "" {
+			_ = pu.fileStorage.Delete(ctx, key)
+		}
+		return nil, err
+	}
+	if previousKey != "" {
+		_ = pu.fileStorage.Delete(ctx, previousKey)
+	}
+
+	user, err := pu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pu.toProfile(&user), nil
+}
+
+func (pu *profileUsecase) toProfile(user *domain.User) *domain.Profile {
+	profile := &domain.Profile{
+		Name:        user.Name,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		Currency:    user.Currency,
+		Locale:      user.Locale,
+		Timezone:    user.Timezone,
+	}
+	if user.AvatarKey != "" {
+		profile.AvatarURL = pu.fileStorage.URL(user.AvatarKey)
+	}
+	return profile
+}
+
+func (pu *profileUsecase) ChangePassword(c context.Context, userID string, sessionID string, currentPassword string, newPassword string, accessTokenExpiry int) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	user, err := pu.getWithPassword(ctx, userID, sessionID, currentPassword)
+	if err != nil {
+		return err
+	}
+
+	err = pu.passwordPolicy.Check(ctx, newPassword, &user)
+	if err != nil {
+		return err
+	}
+
+	encryptedPassword, err := pu.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	err = pu.userRepository.UpdatePassword(ctx, userID, encryptedPassword)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := pu.sessionRepository.FetchActiveByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			continue
+		}
+		err = pu.sessionRepository.Revoke(ctx, session.ID, userID)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return err
+		}
+		err = revokeSession(ctx, pu.revocationStore, pu.refreshTokenRepository, session.ID, accessTokenExpiry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pu *profileUsecase) ChangeEmail(c context.Context, userID string, sessionID string, password string, email string) (domain.User, error) {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	user, err := pu.getWithPassword(ctx, userID, sessionID, password)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	_, err = pu.userRepository.GetByEmail(ctx, email)
+	if err == nil {
+		return domain.User{}, domain.ErrEmailTaken
+	}
+	if err != mongo.ErrNoDocuments {
+		return domain.User{}, err
+	}
+
+	err = pu.userRepository.SetPendingEmail(ctx, userID, email)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	// The current address is told about the change in case the account was
+	// taken over. The change is stored at this point, so a failed send is not
+	// reported.
+	_ = pu.mailer.Send(ctx, domain.MailMessage{
+		To:      user.Email,
+		Subject: "Your email is being changed",
+		Body:    fmt.Sprintf("Hi %s,\n\nA change of the email of your account to %s was requested. It takes effect once the new address is confirmed.\n\nIf you did not do this, reset your password and contact support.\n", user.Name, email),
+	})
+
+	user.PendingEmail = email
+	return user, nil
+}
+
+func (pu *profileUsecase) DeleteAccount(c context.Context, userID string, sessionID string, password string, accessTokenExpiry int) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	user, err := pu.getWithPassword(ctx, userID, sessionID, password)
+	if err != nil {
+		return err
+	}
+
+	loans, err := pu.loanRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, loan := range loans {
+		if loan.Status == domain.LoanStatusActive && loanutil.Balance(&loan, now).Remaining > 0 {
+			return domain.ErrOutstandingBalance
+		}
+	}
+
+	entries, err := pu.walletEntryRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if hasWalletBalance(entries, user.ID) {
+		return domain.ErrOutstandingBalance
+	}
+
+	debts, err := pu.groupDebtRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, debt := range debts {
+		if debt.Balance > 0 {
+			return domain.ErrOutstandingBalance
+		}
+	}
+
+	err = pu.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		return pu.anonymize(ctx, userID, loans, now, accessTokenExpiry)
+	})
+	if err != nil {
+		return err
+	}
+
+	pu.deleteAvatar(ctx, &user)
+	return nil
+}
+
+func (pu *profileUsecase) EraseAccount(c context.Context, userID string, sessionID string, password string, accessTokenExpiry int) error {
+	ctx, cancel := context.WithTimeout(c, pu.contextTimeout)
+	defer cancel()
+
+	user, err := pu.getWithPassword(ctx, userID, sessionID, password)
+	if err != nil {
+		return err
+	}
+
+	loans, err := pu.loanRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// The archives are deleted first, so that a failure leaves the account
+	// in place and the erasure can be retried.
+	exports, err := pu.dataExportRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, export := range exports {
+		if export.FileKey != "" {
+			err = pu.fileStorage.Delete(ctx, export.FileKey)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = pu.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err := pu.anonymize(ctx, userID, loans, time.Now(), accessTokenExpiry)
+		if err != nil {
+			return err
+		}
+		err = pu.taskRepository.DeleteAllForUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		err = pu.sessionRepository.DeleteAllForUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		err = pu.dataExportRepository.DeleteAllForUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		return pu.auditRepository.AnonymizeUser(ctx, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	pu.deleteAvatar(ctx, &user)
+	return nil
+}
+
+// hasWalletBalance reports whether a group wallet owes the user or the user
+// owes it. The entries of the user are all that count towards their balance.
+func hasWalletBalance(entries []domain.WalletEntry, userID primitive.ObjectID) bool {
+	byWallet := make(map[primitive.ObjectID][]domain.WalletEntry)
+	for _, entry := range entries {
+		byWallet[entry.WalletID] = append(byWallet[entry.WalletID], entry)
+	}
+
+	for walletID, entries := range byWallet {
+		summary := walletutil.Summarize(&domain.GroupWallet{ID: walletID}, entries)
+		for _, member := range summary.Members {
+			if member.UserID == userID && member.Net != 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// anonymize declines the pending loans, ends every session and token and
+// replaces the user with the tombstone.
+func (pu *profileUsecase) anonymize(ctx context.Context, userID string, loans []domain.Loan, now time.Time, accessTokenExpiry int) error {
+	for _, loan := range loans {
+		if loan.Status != domain.LoanStatusPending {
+			continue
+		}
+		loan.Status = domain.LoanStatusDeclined
+		err := pu.loanRepository.UpdateConfirmation(ctx, &loan)
+		if err != nil && err != domain.ErrLoanNotPending {
+			return err
+		}
+	}
+
+	err := pu.personalAccessTokenRepository.RevokeAllForUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = revokeAllSessions(ctx, pu.revocationStore, pu.refreshTokenRepository, pu.sessionRepository, userID, accessTokenExpiry)
+	if err != nil {
+		return err
+	}
+
+	return pu.userRepository.Anonymize(ctx, userID, now)
+}
+
+// deleteAvatar runs once the account is anonymized. A file left behind by a
+// failed delete is no longer linked to the user, so the error is not reported.
+func (pu *profileUsecase) deleteAvatar(ctx context.Context, user *domain.User) {
+	if user.AvatarKey != "" {
+		_ = pu.fileStorage.Delete(ctx, user.AvatarKey)
+	}
+}
+
+// getWithPassword returns the user if the password is theirs. Wrong passwords
+// are throttled like failed logins. Accounts without a password must instead
+// have signed in to the session within reauthMaxAge.
+func (pu *profileUsecase) getWithPassword(ctx context.Context, userID string, sessionID string, password string) (domain.User, error) {
+	user, err := pu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	now := time.Now()
+	if user.Password == "" {
+		sessions, err := pu.sessionRepository.FetchActiveByUserID(ctx, userID)
+		if err != nil {
+			return domain.User{}, err
+		}
+		for _, session := range sessions {
+			if session.ID == sessionID && now.Sub(session.CreatedAt) <= pu.reauthMaxAge {
+				return user, nil
+			}
+		}
+		return domain.User{}, domain.ErrReauthenticationRequired
+	}
+
+	key := "reauth:" + userID
+	attempts, err := pu.loginAttemptStore.Get(ctx, key)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if attempts.Failures >= pu.throttlePolicy.MaxAccountFailures {
+		retryAfter := attempts.LastFailureAt.Add(pu.throttlePolicy.LockoutDuration).Sub(now)
+		if retryAfter > 0 {
+			return domain.User{}, &domain.LoginLockedError{RetryAfter: retryAfter}
+		}
+	}
+
+	match, _ := pu.passwordHasher.Verify(user.Password, password)
+	if !match {
+		_, err = pu.loginAttemptStore.RecordFailure(ctx, key, now, pu.throttlePolicy.LockoutDuration)
+		if err != nil {
+			return domain.User{}, err
+		}
+		return domain.User{}, domain.ErrIncorrectPassword
+	}
+
+	err = pu.loginAttemptStore.Reset(ctx, key)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	return user, nil
 }
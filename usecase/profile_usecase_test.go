@@ -0,0 +1,362 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/fakeutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/memstore"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/passwordutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/storageutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestAccountChanges(t *testing.T) {
+	passwordHasher := passwordutil.NewArgon2idHasher(passwordutil.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1})
+	passwordPolicy := passwordutil.NewPolicy(passwordutil.PolicyParams{MinLength: 8, MaxBytes: passwordutil.BcryptMaxBytes}, nil)
+	throttlePolicy := domain.LoginThrottlePolicy{MaxAccountFailures: 3, LockoutDuration: time.Minute}
+
+	password, err := passwordHasher.Hash("password")
+	assert.NoError(t, err)
+	user := domain.User{
+		ID:       primitive.NewObjectID(),
+		Name:     "Test Name",
+		Email:    "test@gmail.com",
+		Password: password,
+	}
+	userID := user.ID.Hex()
+
+	type deps struct {
+		users         *mocks.UserRepository
+		refreshTokens *mocks.RefreshTokenRepository
+		sessions      *mocks.SessionRepository
+		accessTokens  *mocks.PersonalAccessTokenRepository
+		loans         *mocks.LoanRepository
+		wallets       *mocks.WalletEntryRepository
+		debts         *mocks.GroupDebtRepository
+		tasks         *mocks.TaskRepository
+		exports       *mocks.DataExportRepository
+		audit         *mocks.AuditRepository
+		revocations   domain.TokenRevocationStore
+		mailer        *fakeutil.Mailer
+		storageDir    string
+	}
+	newUsecase := func() (domain.ProfileUsecase, deps) {
+		d := deps{
+			users:         new(mocks.UserRepository),
+			refreshTokens: new(mocks.RefreshTokenRepository),
+			sessions:      new(mocks.SessionRepository),
+			accessTokens:  new(mocks.PersonalAccessTokenRepository),
+			loans:         new(mocks.LoanRepository),
+			wallets:       new(mocks.WalletEntryRepository),
+			debts:         new(mocks.GroupDebtRepository),
+			tasks:         new(mocks.TaskRepository),
+			exports:       new(mocks.DataExportRepository),
+			audit:         new(mocks.AuditRepository),
+			revocations:   memstore.NewTokenRevocationStore(),
+			mailer:        fakeutil.NewMailer(),
+			storageDir:    t.TempDir(),
+		}
+		d.users.On("GetByID", mock.Anything, userID).Return(user, nil).Maybe()
+		u := usecase.NewProfileUsecase(d.users, d.refreshTokens, d.sessions, d.accessTokens, d.loans, d.wallets, d.debts, d.tasks, d.exports, d.audit, d.revocations, memstore.NewLoginAttemptStore(), throttlePolicy, 10*time.Minute, passwordHasher, passwordPolicy, d.mailer, storageutil.NewLocalStorage(d.storageDir, "/uploads"), fakeutil.NewTransactor(), time.Second*2)
+		return u, d
+	}
+
+	t.Run("update avatar", func(t *testing.T) {
+		u, d := newUsecase()
+		d.users.ExpectedCalls = nil
+
+		img := image.NewRGBA(image.Rect(0, 0, 600, 400))
+		var upload bytes.Buffer
+		assert.NoError(t, png.Encode(&upload, img))
+
+		old := filepath.Join(d.storageDir, "avatars", "old.jpg")
+		assert.NoError(t, os.MkdirAll(filepath.Dir(old), 0o755))
+		assert.NoError(t, os.WriteFile(old, []byte("old"), 0o600))
+
+		var key string
+		d.users.On("SetAvatar", mock.Anything, userID, mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+			key = args.String(2)
+		}).Return("avatars/old.jpg", nil).Once()
+		d.users.On("GetByID", mock.Anything, userID).Return(func(context.Context, string) domain.User {
+			updated := user
+			updated.AvatarKey = key
+			return updated
+		}, nil).Once()
+
+		profile, err := u.UpdateAvatar(context.Background(), userID, &upload)
+		assert.NoError(t, err)
+		assert.Equal(t, "/uploads/"+key, profile.AvatarURL)
+
+		file, err := os.Open(filepath.Join(d.storageDir, filepath.FromSlash(key)))
+		assert.NoError(t, err)
+		defer file.Close()
+		config, format, err := image.DecodeConfig(file)
+		assert.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, 256, config.Width)
+		assert.Equal(t, 256, config.Height)
+
+		_, err = os.Stat(old)
+		assert.True(t, os.IsNotExist(err))
+
+		d.users.AssertExpectations(t)
+	})
+
+	t.Run("update avatar with an invalid image", func(t *testing.T) {
+		u, d := newUsecase()
+
+		_, err := u.UpdateAvatar(context.Background(), userID, strings.NewReader("not an image"))
+		assert.ErrorIs(t, err, domain.ErrInvalidImage)
+
+		d.users.AssertNotCalled(t, "SetAvatar", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("change password revokes other sessions", func(t *testing.T) {
+		u, d := newUsecase()
+		d.users.On("UpdatePassword", mock.Anything, userID, mock.AnythingOfType("string")).Return(nil).Once()
+		d.sessions.On("FetchActiveByUserID", mock.Anything, userID).Return([]domain.Session{{ID: "current"}, {ID: "other"}}, nil).Once()
+		d.sessions.On("Revoke", mock.Anything, "other", userID).Return(nil).Once()
+		d.refreshTokens.On("RevokeFamily", mock.Anything, "other").Return(nil).Once()
+
+		err := u.ChangePassword(context.Background(), userID, "current", "password", "new password", 2)
+		assert.NoError(t, err)
+
+		revoked, err := d.revocations.IsRevoked(context.Background(), "jti", "other", userID, time.Now())
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+		revoked, err = d.revocations.IsRevoked(context.Background(), "jti", "current", userID, time.Now())
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+
+		d.users.AssertExpectations(t)
+		d.sessions.AssertExpectations(t)
+		d.refreshTokens.AssertExpectations(t)
+	})
+
+	t.Run("change password with wrong current password", func(t *testing.T) {
+		u, d := newUsecase()
+
+		err := u.ChangePassword(context.Background(), userID, "current", "wrong", "new password", 2)
+		assert.ErrorIs(t, err, domain.ErrIncorrectPassword)
+
+		d.users.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("change password after too many wrong current passwords", func(t *testing.T) {
+		u, d := newUsecase()
+
+		for i := 0; i < 3; i++ {
+			err := u.ChangePassword(context.Background(), userID, "current", "wrong", "new password", 2)
+			assert.ErrorIs(t, err, domain.ErrIncorrectPassword)
+		}
+
+		err := u.ChangePassword(context.Background(), userID, "current", "password", "new password", 2)
+		var locked *domain.LoginLockedError
+		assert.ErrorAs(t, err, &locked)
+
+		d.users.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("change password to a rejected one", func(t *testing.T) {
+		u, _ := newUsecase()
+
+		err := u.ChangePassword(context.Background(), userID, "current", "password", "short", 2)
+		assert.ErrorIs(t, err, domain.ErrPasswordRejected)
+	})
+
+	t.Run("change email", func(t *testing.T) {
+		u, d := newUsecase()
+		d.users.On("GetByEmail", mock.Anything, "new@gmail.com").Return(domain.User{}, mongo.ErrNoDocuments).Once()
+		d.users.On("SetPendingEmail", mock.Anything, userID, "new@gmail.com").Return(nil).Once()
+
+		updated, err := u.ChangeEmail(context.Background(), userID, "current", "password", "new@gmail.com")
+		assert.NoError(t, err)
+		assert.Equal(t, user.Email, updated.Email)
+		assert.Equal(t, "new@gmail.com", updated.PendingEmail)
+
+		messages := d.mailer.Messages()
+		assert.Len(t, messages, 1)
+		assert.Equal(t, user.Email, messages[0].To)
+
+		d.users.AssertExpectations(t)
+	})
+
+	t.Run("change email to a taken one", func(t *testing.T) {
+		u, d := newUsecase()
+		d.users.On("GetByEmail", mock.Anything, "other@gmail.com").Return(domain.User{ID: primitive.NewObjectID()}, nil).Once()
+
+		_, err := u.ChangeEmail(context.Background(), userID, "current", "password", "other@gmail.com")
+		assert.ErrorIs(t, err, domain.ErrEmailTaken)
+
+		d.users.AssertNotCalled(t, "SetPendingEmail", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	activeLoan := func(repaid int64) domain.Loan {
+		loan := domain.Loan{
+			ID:           primitive.NewObjectID(),
+			LenderID:     primitive.NewObjectID(),
+			BorrowerID:   user.ID,
+			Principal:    1000,
+			Status:       domain.LoanStatusActive,
+			Installments: []domain.Installment{{Number: 1, DueDate: time.Now().AddDate(0, 1, 0), Principal: 1000, Amount: 1000}},
+		}
+		if repaid > 0 {
+			loan.Repayments = []domain.Repayment{{ID: primitive.NewObjectID(), Amount: repaid, PaidAt: time.Now()}}
+		}
+		return loan
+	}
+
+	t.Run("delete account with outstanding balance", func(t *testing.T) {
+		u, d := newUsecase()
+		d.loans.On("FetchByUserID", mock.Anything, userID).Return([]domain.Loan{activeLoan(400)}, nil).Once()
+
+		err := u.DeleteAccount(context.Background(), userID, "current", "password", 2)
+		assert.ErrorIs(t, err, domain.ErrOutstandingBalance)
+
+		d.users.AssertNotCalled(t, "Anonymize", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("delete account with wallet balance", func(t *testing.T) {
+		u, d := newUsecase()
+		walletID := primitive.NewObjectID()
+		d.loans.On("FetchByUserID", mock.Anything, userID).Return([]domain.Loan{}, nil).Once()
+		d.wallets.On("FetchByUserID", mock.Anything, userID).Return([]domain.WalletEntry{
+			{WalletID: walletID, Type: domain.WalletEntryContribution, UserID: user.ID, Amount: 100},
+			{WalletID: walletID, Type: domain.WalletEntryExpense, UserID: primitive.NewObjectID(), Amount: 120, Shares: []domain.WalletShare{
+				{UserID: user.ID, Amount: 60},
+				{UserID: primitive.NewObjectID(), Amount: 60},
+			}},
+		}, nil).Once()
+
+		err := u.DeleteAccount(context.Background(), userID, "current", "password", 2)
+		assert.ErrorIs(t, err, domain.ErrOutstandingBalance)
+
+		d.users.AssertNotCalled(t, "Anonymize", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("delete account with group debt", func(t *testing.T) {
+		u, d := newUsecase()
+		d.loans.On("FetchByUserID", mock.Anything, userID).Return([]domain.Loan{}, nil).Once()
+		d.wallets.On("FetchByUserID", mock.Anything, userID).Return([]domain.WalletEntry{}, nil).Once()
+		d.debts.On("FetchByUserID", mock.Anything, userID).Return([]domain.GroupDebt{
+			{DebtorID: user.ID, CreditorID: primitive.NewObjectID(), Amount: 100, Paid: 100},
+			{DebtorID: primitive.NewObjectID(), CreditorID: user.ID, Amount: 100, Paid: 40, Balance: 60},
+		}, nil).Once()
+
+		err := u.DeleteAccount(context.Background(), userID, "current", "password", 2)
+		assert.ErrorIs(t, err, domain.ErrOutstandingBalance)
+
+		d.users.AssertNotCalled(t, "Anonymize", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("delete account", func(t *testing.T) {
+		u, d := newUsecase()
+		pending := domain.Loan{ID: primitive.NewObjectID(), LenderID: user.ID, BorrowerID: primitive.NewObjectID(), Status: domain.LoanStatusPending}
+		repaid := domain.Loan{ID: primitive.NewObjectID(), LenderID: user.ID, BorrowerID: primitive.NewObjectID(), Status: domain.LoanStatusRepaid}
+		d.loans.On("FetchByUserID", mock.Anything, userID).Return([]domain.Loan{pending, repaid}, nil).Once()
+		walletID := primitive.NewObjectID()
+		d.wallets.On("FetchByUserID", mock.Anything, userID).Return([]domain.WalletEntry{
+			{WalletID: walletID, Type: domain.WalletEntryContribution, UserID: user.ID, Amount: 100},
+			{WalletID: walletID, Type: domain.WalletEntryExpense, UserID: user.ID, Amount: 80, Shares: []domain.WalletShare{{UserID: user.ID, Amount: 80}}},
+			{WalletID: walletID, Type: domain.WalletEntryRefund, UserID: user.ID, Amount: 20},
+		}, nil).Once()
+		d.debts.On("FetchByUserID", mock.Anything, userID).Return([]domain.GroupDebt{
+			{DebtorID: user.ID, CreditorID: primitive.NewObjectID(), Amount: 100, Paid: 100},
+		}, nil).Once()
+		d.loans.On("UpdateConfirmation", mock.Anything, mock.MatchedBy(func(loan *domain.Loan) bool {
+			return loan.ID == pending.ID && loan.Status == domain.LoanStatusDeclined
+		})).Return(nil).Once()
+		d.accessTokens.On("RevokeAllForUser", mock.Anything, userID).Return(nil).Once()
+		d.refreshTokens.On("RevokeAllForUser", mock.Anything, userID).Return(nil).Once()
+		d.sessions.On("RevokeAllForUser", mock.Anything, userID).Return(nil).Once()
+		d.users.On("Anonymize", mock.Anything, userID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		err := u.DeleteAccount(context.Background(), userID, "current", "password", 2)
+		assert.NoError(t, err)
+
+		revoked, err := d.revocations.IsRevoked(context.Background(), "jti", "sid", userID, time.Now().Add(-time.Minute))
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+
+		d.users.AssertExpectations(t)
+		d.loans.AssertExpectations(t)
+		d.wallets.AssertExpectations(t)
+		d.debts.AssertExpectations(t)
+		d.accessTokens.AssertExpectations(t)
+		d.refreshTokens.AssertExpectations(t)
+		d.sessions.AssertExpectations(t)
+	})
+
+	t.Run("erase account with outstanding balance", func(t *testing.T) {
+		u, d := newUsecase()
+		d.loans.On("FetchByUserID", mock.Anything, userID).Return([]domain.Loan{activeLoan(400)}, nil).Once()
+		d.accessTokens.On("RevokeAllForUser", mock.Anything, userID).Return(nil).Once()
+		d.refreshTokens.On("RevokeAllForUser", mock.Anything, userID).Return(nil).Once()
+		d.sessions.On("RevokeAllForUser", mock.Anything, userID).Return(nil).Once()
+		d.users.On("Anonymize", mock.Anything, userID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		d.tasks.On("DeleteAllForUser", mock.Anything, userID).Return(nil).Once()
+		d.sessions.On("DeleteAllForUser", mock.Anything, userID).Return(nil).Once()
+
+		archive := filepath.Join(d.storageDir, "exports", "a.zip")
+		assert.NoError(t, os.MkdirAll(filepath.Dir(archive), 0o755))
+		assert.NoError(t, os.WriteFile(archive, []byte("zip"), 0o600))
+		d.exports.On("FetchByUserID", mock.Anything, userID).Return([]domain.DataExport{{ID: primitive.NewObjectID(), FileKey: "exports/a.zip"}}, nil).Once()
+		d.exports.On("DeleteAllForUser", mock.Anything, userID).Return(nil).Once()
+		d.audit.On("AnonymizeUser", mock.Anything, userID).Return(nil).Once()
+
+		err := u.EraseAccount(context.Background(), userID, "current", "password", 2)
+		assert.NoError(t, err)
+
+		_, err = os.Stat(archive)
+		assert.True(t, os.IsNotExist(err))
+
+		d.users.AssertExpectations(t)
+		d.tasks.AssertExpectations(t)
+		d.sessions.AssertExpectations(t)
+		d.exports.AssertExpectations(t)
+		d.audit.AssertExpectations(t)
+	})
+
+	t.Run("account without password", func(t *testing.T) {
+		u, d := newUsecase()
+		d.users.ExpectedCalls = nil
+		oidcUser := user
+		oidcUser.Password = ""
+		d.users.On("GetByID", mock.Anything, userID).Return(oidcUser, nil).Once()
+		d.users.On("UpdatePassword", mock.Anything, userID, mock.AnythingOfType("string")).Return(nil).Once()
+		d.sessions.On("FetchActiveByUserID", mock.Anything, userID).Return([]domain.Session{{ID: "current", CreatedAt: time.Now().Add(-time.Minute)}}, nil).Twice()
+
+		err := u.ChangePassword(context.Background(), userID, "current", "", "new password", 2)
+		assert.NoError(t, err)
+
+		d.users.AssertExpectations(t)
+	})
+
+	t.Run("account without password signed in long ago", func(t *testing.T) {
+		u, d := newUsecase()
+		d.users.ExpectedCalls = nil
+		oidcUser := user
+		oidcUser.Password = ""
+		d.users.On("GetByID", mock.Anything, userID).Return(oidcUser, nil).Once()
+		d.sessions.On("FetchActiveByUserID", mock.Anything, userID).Return([]domain.Session{{ID: "current", CreatedAt: time.Now().Add(-time.Hour)}}, nil).Once()
+
+		err := u.DeleteAccount(context.Background(), userID, "current", "", 2)
+		assert.ErrorIs(t, err, domain.ErrReauthenticationRequired)
+
+		d.users.AssertNotCalled(t, "Anonymize", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
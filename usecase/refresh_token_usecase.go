@@ -6,17 +6,27 @@ import (
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type refreshTokenUsecase struct {
-	userRepository domain.UserRepository
-	contextTimeout time.Duration
+	userRepository         domain.UserRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	sessionRepository      domain.SessionRepository
+	revocationStore        domain.TokenRevocationStore
+	tokenManager           *tokenutil.TokenManager
+	contextTimeout         time.Duration
 }
 
-func NewRefreshTokenUsecase(userRepository domain.UserRepository, timeout time.Duration) domain.RefreshTokenUsecase {
+func NewRefreshTokenUsecase(userRepository domain.UserRepository, refreshTokenRepository domain.RefreshTokenRepository, sessionRepository domain.SessionRepository, revocationStore domain.TokenRevocationStore, tokenManager *tokenutil.TokenManager, timeout time.Duration) domain.RefreshTokenUsecase {
 	return &refreshTokenUsecase{
-		userRepository: userRepository,
-		contextTimeout: timeout,
+		userRepository:         userRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		sessionRepository:      sessionRepository,
+		revocationStore:        revocationStore,
+		tokenManager:           tokenManager,
+		contextTimeout:         timeout,
 	}
 }
 
@@ -26,14 +36,107 @@ func (rtu *refreshTokenUsecase) GetUserByID(c context.Context, email string) (do
 	return rtu.userRepository.GetByID(ctx, email)
 }
 
-func (rtu *refreshTokenUsecase) CreateAccessToken(user *domain.User, secret string, expiry int) (accessToken string, err error) {
-	return tokenutil.CreateAccessToken(user, secret, expiry)
+func (rtu *refreshTokenUsecase) CreateAccessToken(user *domain.User, sessionID string, secret string, expiry int) (accessToken string, err error) {
+	return rtu.tokenManager.CreateAccessToken(user, sessionID, secret, expiry)
 }
 
-func (rtu *refreshTokenUsecase) CreateRefreshToken(user *domain.User, secret string, expiry int) (refreshToken string, err error) {
-	return tokenutil.CreateRefreshToken(user, secret, expiry)
+func (rtu *refreshTokenUsecase) CreateRefreshToken(c context.Context, user *domain.User, familyID string, client domain.SessionClient, secret string, expiry int) (refreshToken string, err error) {
+	ctx, cancel := context.WithTimeout(c, rtu.contextTimeout)
+	defer cancel()
+	refreshToken, _, err = issueRefreshToken(ctx, rtu.refreshTokenRepository, rtu.sessionRepository, rtu.tokenManager, user, familyID, client, secret, expiry)
+	return refreshToken, err
+}
+
+func (rtu *refreshTokenUsecase) ExtractClaimsFromToken(requestToken string, secret string) (*domain.JwtCustomRefreshClaims, error) {
+	return rtu.tokenManager.ValidateRefreshToken(requestToken, secret)
+}
+
+// Rotate invalidates the presented refresh token. Presenting a token that was
+// already rotated means it leaked, so its whole session is revoked, including
+// the access tokens issued for it.
+func (rtu *refreshTokenUsecase) Rotate(c context.Context, claims *domain.JwtCustomRefreshClaims, accessTokenExpiry int) error {
+	ctx, cancel := context.WithTimeout(c, rtu.contextTimeout)
+	defer cancel()
+
+	ok, err := rtu.refreshTokenRepository.MarkUsed(ctx, claims.Id)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+
+	token, err := rtu.refreshTokenRepository.GetByID(ctx, claims.Id)
+	if err == mongo.ErrNoDocuments {
+		return domain.ErrRefreshTokenNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if token.RevokedAt != nil {
+		return domain.ErrRefreshTokenRevoked
+	}
+
+	// The session may already be revoked, e.g. by an earlier reuse.
+	err = rtu.sessionRepository.Revoke(ctx, token.FamilyID, token.UserID.Hex())
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+
+	err = revokeSession(ctx, rtu.revocationStore, rtu.refreshTokenRepository, token.FamilyID, accessTokenExpiry)
+	if err != nil {
+		return err
+	}
+
+	return domain.ErrRefreshTokenReused
 }
 
-func (rtu *refreshTokenUsecase) ExtractIDFromToken(requestToken string, secret string) (string, error) {
-	return tokenutil.ExtractIDFromToken(requestToken, secret)
+// issueRefreshToken signs a refresh token for the given family and stores its
+// record. An empty familyID starts a new family and session, otherwise the
+// session of the family is marked as seen from the client.
+func issueRefreshToken(ctx context.Context, refreshTokenRepository domain.RefreshTokenRepository, sessionRepository domain.SessionRepository, tokenManager *tokenutil.TokenManager, user *domain.User, familyID string, client domain.SessionClient, secret string, expiry int) (string, string, error) {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour * time.Duration(expiry))
+
+	if familyID == "" {
+		familyID = primitive.NewObjectID().Hex()
+		err := sessionRepository.Create(ctx, &domain.Session{
+			ID:         familyID,
+			UserID:     user.ID,
+			UserAgent:  client.UserAgent,
+			IP:         client.IP,
+			CreatedAt:  now,
+			LastSeenAt: now,
+			ExpiresAt:  expiresAt,
+		})
+		if err != nil {
+			return "", "", err
+		}
+	} else {
+		err := sessionRepository.Touch(ctx, familyID, client, now, expiresAt)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	token := domain.RefreshToken{
+		ID:        primitive.NewObjectID().Hex(),
+		FamilyID:  familyID,
+		UserID:    user.ID,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+
+	refreshToken, err := tokenManager.CreateRefreshToken(user, token.ID, token.FamilyID, secret, expiry)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = refreshTokenRepository.Create(ctx, &token)
+	if err != nil {
+		return "", "", err
+	}
+
+	return refreshToken, familyID, nil
 }
@@ -0,0 +1,126 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/memstore"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestRotate(t *testing.T) {
+	mockUserRepository := new(mocks.UserRepository)
+	tokenManager := tokenutil.NewTokenManager("issuer", "audience", 0, nil)
+
+	claims := &domain.JwtCustomRefreshClaims{
+		ID:       primitive.NewObjectID().Hex(),
+		FamilyID: primitive.NewObjectID().Hex(),
+		StandardClaims: jwt.StandardClaims{
+			Id: primitive.NewObjectID().Hex(),
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("MarkUsed", mock.Anything, claims.Id).Return(true, nil).Once()
+
+		u := usecase.NewRefreshTokenUsecase(mockUserRepository, mockRefreshTokenRepository, new(mocks.SessionRepository), memstore.NewTokenRevocationStore(), tokenManager, time.Second*2)
+
+		err := u.Rotate(context.Background(), claims, 1)
+
+		assert.NoError(t, err)
+
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("reused", func(t *testing.T) {
+		usedAt := time.Now()
+		userID := primitive.NewObjectID()
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("MarkUsed", mock.Anything, claims.Id).Return(false, nil).Once()
+		mockRefreshTokenRepository.On("GetByID", mock.Anything, claims.Id).Return(domain.RefreshToken{
+			ID:       claims.Id,
+			FamilyID: claims.FamilyID,
+			UserID:   userID,
+			UsedAt:   &usedAt,
+		}, nil).Once()
+		mockRefreshTokenRepository.On("RevokeFamily", mock.Anything, claims.FamilyID).Return(nil).Once()
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("Revoke", mock.Anything, claims.FamilyID, userID.Hex()).Return(nil).Once()
+		revocationStore := memstore.NewTokenRevocationStore()
+
+		u := usecase.NewRefreshTokenUsecase(mockUserRepository, mockRefreshTokenRepository, mockSessionRepository, revocationStore, tokenManager, time.Second*2)
+
+		err := u.Rotate(context.Background(), claims, 1)
+
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+
+		revoked, err := revocationStore.IsRevoked(context.Background(), primitive.NewObjectID().Hex(), claims.FamilyID, userID.Hex(), time.Now())
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+
+		mockRefreshTokenRepository.AssertExpectations(t)
+		mockSessionRepository.AssertExpectations(t)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		revokedAt := time.Now()
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("MarkUsed", mock.Anything, claims.Id).Return(false, nil).Once()
+		mockRefreshTokenRepository.On("GetByID", mock.Anything, claims.Id).Return(domain.RefreshToken{
+			ID:        claims.Id,
+			FamilyID:  claims.FamilyID,
+			RevokedAt: &revokedAt,
+		}, nil).Once()
+
+		u := usecase.NewRefreshTokenUsecase(mockUserRepository, mockRefreshTokenRepository, new(mocks.SessionRepository), memstore.NewTokenRevocationStore(), tokenManager, time.Second*2)
+
+		err := u.Rotate(context.Background(), claims, 1)
+
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenRevoked)
+
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("MarkUsed", mock.Anything, claims.Id).Return(false, nil).Once()
+		mockRefreshTokenRepository.On("GetByID", mock.Anything, claims.Id).Return(domain.RefreshToken{}, mongo.ErrNoDocuments).Once()
+
+		u := usecase.NewRefreshTokenUsecase(mockUserRepository, mockRefreshTokenRepository, new(mocks.SessionRepository), memstore.NewTokenRevocationStore(), tokenManager, time.Second*2)
+
+		err := u.Rotate(context.Background(), claims, 1)
+
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenNotFound)
+
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("refresh touches session", func(t *testing.T) {
+		user := &domain.User{ID: primitive.NewObjectID()}
+		client := domain.SessionClient{UserAgent: "test-agent", IP: "192.0.2.1"}
+
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("Create", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil).Once()
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("Touch", mock.Anything, claims.FamilyID, client, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		u := usecase.NewRefreshTokenUsecase(mockUserRepository, mockRefreshTokenRepository, mockSessionRepository, memstore.NewTokenRevocationStore(), tokenManager, time.Second*2)
+
+		_, err := u.CreateRefreshToken(context.Background(), user, claims.FamilyID, client, "secret", 1)
+
+		assert.NoError(t, err)
+
+		mockRefreshTokenRepository.AssertExpectations(t)
+		mockSessionRepository.AssertExpectations(t)
+	})
+}
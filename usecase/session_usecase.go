@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type sessionUsecase struct {
+	sessionRepository      domain.SessionRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	revocationStore        domain.TokenRevocationStore
+	contextTimeout         time.Duration
+}
+
+func NewSessionUsecase(sessionRepository domain.SessionRepository, refreshTokenRepository domain.RefreshTokenRepository, revocationStore domain.TokenRevocationStore, timeout time.Duration) domain.SessionUsecase {
+	return &sessionUsecase{
+		sessionRepository:      sessionRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		revocationStore:        revocationStore,
+		contextTimeout:         timeout,
+	}
+}
+
+func (su *sessionUsecase) FetchByUserID(c context.Context, userID string, currentSessionID string) ([]domain.Session, error) {
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	sessions, err := su.sessionRepository.FetchActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+
+	return sessions, nil
+}
+
+func (su *sessionUsecase) Revoke(c context.Context, userID string, sessionID string, accessTokenExpiry int) error {
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+
+	err := su.sessionRepository.Revoke(ctx, sessionID, userID)
+	if err == mongo.ErrNoDocuments || err == primitive.ErrInvalidHex {
+		return domain.ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return revokeSession(ctx, su.revocationStore, su.refreshTokenRepository, sessionID, accessTokenExpiry)
+}
+
+// revokeSession rejects the access tokens and refresh tokens of a session
+// whose record has already been revoked.
+func revokeSession(ctx context.Context, revocationStore domain.TokenRevocationStore, refreshTokenRepository domain.RefreshTokenRepository, sessionID string, accessTokenExpiry int) error {
+	expiresAt := time.Now().Add(time.Hour * time.Duration(accessTokenExpiry))
+	err := revocationStore.RevokeSessionTokens(ctx, sessionID, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	return refreshTokenRepository.RevokeFamily(ctx, sessionID)
+}
@@ -0,0 +1,68 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/memstore"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestSession(t *testing.T) {
+	userID := primitive.NewObjectID().Hex()
+	sessionID := primitive.NewObjectID().Hex()
+
+	t.Run("fetch marks current session", func(t *testing.T) {
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("FetchActiveByUserID", mock.Anything, userID).Return([]domain.Session{{ID: "other"}, {ID: sessionID}}, nil).Once()
+
+		u := usecase.NewSessionUsecase(mockSessionRepository, new(mocks.RefreshTokenRepository), memstore.NewTokenRevocationStore(), time.Second*2)
+
+		sessions, err := u.FetchByUserID(context.Background(), userID, sessionID)
+
+		assert.NoError(t, err)
+		assert.False(t, sessions[0].Current)
+		assert.True(t, sessions[1].Current)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("Revoke", mock.Anything, sessionID, userID).Return(nil).Once()
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("RevokeFamily", mock.Anything, sessionID).Return(nil).Once()
+		revocationStore := memstore.NewTokenRevocationStore()
+
+		u := usecase.NewSessionUsecase(mockSessionRepository, mockRefreshTokenRepository, revocationStore, time.Second*2)
+
+		err := u.Revoke(context.Background(), userID, sessionID, 2)
+		assert.NoError(t, err)
+
+		revoked, err := revocationStore.IsRevoked(context.Background(), "jti", sessionID, userID, time.Now())
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+
+		revoked, err = revocationStore.IsRevoked(context.Background(), "jti", "other", userID, time.Now())
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+
+		mockSessionRepository.AssertExpectations(t)
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("revoke unknown session", func(t *testing.T) {
+		mockSessionRepository := new(mocks.SessionRepository)
+		mockSessionRepository.On("Revoke", mock.Anything, sessionID, userID).Return(mongo.ErrNoDocuments).Once()
+
+		u := usecase.NewSessionUsecase(mockSessionRepository, new(mocks.RefreshTokenRepository), memstore.NewTokenRevocationStore(), time.Second*2)
+
+		err := u.Revoke(context.Background(), userID, sessionID, 2)
+		assert.ErrorIs(t, err, domain.ErrSessionNotFound)
+	})
+}
@@ -6,24 +6,54 @@ import (
 
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
 	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type signupUsecase struct {
-	userRepository domain.UserRepository
-	contextTimeout time.Duration
+	userRepository         domain.UserRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	sessionRepository      domain.SessionRepository
+	passwordHasher         domain.PasswordHasher
+	passwordPolicy         domain.PasswordPolicy
+	tokenManager           *tokenutil.TokenManager
+	contextTimeout         time.Duration
 }
 
-func NewSignupUsecase(userRepository domain.UserRepository, timeout time.Duration) domain.SignupUsecase {
+func NewSignupUsecase(userRepository domain.UserRepository, refreshTokenRepository domain.RefreshTokenRepository, sessionRepository domain.SessionRepository, passwordHasher domain.PasswordHasher, passwordPolicy domain.PasswordPolicy, tokenManager *tokenutil.TokenManager, timeout time.Duration) domain.SignupUsecase {
 	return &signupUsecase{
-		userRepository: userRepository,
-		contextTimeout: timeout,
+		userRepository:         userRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		sessionRepository:      sessionRepository,
+		passwordHasher:         passwordHasher,
+		passwordPolicy:         passwordPolicy,
+		tokenManager:           tokenManager,
+		contextTimeout:         timeout,
 	}
 }
 
+// Create checks the plain password of the user against the password policy,
+// replaces it with its hash and stores the user.
 func (su *signupUsecase) Create(c context.Context, user *domain.User) error {
 	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
 	defer cancel()
-	return su.userRepository.Create(ctx, user)
+
+	err := su.passwordPolicy.Check(ctx, user.Password, user)
+	if err != nil {
+		return err
+	}
+
+	encryptedPassword, err := su.passwordHasher.Hash(user.Password)
+	if err != nil {
+		return err
+	}
+	user.Password = encryptedPassword
+
+	err = su.userRepository.Create(ctx, user)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrEmailTaken
+	}
+
+	return err
 }
 
 func (su *signupUsecase) GetUserByEmail(c context.Context, email string) (domain.User, error) {
@@ -32,10 +62,12 @@ func (su *signupUsecase) GetUserByEmail(c context.Context, email string) (domain
 	return su.userRepository.GetByEmail(ctx, email)
 }
 
-func (su *signupUsecase) CreateAccessToken(user *domain.User, secret string, expiry int) (accessToken string, err error) {
-	return tokenutil.CreateAccessToken(user, secret, expiry)
+func (su *signupUsecase) CreateAccessToken(user *domain.User, sessionID string, secret string, expiry int) (accessToken string, err error) {
+	return su.tokenManager.CreateAccessToken(user, sessionID, secret, expiry)
 }
 
-func (su *signupUsecase) CreateRefreshToken(user *domain.User, secret string, expiry int) (refreshToken string, err error) {
-	return tokenutil.CreateRefreshToken(user, secret, expiry)
+func (su *signupUsecase) CreateRefreshToken(c context.Context, user *domain.User, client domain.SessionClient, secret string, expiry int) (refreshToken string, sessionID string, err error) {
+	ctx, cancel := context.WithTimeout(c, su.contextTimeout)
+	defer cancel()
+	return issueRefreshToken(ctx, su.refreshTokenRepository, su.sessionRepository, su.tokenManager, user, "", client, secret, expiry)
 }
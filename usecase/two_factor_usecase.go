@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/totputil"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	recoveryCodeCount = 10
+	// maxChallengeFailures is the number of wrong codes after which a login
+	// challenge stops working and the password has to be entered again.
+	maxChallengeFailures = 5
+)
+
+type twoFactorUsecase struct {
+	userRepository    domain.UserRepository
+	loginAttemptStore domain.LoginAttemptStore
+	revocationStore   domain.TokenRevocationStore
+	throttlePolicy    domain.LoginThrottlePolicy
+	tokenManager      *tokenutil.TokenManager
+	contextTimeout    time.Duration
+}
+
+func NewTwoFactorUsecase(userRepository domain.UserRepository, loginAttemptStore domain.LoginAttemptStore, revocationStore domain.TokenRevocationStore, throttlePolicy domain.LoginThrottlePolicy, tokenManager *tokenutil.TokenManager, timeout time.Duration) domain.TwoFactorUsecase {
+	return &twoFactorUsecase{
+		userRepository:    userRepository,
+		loginAttemptStore: loginAttemptStore,
+		revocationStore:   revocationStore,
+		throttlePolicy:    throttlePolicy,
+		tokenManager:      tokenManager,
+		contextTimeout:    timeout,
+	}
+}
+
+// Enroll replaces any pending enrollment with a new secret and recovery
+// codes. The plain recovery codes are only ever returned here.
+func (tu *twoFactorUsecase) Enroll(c context.Context, userID string, issuer string) (domain.TwoFactorEnrollResponse, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	user, err := tu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return domain.TwoFactorEnrollResponse{}, err
+	}
+
+	if user.TwoFactor != nil && user.TwoFactor.EnabledAt != nil {
+		return domain.TwoFactorEnrollResponse{}, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totputil.GenerateSecret()
+	if err != nil {
+		return domain.TwoFactorEnrollResponse{}, err
+	}
+
+	salt, err := newRecoverySalt()
+	if err != nil {
+		return domain.TwoFactorEnrollResponse{}, err
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		codes[i], err = newRecoveryCode()
+		if err != nil {
+			return domain.TwoFactorEnrollResponse{}, err
+		}
+		hashes[i] = hashRecoveryCode(salt, codes[i])
+	}
+
+	err = tu.userRepository.SetTwoFactor(ctx, userID, &domain.TwoFactor{
+		Secret:        secret,
+		RecoverySalt:  salt,
+		RecoveryCodes: hashes,
+	})
+	if err != nil {
+		return domain.TwoFactorEnrollResponse{}, err
+	}
+
+	return domain.TwoFactorEnrollResponse{
+		Secret:        secret,
+		URI:           totputil.URI(issuer, user.Email, secret),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (tu *twoFactorUsecase) Enable(c context.Context, userID string, code string) error {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	user, err := tu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.TwoFactor == nil {
+		return domain.ErrTwoFactorNotEnrolled
+	}
+	if user.TwoFactor.EnabledAt != nil {
+		return domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := totputil.Validate(user.TwoFactor.Secret, code, time.Now(), 1)
+	if !ok {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	now := time.Now()
+	twoFactor := *user.TwoFactor
+	twoFactor.EnabledAt = &now
+	twoFactor.LastStep = step
+	return tu.userRepository.SetTwoFactor(ctx, userID, &twoFactor)
+}
+
+func (tu *twoFactorUsecase) Disable(c context.Context, userID string, code string) error {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	user, err := tu.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.TwoFactor == nil || user.TwoFactor.EnabledAt == nil {
+		return domain.ErrTwoFactorNotEnabled
+	}
+
+	now := time.Now()
+	accountKey := "2fa:" + userID
+	err = tu.checkLockout(ctx, accountKey, now)
+	if err != nil {
+		return err
+	}
+
+	err = tu.verifyCode(ctx, &user, code)
+	if err == domain.ErrInvalidTwoFactorCode {
+		_, err := tu.loginAttemptStore.RecordFailure(ctx, accountKey, now, tu.throttlePolicy.LockoutDuration)
+		if err != nil {
+			return err
+		}
+		return domain.ErrInvalidTwoFactorCode
+	}
+	if err != nil {
+		return err
+	}
+
+	err = tu.userRepository.SetTwoFactor(ctx, userID, nil)
+	if err != nil {
+		return err
+	}
+
+	return tu.loginAttemptStore.Reset(ctx, accountKey)
+}
+
+func (tu *twoFactorUsecase) CreateChallengeToken(user *domain.User, secret string, expiryMinute int) (string, error) {
+	return tu.tokenManager.CreateChallengeToken(user, secret, expiryMinute)
+}
+
+// VerifyChallenge accepts each challenge once. Wrong codes are counted per
+// challenge, which stops working after maxChallengeFailures, and per account,
+// which is then locked out like after failed passwords.
+func (tu *twoFactorUsecase) VerifyChallenge(c context.Context, challengeToken string, code string, secret string) (domain.User, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	claims, err := tu.tokenManager.ValidateChallengeToken(challengeToken, secret)
+	if err != nil {
+		return domain.User{}, domain.ErrInvalidChallengeToken
+	}
+
+	// Ending all sessions of the user also ends their pending challenges.
+	revoked, err := tu.revocationStore.IsRevoked(ctx, claims.Id, "", claims.ID, time.Unix(claims.IssuedAt, 0))
+	if err != nil {
+		return domain.User{}, err
+	}
+	if revoked {
+		return domain.User{}, domain.ErrInvalidChallengeToken
+	}
+
+	now := time.Now()
+	accountKey := "2fa:" + claims.ID
+	err = tu.checkLockout(ctx, accountKey, now)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	user, err := tu.userRepository.GetByID(ctx, claims.ID)
+	if err == mongo.ErrNoDocuments {
+		return domain.User{}, domain.ErrInvalidChallengeToken
+	}
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	if user.DeletedAt != nil || user.TwoFactor == nil || user.TwoFactor.EnabledAt == nil {
+		return domain.User{}, domain.ErrInvalidChallengeToken
+	}
+	if user.DisabledAt != nil {
+		return domain.User{}, domain.ErrAccountDisabled
+	}
+
+	expiresAt := time.Unix(claims.ExpiresAt, 0)
+	err = tu.verifyCode(ctx, &user, code)
+	if err == domain.ErrInvalidTwoFactorCode {
+		challenge, err := tu.loginAttemptStore.RecordFailure(ctx, "challenge:"+claims.Id, now, tu.throttlePolicy.LockoutDuration)
+		if err != nil {
+			return domain.User{}, err
+		}
+		if challenge.Failures >= maxChallengeFailures {
+			err = tu.revocationStore.RevokeToken(ctx, claims.Id, expiresAt)
+			if err != nil {
+				return domain.User{}, err
+			}
+		}
+		_, err = tu.loginAttemptStore.RecordFailure(ctx, accountKey, now, tu.throttlePolicy.LockoutDuration)
+		if err != nil {
+			return domain.User{}, err
+		}
+		return domain.User{}, domain.ErrInvalidTwoFactorCode
+	}
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	err = tu.revocationStore.RevokeToken(ctx, claims.Id, expiresAt)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	err = tu.loginAttemptStore.Reset(ctx, accountKey)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	return user, nil
+}
+
+// checkLockout fails with a LoginLockedError while the account is locked out
+// after too many wrong codes.
+func (tu *twoFactorUsecase) checkLockout(ctx context.Context, accountKey string, now time.Time) error {
+	account, err := tu.loginAttemptStore.Get(ctx, accountKey)
+	if err != nil {
+		return err
+	}
+	if account.Failures >= tu.throttlePolicy.MaxAccountFailures {
+		retryAfter := account.LastFailureAt.Add(tu.throttlePolicy.LockoutDuration).Sub(now)
+		if retryAfter > 0 {
+			return &domain.LoginLockedError{RetryAfter: retryAfter}
+		}
+	}
+	return nil
+}
+
+// verifyCode accepts each TOTP step once, and otherwise consumes a matching
+// recovery code.
+func (tu *twoFactorUsecase) verifyCode(ctx context.Context, user *domain.User, code string) error {
+	userID := user.ID.Hex()
+
+	step, ok := totputil.Validate(user.TwoFactor.Secret, code, time.Now(), 1)
+	if ok {
+		used, err := tu.userRepository.UseTwoFactorStep(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return domain.ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	used, err := tu.userRepository.UseRecoveryCode(ctx, userID, hashRecoveryCode(user.TwoFactor.RecoverySalt, code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return domain.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 5)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(b))
+	return code[:4] + "-" + code[4:], nil
+}
+
+// newRecoverySalt returns the salt of the recovery codes of a user, so that
+// their hashes cannot be looked up in a table computed for all users.
+func newRecoverySalt() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashRecoveryCode(salt string, code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	return tokenutil.HashOpaqueToken(salt + code)
+}
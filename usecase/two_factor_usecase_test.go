@@ -0,0 +1,214 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/domain/mocks"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/memstore"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/tokenutil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/internal/totputil"
+	"github.com/amitshekhariitbhu/go-backend-clean-architecture/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTwoFactor(t *testing.T) {
+	tokenManager := tokenutil.NewTokenManager("issuer", "audience", 0, nil)
+	user := domain.User{
+		ID:    primitive.NewObjectID(),
+		Name:  "Test Name",
+		Email: "test@gmail.com",
+	}
+	userID := user.ID.Hex()
+
+	t.Run("enroll and enable", func(t *testing.T) {
+		mockUserRepository := new(mocks.UserRepository)
+
+		var stored *domain.TwoFactor
+		mockUserRepository.On("GetByID", mock.Anything, userID).Return(user, nil).Once()
+		mockUserRepository.On("SetTwoFactor", mock.Anything, userID, mock.AnythingOfType("*domain.TwoFactor")).Run(func(args mock.Arguments) {
+			stored = args.Get(2).(*domain.TwoFactor)
+		}).Return(nil).Twice()
+
+		u := newTwoFactorUsecase(mockUserRepository)
+
+		response, err := u.Enroll(context.Background(), userID, "Debt Helper")
+		assert.NoError(t, err)
+		assert.Contains(t, response.URI, "otpauth://totp/")
+		assert.Len(t, response.RecoveryCodes, 10)
+		assert.Equal(t, response.Secret, stored.Secret)
+		assert.Nil(t, stored.EnabledAt)
+		assert.NotEmpty(t, stored.RecoverySalt)
+		assert.NotContains(t, stored.RecoveryCodes, response.RecoveryCodes[0])
+		assert.NotContains(t, stored.RecoveryCodes, tokenutil.HashOpaqueToken(strings.ReplaceAll(response.RecoveryCodes[0], "-", "")))
+
+		enrolled := user
+		enrolled.TwoFactor = stored
+		mockUserRepository.On("GetByID", mock.Anything, userID).Return(enrolled, nil).Once()
+
+		err = u.Enable(context.Background(), userID, "000000")
+		assert.ErrorIs(t, err, domain.ErrInvalidTwoFactorCode)
+
+		mockUserRepository.On("GetByID", mock.Anything, userID).Return(enrolled, nil).Once()
+
+		code, err := totputil.Code(response.Secret, time.Now())
+		assert.NoError(t, err)
+		err = u.Enable(context.Background(), userID, code)
+		assert.NoError(t, err)
+		assert.NotNil(t, stored.EnabledAt)
+
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("challenge", func(t *testing.T) {
+		secret, err := totputil.GenerateSecret()
+		assert.NoError(t, err)
+
+		enabledAt := time.Now()
+		enabled := user
+		enabled.TwoFactor = &domain.TwoFactor{Secret: secret, EnabledAt: &enabledAt, RecoverySalt: "salt"}
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByID", mock.Anything, userID).Return(enabled, nil)
+
+		u := newTwoFactorUsecase(mockUserRepository)
+
+		challenge, err := u.CreateChallengeToken(&enabled, "secret", 5)
+		assert.NoError(t, err)
+
+		code, err := totputil.Code(secret, time.Now())
+		assert.NoError(t, err)
+
+		mockUserRepository.On("UseTwoFactorStep", mock.Anything, userID, mock.AnythingOfType("int64")).Return(true, nil).Once()
+		result, err := u.VerifyChallenge(context.Background(), challenge, code, "secret")
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, result.ID)
+
+		// A challenge can only be answered once.
+		_, err = u.VerifyChallenge(context.Background(), challenge, code, "secret")
+		assert.ErrorIs(t, err, domain.ErrInvalidChallengeToken)
+
+		// The same code cannot be replayed.
+		challenge, err = u.CreateChallengeToken(&enabled, "secret", 5)
+		assert.NoError(t, err)
+		mockUserRepository.On("UseTwoFactorStep", mock.Anything, userID, mock.AnythingOfType("int64")).Return(false, nil).Once()
+		_, err = u.VerifyChallenge(context.Background(), challenge, code, "secret")
+		assert.ErrorIs(t, err, domain.ErrInvalidTwoFactorCode)
+
+		mockUserRepository.On("UseRecoveryCode", mock.Anything, userID, tokenutil.HashOpaqueToken("saltabcdefgh")).Return(true, nil).Once()
+		_, err = u.VerifyChallenge(context.Background(), challenge, "ABCD-EFGH", "secret")
+		assert.NoError(t, err)
+
+		_, err = u.VerifyChallenge(context.Background(), challenge, code, "other")
+		assert.ErrorIs(t, err, domain.ErrInvalidChallengeToken)
+
+		access, err := tokenManager.CreateAccessToken(&enabled, "sid", "secret", 1)
+		assert.NoError(t, err)
+		_, err = u.VerifyChallenge(context.Background(), access, code, "secret")
+		assert.ErrorIs(t, err, domain.ErrInvalidChallengeToken)
+
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("wrong codes", func(t *testing.T) {
+		secret, err := totputil.GenerateSecret()
+		assert.NoError(t, err)
+
+		enabledAt := time.Now()
+		enabled := user
+		enabled.TwoFactor = &domain.TwoFactor{Secret: secret, EnabledAt: &enabledAt}
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByID", mock.Anything, userID).Return(enabled, nil)
+		mockUserRepository.On("UseRecoveryCode", mock.Anything, userID, mock.Anything).Return(false, nil)
+
+		u := newTwoFactorUsecase(mockUserRepository)
+
+		challenge, err := u.CreateChallengeToken(&enabled, "secret", 5)
+		assert.NoError(t, err)
+		for i := 0; i < 5; i++ {
+			_, err = u.VerifyChallenge(context.Background(), challenge, "ABCD-EFGH", "secret")
+			assert.ErrorIs(t, err, domain.ErrInvalidTwoFactorCode)
+		}
+
+		// The challenge stops working after five wrong codes.
+		_, err = u.VerifyChallenge(context.Background(), challenge, "ABCD-EFGH", "secret")
+		assert.ErrorIs(t, err, domain.ErrInvalidChallengeToken)
+
+		// New challenges keep counting towards the account lockout.
+		challenge, err = u.CreateChallengeToken(&enabled, "secret", 5)
+		assert.NoError(t, err)
+		for i := 0; i < 5; i++ {
+			_, err = u.VerifyChallenge(context.Background(), challenge, "ABCD-EFGH", "secret")
+			assert.ErrorIs(t, err, domain.ErrInvalidTwoFactorCode)
+		}
+
+		challenge, err = u.CreateChallengeToken(&enabled, "secret", 5)
+		assert.NoError(t, err)
+		_, err = u.VerifyChallenge(context.Background(), challenge, "ABCD-EFGH", "secret")
+		var locked *domain.LoginLockedError
+		assert.ErrorAs(t, err, &locked)
+	})
+
+	t.Run("disable with wrong codes", func(t *testing.T) {
+		secret, err := totputil.GenerateSecret()
+		assert.NoError(t, err)
+
+		enabledAt := time.Now()
+		enabled := user
+		enabled.TwoFactor = &domain.TwoFactor{Secret: secret, EnabledAt: &enabledAt, RecoverySalt: "salt"}
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByID", mock.Anything, userID).Return(enabled, nil)
+		mockUserRepository.On("UseRecoveryCode", mock.Anything, userID, mock.Anything).Return(false, nil)
+
+		u := newTwoFactorUsecase(mockUserRepository)
+
+		for i := 0; i < 10; i++ {
+			err = u.Disable(context.Background(), userID, "ABCD-EFGH")
+			assert.ErrorIs(t, err, domain.ErrInvalidTwoFactorCode)
+		}
+
+		// Wrong codes lock out the login challenges as well.
+		err = u.Disable(context.Background(), userID, "ABCD-EFGH")
+		var locked *domain.LoginLockedError
+		assert.ErrorAs(t, err, &locked)
+
+		challenge, err := u.CreateChallengeToken(&enabled, "secret", 5)
+		assert.NoError(t, err)
+		_, err = u.VerifyChallenge(context.Background(), challenge, "ABCD-EFGH", "secret")
+		assert.ErrorAs(t, err, &locked)
+
+		mockUserRepository.AssertNotCalled(t, "SetTwoFactor", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("disabled account", func(t *testing.T) {
+		enabledAt := time.Now()
+		disabled := user
+		disabled.TwoFactor = &domain.TwoFactor{Secret: "secret", EnabledAt: &enabledAt}
+		disabled.DisabledAt = &enabledAt
+
+		mockUserRepository := new(mocks.UserRepository)
+		mockUserRepository.On("GetByID", mock.Anything, userID).Return(disabled, nil).Once()
+
+		u := newTwoFactorUsecase(mockUserRepository)
+
+		challenge, err := u.CreateChallengeToken(&disabled, "secret", 5)
+		assert.NoError(t, err)
+		_, err = u.VerifyChallenge(context.Background(), challenge, "123456", "secret")
+		assert.ErrorIs(t, err, domain.ErrAccountDisabled)
+
+		mockUserRepository.AssertExpectations(t)
+	})
+}
+
+func newTwoFactorUsecase(userRepository domain.UserRepository) domain.TwoFactorUsecase {
+	policy := domain.LoginThrottlePolicy{MaxAccountFailures: 10, LockoutDuration: time.Minute}
+	tokenManager := tokenutil.NewTokenManager("issuer", "audience", 0, nil)
+	return usecase.NewTwoFactorUsecase(userRepository, memstore.NewLoginAttemptStore(), memstore.NewTokenRevocationStore(), policy, tokenManager, time.Second*2)
+}